@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestAngleFlag tests trigonometry with the angle units selected by -angle
+func TestAngleFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"sin_degrees", []string{"-angle=deg", "-op=sin", "180"}, "sin(180) = 0.00", "", exitOK},
+		{"cos_gradians", []string{"-angle=grad", "-op=cos", "200"}, "cos(200) = -1.00", "", exitOK},
+		{"sin_turns", []string{"-angle=turn", "-op=sin", "0.25"}, "sin(0.25) = 1.00", "", exitOK},
+		{"tan_degrees", []string{"-angle=deg", "-op=tan", "45"}, "tan(45) = 1.00", "", exitOK},
+		{"tan_large_argument", []string{"-op=tan", "2e12"}, "tan(2e12) = -3.83", "", exitOK},
+		{"tan_undefined", []string{"-angle=deg", "-op=tan", "90"}, "", "Error performing tangent: undefined result", exitDomain},
+		{"asin_degrees", []string{"-angle=deg", "-op=asin", "0.5"}, "asin(0.5) = 30.00", "", exitOK},
+		{"acos_radians", []string{"-op=acos", "--", "-1"}, "acos(-1) = 3.14", "", exitOK},
+		{"acos_out_of_range", []string{"-angle=deg", "-op=acos", "2"}, "", "Error performing arccosine: undefined result", exitDomain},
+		{"atan_degrees", []string{"-angle=deg", "-op=atan", "1"}, "atan(1) = 45.00", "", exitOK},
+		{"atan2_degrees", []string{"-angle=deg", "-op=atan2", "1", "-1"}, "atan2(1, -1) = 135.00", "", exitOK},
+		{"expression", []string{"-angle=deg", "-e", "sin(30) + acos(0)"}, "sin(30) + acos(0) = 90.50", "", exitOK},
+		{"expression_tan_undefined", []string{"-angle=deg", "-e", "tan(270)"}, "", "undefined result", exitDomain},
+		{"integrate_degrees", []string{"-angle=deg", "integrate", "cos(x)", "0", "90"}, "= 57.30", "", exitOK},
+		{"unknown_unit", []string{"-angle=degrees", "-op=sin", "1"}, "", `unknown angle unit "degrees"`, exitUsage},
+		{"combined_backend", []string{"-angle=deg", "-exact", "-op=add", "1", "2"}, "", "-angle cannot be combined", exitUsage},
+		{"missing_args", []string{"-op=atan2", "1"}, "Usage:", "", exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
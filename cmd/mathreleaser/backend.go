@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// backend is a kind of number -op calculates with. float64 is the default,
+// and the others are selected with a flag, except that an operation
+// float64 lacks runs on the integer or currency backend without one.
+type backend struct {
+	// name identifies the backend in -list, e.g. "exact".
+	name string
+	// flag selects the backend, e.g. "-exact".
+	flag string
+	// flags shows the flags of the backend in usage text.
+	flags string
+	// with ends the error for an operation the backend lacks, as in
+	// "Operation sqrt is not supported with -exact".
+	with string
+	// operand names plain operands in usage text, e.g. "number".
+	operand string
+	// angle is set if unary operations take -angle.
+	angle bool
+	// supports reports whether the backend implements op.
+	supports func(op backendOperation) bool
+	// run performs op, which the backend supports, on textual operands. It
+	// returns the same as calculate.
+	run func(op backendOperation, args []string) (opResult, string, error)
+}
+
+// backends lists every backend, in the order of usage text and -list.
+var backends = []*backend{floatBackend, integerBackend, preciseBackend, exactBackend, complexBackend, decimalBackend, currencyBackend}
+
+// defaultBackends are tried in order for an operation when no backend flag
+// is given.
+var defaultBackends = []*backend{floatBackend, integerBackend, currencyBackend}
+
+// activeBackend is the backend selected with a flag, or nil if none was.
+var activeBackend *backend
+
+// backendOperation is an entry of backendOperations: an operation with its
+// implementations on the backends other than float64, which uses Func.
+type backendOperation struct {
+	calculator.Operation
+	// operands names the operands in usage text when they are not all
+	// plain numbers, e.g. "<n> <k>".
+	operands string
+	precise  func(args []calculator.Number) (calculator.Number, error)
+	exact    func(args []calculator.Rational) (calculator.Rational, error)
+	// complex returns the result for JSON and YAML output and its text
+	// form, since some complex operations have real results.
+	complex func(args []complex128) (interface{}, string, error)
+	decimal func(c *decimalContext, args []calculator.Decimal) (calculator.Decimal, error)
+	// integer returns the result for JSON and YAML output and its text
+	// form.
+	integer func(args []calculator.Integer) (interface{}, string, error)
+	// convert performs the operation on its textual operands, and returns
+	// the same as calculate.
+	convert func(args []string) (opResult, string, error)
+}
+
+// lookupOperation returns the operation -op names, and whether there is
+// one. Operations of the float64 registry take their description from it.
+func lookupOperation(name string) (backendOperation, bool) {
+	if op, ok := operations.Lookup(name); ok {
+		return withBackends(op), true
+	}
+	for _, op := range backendOperations {
+		if op.Arity > 0 && (op.Name == name || containsString(op.Aliases, name)) {
+			return op, true
+		}
+	}
+	return backendOperation{}, false
+}
+
+// withBackends returns the registry operation op with its implementations
+// from backendOperations.
+func withBackends(op calculator.Operation) backendOperation {
+	for _, entry := range backendOperations {
+		if entry.Name == op.Name {
+			entry.Operation = op
+			return entry
+		}
+	}
+	return backendOperation{Operation: op}
+}
+
+// allOperations returns every operation -op accepts: those of the float64
+// registry in registration order, then those only other backends have.
+func allOperations() []backendOperation {
+	var ops []backendOperation
+	for _, op := range operations.Operations() {
+		ops = append(ops, withBackends(op))
+	}
+	for _, op := range backendOperations {
+		if _, taken := operations.Lookup(op.Name); op.Arity > 0 && !taken {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// containsString reports whether values includes v.
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// backendFor returns the backend to perform op on: the active backend, or
+// the first default backend supporting op.
+func backendFor(op backendOperation) (*backend, error) {
+	if activeBackend != nil {
+		if !activeBackend.supports(op) {
+			return nil, &errorResult{Code: exitUsage, Message: fmt.Sprintf("Operation %s is not supported %s", op.Name, activeBackend.with)}
+		}
+		return activeBackend, nil
+	}
+	for _, b := range defaultBackends {
+		if b.supports(op) {
+			return b, nil
+		}
+	}
+	var flags []string
+	for _, b := range backends {
+		if b.supports(op) {
+			flags = append(flags, b.flag)
+		}
+	}
+	return nil, &errorResult{Code: exitUsage, Message: fmt.Sprintf("Operation %s needs %s", op.Name, strings.Join(flags, " or "))}
+}
+
+// supported returns the operations b supports, in the order of
+// allOperations.
+func (b *backend) supported() []backendOperation {
+	var ops []backendOperation
+	for _, op := range allOperations() {
+		if b.supports(op) {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// usage returns the usage line for the operations of b taking the same
+// operands as op, e.g.
+// "Usage: mathreleaser -exact -op=[subtract|divide|power] <number1> <number2>".
+func (b *backend) usage(op backendOperation) string {
+	prefix := "Usage: mathreleaser "
+	if b.angle && op.Arity == 1 && !op.Variadic {
+		prefix += "[-angle=rad|deg|grad|turn] "
+	} else if b.flags != "" {
+		prefix += b.flags + " "
+	}
+	if op.operands != "" {
+		return prefix + "-op=" + op.Name + " " + op.operands
+	}
+
+	var names []string
+	for _, other := range b.supported() {
+		if other.operands == "" && other.Variadic == op.Variadic && (op.Variadic || other.Arity == op.Arity) {
+			names = append(names, other.Name)
+		}
+	}
+	return fmt.Sprintf("%s-op=[%s] %s", prefix, strings.Join(names, "|"), b.placeholders(op))
+}
+
+// placeholders returns the operands of op in usage text, e.g.
+// "<number1> <number2>".
+func (b *backend) placeholders(op backendOperation) string {
+	switch {
+	case op.Variadic:
+		return fmt.Sprintf("<%s>... (or %ss on stdin)", b.operand, b.operand)
+	case op.Arity == 1:
+		return "<" + b.operand + ">"
+	}
+	placeholders := make([]string, op.Arity)
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("<%s%d>", b.operand, i+1)
+	}
+	return strings.Join(placeholders, " ")
+}
+
+// summary returns a usage line, without "Usage:", listing every operation
+// of b.
+func (b *backend) summary() string {
+	ops := b.supported()
+	if len(ops) == 1 {
+		return "       " + strings.TrimPrefix(b.usage(ops[0]), "Usage: ")
+	}
+	names := make([]string, len(ops))
+	for i, op := range ops {
+		names[i] = op.Name
+	}
+	return fmt.Sprintf("       mathreleaser %s -op=[%s] <%s>...", b.flags, strings.Join(names, "|"), b.operand)
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+)
+
+// Record formats accepted by the batch subcommand. Results are written in
+// the same format as the input.
+const (
+	batchLines = "lines" // "divide 10 2", one record per line
+	batchCSV   = "csv"   // "divide,10,2"
+	batchJSONL = "jsonl" // {"op":"divide","operands":[10,2]}, one per line
+)
+
+// maxBatchWorkers caps -workers. Records are evaluated on the CPU, so more
+// workers than this only cost memory for their channels and goroutines.
+const maxBatchWorkers = 256
+
+// batchRecord is a single operation read by the batch subcommand. err is
+// set when the record itself could not be read.
+type batchRecord struct {
+	line int
+	op   string
+	args []string
+	err  *errorResult
+}
+
+// batchOutcome is the result of evaluating one record.
+type batchOutcome struct {
+	record batchRecord
+	result opResult
+	text   string
+	err    *errorResult
+}
+
+// batchJob pairs a record with the channel its outcome is delivered on.
+type batchJob struct {
+	record batchRecord
+	done   chan batchOutcome
+}
+
+// batchReader reads records from the input of the batch subcommand.
+type batchReader interface {
+	// next returns the next record, or io.EOF when the input is exhausted.
+	// Other errors mean the input can no longer be read.
+	next() (batchRecord, error)
+}
+
+// runBatch implements "mathreleaser batch". It evaluates every record of the
+// input, continues past records that fail, and exits with a non-zero code
+// if any record failed.
+func runBatch(args []string) {
+	flags := newSubcommandFlags("batch")
+	in := flags.String("in", "", "Read records from this file instead of stdin")
+	format := flags.String("format", batchLines, "Record format: lines, csv or jsonl")
+	workers := flags.Int("workers", 1, "Number of records to evaluate concurrently")
+	positional, ok := parseSubcommandFlags(flags, args)
+	if !ok {
+		return
+	}
+
+	switch {
+	case len(positional) > 0:
+		failf(exitUsage, "Unexpected argument to batch: %s", positional[0])
+		return
+	case *format != batchLines && *format != batchCSV && *format != batchJSONL:
+		failf(exitUsage, "unknown batch format %q (want lines, csv or jsonl)", *format)
+		return
+	case *workers < 1:
+		failf(exitUsage, "-workers must be at least 1")
+		return
+	}
+
+	input := io.Reader(os.Stdin)
+	if *in != "" && *in != "-" {
+		f, err := os.Open(filepath.Clean(*in))
+		if err != nil {
+			failf(exitError, "Error opening input: %v", err)
+			return
+		}
+		defer f.Close()
+		input = f
+	}
+
+	var records batchReader
+	switch *format {
+	case batchCSV:
+		records = newCSVBatchReader(input)
+	case batchJSONL:
+		records = &lineBatchReader{scanner: bufio.NewScanner(input), parse: parseJSONLRecord}
+	default:
+		records = &lineBatchReader{scanner: bufio.NewScanner(input), parse: parseLinesRecord}
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	csvOut := csv.NewWriter(out)
+	total, failed := 0, 0
+	failedCode := exitOK
+
+	readErr := processBatch(records, *workers, func(o batchOutcome) {
+		total++
+		if o.err != nil {
+			failed++
+			if failedCode == exitOK {
+				failedCode = o.err.Code
+			} else if failedCode != o.err.Code {
+				failedCode = exitError
+			}
+			// Flush first so results and errors interleave in record order
+			// when stdout and stderr share a terminal.
+			csvOut.Flush()
+			out.Flush()
+			writeBatchError(*format, *o.err)
+			return
+		}
+
+		switch *format {
+		case batchCSV:
+			row := append([]string{o.record.op}, o.record.args...)
+			_ = csvOut.Write(append(row, fmt.Sprint(o.result.Result)))
+		case batchJSONL:
+			o.result.Line = o.record.line
+			_ = output.Encode(out, output.JSON, o.result)
+		default:
+			fmt.Fprintln(out, o.text)
+		}
+	})
+	csvOut.Flush()
+	out.Flush()
+
+	if readErr != nil {
+		writeBatchError(*format, errorResult{Code: exitError, Message: fmt.Sprintf("Error reading input: %v", readErr)})
+		osExit(exitError)
+		return
+	}
+	if failed > 0 {
+		// When every failure has the same class its code is used, so a
+		// batch of divisions by zero still exits with exitDivisionByZero.
+		writeBatchError(*format, errorResult{Code: failedCode, Message: fmt.Sprintf("%d of %d records failed", failed, total)})
+		osExit(failedCode)
+	}
+}
+
+// processBatch evaluates records on a pool of workers and calls emit with
+// each outcome in input order. At most 2*workers records are in flight, so
+// memory use does not grow with the input, and workers is capped at
+// maxBatchWorkers. It returns the error that stopped the reader, if any.
+func processBatch(records batchReader, workers int, emit func(batchOutcome)) error {
+	if workers > maxBatchWorkers {
+		workers = maxBatchWorkers
+	}
+	jobs := make(chan batchJob, workers)
+	pending := make(chan chan batchOutcome, 2*workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				job.done <- evaluateRecord(job.record)
+			}
+		}()
+	}
+
+	var readErr error
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		for {
+			record, err := records.next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				readErr = err
+				return
+			}
+			done := make(chan batchOutcome, 1)
+			pending <- done
+			jobs <- batchJob{record: record, done: done}
+		}
+	}()
+
+	for done := range pending {
+		emit(<-done)
+	}
+	wg.Wait()
+	return readErr
+}
+
+// evaluateRecord performs the operation of a single record.
+func evaluateRecord(record batchRecord) batchOutcome {
+	if record.err != nil {
+		return batchOutcome{record: record, err: record.err}
+	}
+
+	result, text, err := calculate(record.op, record.args)
+	if err != nil {
+		e := errorResultFor(err)
+		if e.Usage != nil {
+			e.Message = fmt.Sprintf("wrong number of arguments for %s", record.op)
+			e.Usage = nil
+		}
+		e.Line = record.line
+		return batchOutcome{record: record, err: &e}
+	}
+	return batchOutcome{record: record, result: result, text: text}
+}
+
+// writeBatchError prints a record error or the summary to stderr, as JSON
+// for jsonl input and as text otherwise.
+func writeBatchError(format string, e errorResult) {
+	if format == batchJSONL {
+		_ = output.Encode(os.Stderr, output.JSON, e)
+		return
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(os.Stderr, "Error: line %d: %s\n", e.Line, e.Message)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %s\n", e.Message)
+}
+
+// lineBatchReader reads one record per line. Blank lines and lines starting
+// with # are skipped.
+type lineBatchReader struct {
+	scanner *bufio.Scanner
+	line    int
+	parse   func(line int, text string) batchRecord
+}
+
+func (r *lineBatchReader) next() (batchRecord, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		return r.parse(r.line, text), nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return batchRecord{}, err
+	}
+	return batchRecord{}, io.EOF
+}
+
+// parseLinesRecord parses a whitespace-separated record such as "divide 10 2".
+func parseLinesRecord(line int, text string) batchRecord {
+	fields := strings.Fields(text)
+	return batchRecord{line: line, op: fields[0], args: fields[1:]}
+}
+
+// parseJSONLRecord parses a record such as {"op":"divide","operands":[10,2]}.
+// Operands may be JSON numbers or strings.
+func parseJSONLRecord(line int, text string) batchRecord {
+	var raw struct {
+		Op       string        `json:"op"`
+		Operands []interface{} `json:"operands"`
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(text)))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return invalidRecord(line, "Error parsing record: %v", err)
+	}
+	if raw.Op == "" {
+		return invalidRecord(line, "Error parsing record: missing op")
+	}
+
+	args := make([]string, len(raw.Operands))
+	for i, operand := range raw.Operands {
+		switch v := operand.(type) {
+		case json.Number:
+			args[i] = v.String()
+		case string:
+			args[i] = v
+		default:
+			return invalidRecord(line, "Error parsing record: operand %d must be a number or a string", i+1)
+		}
+	}
+	return batchRecord{line: line, op: raw.Op, args: args}
+}
+
+// csvBatchReader reads records such as "divide,10,2". Lines starting with #
+// are skipped.
+type csvBatchReader struct {
+	reader *csv.Reader
+}
+
+// newCSVBatchReader returns a csvBatchReader that accepts records with any
+// number of fields.
+func newCSVBatchReader(r io.Reader) *csvBatchReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	return &csvBatchReader{reader: reader}
+}
+
+func (r *csvBatchReader) next() (batchRecord, error) {
+	fields, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return invalidRecord(parseErr.StartLine, "Error parsing record: %v", parseErr.Err), nil
+		}
+		return batchRecord{}, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	if fields[0] == "" {
+		return invalidRecord(line, "Error parsing record: missing operation"), nil
+	}
+	return batchRecord{line: line, op: fields[0], args: fields[1:]}, nil
+}
+
+// invalidRecord returns a record that failed to parse.
+func invalidRecord(line int, format string, args ...interface{}) batchRecord {
+	return batchRecord{
+		line: line,
+		err:  &errorResult{Line: line, Code: exitInvalidInput, Message: fmt.Sprintf(format, args...)},
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeBatchInput writes records to a temporary file and returns its path
+func writeBatchInput(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "records")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write batch input: %v", err)
+	}
+	return path
+}
+
+// TestBatch tests the batch subcommand
+func TestBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		input       string
+		expectedOut string
+		expectedErr []string
+		exitCode    int
+	}{
+		{
+			name:        "lines",
+			args:        []string{"batch"},
+			input:       "add 5 3\n# comment\n\nsqrt 16\n",
+			expectedOut: "5 + 3 = 8.00\nsqrt(16) = 4.00\n",
+			exitCode:    exitOK,
+		},
+		{
+			name:        "lines_with_errors",
+			args:        []string{"batch"},
+			input:       "add 5 3\ndivide 10 0\nmodulo 1 2\nadd 1\nmultiply 2 3\n",
+			expectedOut: "5 + 3 = 8.00\n2 * 3 = 6.00\n",
+			expectedErr: []string{
+				"Error: line 2: Error performing division: division by zero",
+				"Error: line 3: Unknown operation: modulo",
+				"Error: line 4: wrong number of arguments for add",
+				"Error: 3 of 5 records failed",
+			},
+			exitCode: exitError,
+		},
+		{
+			name:        "exact_backend",
+			args:        []string{"-exact", "batch"},
+			input:       "divide 1 3\nadd 1/2 1/3 1/6\nsqrt 2\n",
+			expectedOut: "1 / 3 = 1/3\n1/2 + 1/3 + 1/6 = 1\n",
+			expectedErr: []string{"Error: line 3: Operation sqrt is not supported with -exact"},
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "same_class_errors",
+			args:        []string{"batch"},
+			input:       "divide 1 0\ndivide 2 0\n",
+			expectedErr: []string{"Error: 2 of 2 records failed"},
+			exitCode:    exitDivisionByZero,
+		},
+		{
+			name:        "csv",
+			args:        []string{"batch", "-format=csv"},
+			input:       "divide,10,2\n power , 2, 10\nsqrt,x\n\"unterminated,1\n",
+			expectedOut: "divide,10,2,5\npower,2,10,1024\n",
+			expectedErr: []string{
+				"Error: line 3: Error parsing number",
+				"Error: line 4: Error parsing record",
+				"Error: 2 of 4 records failed",
+			},
+			exitCode: exitInvalidInput,
+		},
+		{
+			name:        "jsonl",
+			args:        []string{"batch", "-format=jsonl"},
+			input:       "{\"op\":\"add\",\"operands\":[5,\"3\"]}\n{bad\n{\"op\":\"divide\",\"operands\":[1,0]}\n",
+			expectedOut: `{"line":1,"op":"add","operands":[5,3],"result":8,"formatted":"8.00"}` + "\n",
+			expectedErr: []string{
+				`{"line":2,"code":3,"message":"Error parsing record:`,
+				`{"line":3,"code":4,"message":"Error performing division: division by zero","detail":"divide(1, 0): division by zero"}`,
+				`{"code":1,"message":"2 of 3 records failed"}`,
+			},
+			exitCode: exitError,
+		},
+		{
+			name:        "jsonl_bad_operand",
+			args:        []string{"batch", "-format=jsonl"},
+			input:       "{\"op\":\"add\",\"operands\":[true,1]}\n",
+			expectedErr: []string{"operand 1 must be a number or a string"},
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "bad_format",
+			args:        []string{"batch", "-format=xml"},
+			expectedErr: []string{"unknown batch format"},
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "huge_workers",
+			args:        []string{"batch", "-workers=4611686018427387904"},
+			input:       "add 5 3\nsqrt 16\n",
+			expectedOut: "5 + 3 = 8.00\nsqrt(16) = 4.00\n",
+			exitCode:    exitOK,
+		},
+		{
+			name:        "bad_workers",
+			args:        []string{"batch", "-workers=0"},
+			expectedErr: []string{"-workers must be at least 1"},
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "missing_file",
+			args:        []string{"batch", "-in=/nonexistent/records"},
+			expectedErr: []string{"Error opening input"},
+			exitCode:    exitError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			args := tt.args
+			if tt.input != "" {
+				args = append(args, "-in", writeBatchInput(t, tt.input))
+			}
+			os.Args = append([]string{"mathreleaser"}, args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if stdout != tt.expectedOut {
+				t.Errorf("Expected stdout %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			for _, want := range tt.expectedErr {
+				if !strings.Contains(stderr, want) {
+					t.Errorf("Expected error %q, got stderr: %s", want, stderr)
+				}
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
+
+// TestBatchStdin tests that batch reads stdin when -in is not given
+func TestBatchStdin(t *testing.T) {
+	resetFlags()
+	outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+	defer teardown()
+
+	rIn, wIn, _ := os.Pipe()
+	originalStdin := os.Stdin
+	os.Stdin = rIn
+	defer func() { os.Stdin = originalStdin }()
+	fmt.Fprint(wIn, "subtract 10 4\n")
+	wIn.Close()
+
+	os.Args = []string{"mathreleaser", "batch"}
+	mainInternal()
+
+	stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+	if stdout != "10 - 4 = 6.00\n" {
+		t.Errorf("Expected '10 - 4 = 6.00', got stdout: %s, stderr: %s", stdout, stderr)
+	}
+	if exitCode != exitOK {
+		t.Errorf("Expected exit code %d, got %d", exitOK, exitCode)
+	}
+}
+
+// TestBatchWorkersPreserveOrder tests that concurrent evaluation keeps input order
+func TestBatchWorkersPreserveOrder(t *testing.T) {
+	var input, expected strings.Builder
+	for i := 1; i <= 500; i++ {
+		fmt.Fprintf(&input, "multiply %d 2\n", i)
+		if i%50 == 0 {
+			fmt.Fprintf(&input, "divide %d 0\n", i)
+		}
+		fmt.Fprintf(&expected, "%d * 2 = %s\n", i, formatThousands(i*2))
+	}
+
+	resetFlags()
+	outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+	defer teardown()
+
+	os.Args = []string{"mathreleaser", "batch", "-workers=8", "-in", writeBatchInput(t, input.String())}
+
+	// Drain stdout while the batch runs so the pipe cannot fill up
+	stdoutCh := make(chan string)
+	go func() {
+		_, _ = outBuf.ReadFrom(rOut)
+		stdoutCh <- outBuf.String()
+	}()
+	mainInternal()
+	wOut.Close()
+	stdout := <-stdoutCh
+	wErr.Close()
+	_, _ = errBuf.ReadFrom(rErr)
+
+	if stdout != expected.String() {
+		t.Errorf("Output out of order or incomplete:\n%s", stdout)
+	}
+	if !strings.Contains(errBuf.String(), "Error: 10 of 510 records failed") {
+		t.Errorf("Expected failure summary, got stderr: %s", errBuf.String())
+	}
+	if exitCode != exitDivisionByZero {
+		t.Errorf("Expected exit code %d, got %d", exitDivisionByZero, exitCode)
+	}
+}
+
+// formatThousands formats a small integer the way FormatNumber does
+func formatThousands(n int) string {
+	if n >= 1000 {
+		return fmt.Sprintf("%d,%03d.00", n/1000, n%1000)
+	}
+	return fmt.Sprintf("%d.00", n)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/numeric"
+)
+
+// integrateUsage is the usage text of the integrate subcommand.
+var integrateUsage = []string{
+	"Usage: mathreleaser integrate [-method=gk|simpson] [-tol=1e-10] <expression in x> <from> <to>",
+}
+
+// derivativeUsage is the usage text of the derivative subcommand.
+var derivativeUsage = []string{
+	"Usage: mathreleaser derivative <expression in x> <x>",
+}
+
+// estimateResult is the structured form of a numerical result with an
+// error estimate.
+type estimateResult struct {
+	Op             string        `json:"op"`
+	Expression     string        `json:"expression"`
+	Operands       []interface{} `json:"operands"`
+	Result         interface{}   `json:"result"`
+	EstimatedError interface{}   `json:"estimatedError"`
+	Formatted      string        `json:"formatted"`
+}
+
+// exprFunction adapts an expression in x to a func(float64) float64 for the
+// numeric package. An evaluation error makes the function return NaN and is
+// recorded, so it can be reported instead of the numeric package's error.
+type exprFunction struct {
+	input string
+	node  expr.Node
+	env   *expr.Env
+	err   error   // the first evaluation error
+	errX  float64 // the value of x that caused err
+}
+
+// parseFunction parses an expression argument, reporting a syntax error
+// with a caret under the offending column. It returns false on failure.
+func parseFunction(input string) (*exprFunction, bool) {
+	node, err := expr.Parse(input)
+	if err != nil {
+		e := errorResult{Code: exitInvalidInput, Message: fmt.Sprintf("Error parsing expression: %v", err)}
+		var syntaxErr *expr.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			e.Column = syntaxErr.Col
+		}
+		failExpression(input, e)
+		return nil, false
+	}
+	return &exprFunction{input: input, node: node, env: newEnv()}, true
+}
+
+// eval returns the value of the expression at x.
+func (f *exprFunction) eval(x float64) float64 {
+	f.env.Vars["x"] = x
+	y, err := f.node.Eval(f.env)
+	if err != nil {
+		if f.err == nil {
+			f.err, f.errX = err, x
+		}
+		return math.NaN()
+	}
+	return y
+}
+
+// failEval reports the recorded evaluation error, if any, and returns
+// whether there was one.
+func (f *exprFunction) failEval() bool {
+	if f.err == nil {
+		return false
+	}
+	e := errorResult{
+		Code:    exitCodeFor(f.err),
+		Message: fmt.Sprintf("Error evaluating expression at x = %g: %v", f.errX, f.err),
+	}
+	var evalErr *expr.EvalError
+	if errors.As(f.err, &evalErr) {
+		e.Column = evalErr.Col
+	}
+	failExpression(f.input, e)
+	return true
+}
+
+// parsePoint evaluates a numeric argument, which may be an expression such
+// as pi/2. It returns false on failure.
+func parsePoint(input string) (float64, bool) {
+	x, err := expr.Eval(input)
+	if err != nil {
+		failf(exitInvalidInput, "Error parsing number: %v", err)
+		return 0, false
+	}
+	return x, true
+}
+
+// runIntegrate implements "mathreleaser integrate".
+func runIntegrate(args []string) {
+	flags := newSubcommandFlags("integrate")
+	method := flags.String("method", "gk", "integration method: gk (adaptive Gauss–Kronrod) or simpson (adaptive Simpson)")
+	tol := flags.Float64("tol", 1e-10, "error tolerance, relative to the size of the integral")
+	positional, ok := parseSubcommandFlags(flags, args)
+	if !ok {
+		return
+	}
+	if len(positional) != 3 {
+		usage(integrateUsage...)
+		return
+	}
+
+	var integrate func(f func(float64) float64, a, b, tol float64) (numeric.Estimate, error)
+	switch *method {
+	case "gk":
+		integrate = numeric.GaussKronrod
+	case "simpson":
+		integrate = numeric.Simpson
+	default:
+		failf(exitUsage, "Unknown integration method: %s", *method)
+		return
+	}
+
+	f, ok := parseFunction(positional[0])
+	if !ok {
+		return
+	}
+	a, ok := parsePoint(positional[1])
+	if !ok {
+		return
+	}
+	b, ok := parsePoint(positional[2])
+	if !ok {
+		return
+	}
+
+	estimate, err := integrate(f.eval, a, b, *tol)
+	if f.failEval() {
+		return
+	}
+	if err != nil {
+		failOp("integration", err)
+		return
+	}
+
+	printEstimate(fmt.Sprintf("integral of %s from %s to %s", f.input, positional[1], positional[2]), estimateResult{
+		Op:         "integrate",
+		Expression: f.input,
+		Operands:   []interface{}{output.Float(a), output.Float(b)},
+	}, estimate)
+}
+
+// runDerivative implements "mathreleaser derivative".
+func runDerivative(args []string) {
+	flags := newSubcommandFlags("derivative")
+	positional, ok := parseSubcommandFlags(flags, args)
+	if !ok {
+		return
+	}
+	if len(positional) != 2 {
+		usage(derivativeUsage...)
+		return
+	}
+
+	f, ok := parseFunction(positional[0])
+	if !ok {
+		return
+	}
+	x, ok := parsePoint(positional[1])
+	if !ok {
+		return
+	}
+
+	estimate, err := numeric.Derivative(f.eval, x)
+	if f.failEval() {
+		return
+	}
+	if err != nil {
+		failOp("differentiation", err)
+		return
+	}
+
+	printEstimate(fmt.Sprintf("derivative of %s at %s", f.input, positional[1]), estimateResult{
+		Op:         "derivative",
+		Expression: f.input,
+		Operands:   []interface{}{output.Float(x)},
+	}, estimate)
+}
+
+// printEstimate completes r with estimate and prints it, as
+// "<what> = <value> (estimated error <error>)" in text mode.
+func printEstimate(what string, r estimateResult, estimate numeric.Estimate) {
+	r.Result = output.Float(estimate.Value)
+	r.EstimatedError = output.Float(estimate.Error)
+	r.Formatted = numberFormat.Format(estimate.Value)
+	if outputFormat == output.Text {
+		fmt.Printf("%s = %s (estimated error %.2g)\n", what, r.Formatted, estimate.Error)
+		return
+	}
+	encode(os.Stdout, r)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestIntegrate tests the integrate subcommand
+func TestIntegrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{
+			name:        "sin",
+			args:        []string{"integrate", "sin(x)", "0", "3.14159"},
+			expectedOut: []string{"integral of sin(x) from 0 to 3.14159 = 2.00 (estimated error "},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "constant_bound",
+			args:        []string{"integrate", "3*x^2", "0", "pi/pi*2"},
+			expectedOut: []string{"integral of 3*x^2 from 0 to pi/pi*2 = 8.00"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "simpson",
+			args:        []string{"integrate", "-method=simpson", "x^3", "0", "2"},
+			expectedOut: []string{"= 4.00"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "json",
+			args:        []string{"-output=json", "integrate", "2*x", "0", "1"},
+			expectedOut: []string{`{"op":"integrate","expression":"2*x","operands":[0,1],"result":1,"estimatedError":`},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "division_by_zero",
+			args:        []string{"integrate", "1/x", "-1", "1"},
+			expectedErr: "Error evaluating expression at x = 0: column 2: division by zero\n  1/x\n   ^\n",
+			exitCode:    exitDivisionByZero,
+		},
+		{
+			name:        "outside_domain",
+			args:        []string{"integrate", "sqrt(x)", "-1", "1"},
+			expectedErr: "square root of negative number",
+			exitCode:    exitDomain,
+		},
+		{
+			name:        "no_convergence",
+			args:        []string{"integrate", "sin(1/x)", "1e-300", "1", "-tol=1e-15"},
+			expectedErr: "integrate did not converge after 1000 iterations",
+			exitCode:    exitNoConvergence,
+		},
+		{
+			name:        "invalid_tolerance",
+			args:        []string{"integrate", "-tol=0", "x", "0", "1"},
+			expectedErr: "tolerance must be positive",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "syntax_error",
+			args:        []string{"integrate", "sin(x", "0", "1"},
+			expectedErr: "Error parsing expression",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "invalid_bound",
+			args:        []string{"integrate", "x", "0", "abc"},
+			expectedErr: "Error parsing number",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "unknown_method",
+			args:        []string{"integrate", "-method=trapezoid", "x", "0", "1"},
+			expectedErr: "Unknown integration method: trapezoid",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "wrong_arity",
+			args:        []string{"integrate", "x", "0"},
+			expectedOut: []string{"Usage: mathreleaser integrate"},
+			exitCode:    exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCalculusTest(t, tt.args, tt.expectedOut, tt.expectedErr, tt.exitCode)
+		})
+	}
+}
+
+// TestDerivative tests the derivative subcommand
+func TestDerivative(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{
+			name:        "cubic",
+			args:        []string{"derivative", "x^3", "2"},
+			expectedOut: []string{"derivative of x^3 at 2 = 12.00 (estimated error "},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "expression_point",
+			args:        []string{"derivative", "sin(x)", "pi"},
+			expectedOut: []string{"derivative of sin(x) at pi = -1.00"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "yaml",
+			args:        []string{"-output=yaml", "derivative", "5*x", "1"},
+			expectedOut: []string{"op: derivative\nexpression: \"5*x\"\n", "formatted: \"5.00\"\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "undefined_variable",
+			args:        []string{"derivative", "y", "1"},
+			expectedErr: "undefined variable \"y\"",
+			exitCode:    exitError,
+		},
+		{
+			name:        "wrong_arity",
+			args:        []string{"derivative", "x"},
+			expectedOut: []string{"Usage: mathreleaser derivative"},
+			exitCode:    exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCalculusTest(t, tt.args, tt.expectedOut, tt.expectedErr, tt.exitCode)
+		})
+	}
+}
+
+// runCalculusTest runs mathreleaser with args and checks its output and
+// exit code.
+func runCalculusTest(t *testing.T, args, expectedOut []string, expectedErr string, code int) {
+	t.Helper()
+	resetFlags()
+	outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+	defer teardown()
+
+	os.Args = append([]string{"mathreleaser"}, args...)
+	mainInternal()
+
+	stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+	for _, want := range expectedOut {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected %q, got stdout: %s, stderr: %s", want, stdout, stderr)
+		}
+	}
+	if expectedErr != "" && !strings.Contains(stderr, expectedErr) {
+		t.Errorf("Expected error %q, got stdout: %s, stderr: %s", expectedErr, stdout, stderr)
+	}
+	if exitCode != code {
+		t.Errorf("Expected exit code %d, got %d", code, exitCode)
+	}
+}
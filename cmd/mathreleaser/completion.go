@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/internal/helpers"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// completionUsage is the usage text of the completion subcommand.
+var completionUsage = []string{
+	"Usage: mathreleaser completion bash|zsh|fish",
+	"       Load with e.g. source <(mathreleaser completion bash)",
+}
+
+// flagValues lists the values offered when completing flags that take a
+// fixed set of values, other than -op.
+var flagValues = map[string][]string{
+	"angle":    {"rad", "deg", "grad", "turn"},
+	"base":     {"2", "8", "10", "16", "36"},
+	"format":   {"improper", "mixed", "decimal"},
+	"locale":   helpers.LocaleNames(),
+	"notation": helpers.NotationNames(),
+	"output":   {"text", "json", "yaml"},
+	"rounding": calculator.RoundingModeNames(),
+	"word":     calculator.WordNames(),
+}
+
+func init() {
+	// Registered here because runCompletion reads subcommands
+	subcommands["completion"] = runCompletion
+}
+
+// runCompletion implements "mathreleaser completion", printing a script
+// that completes operation names, flags and subcommands in the given shell.
+func runCompletion(args []string) {
+	if len(args) != 1 {
+		usage(completionUsage...)
+		return
+	}
+	switch args[0] {
+	case "bash":
+		fmt.Print(bashCompletion())
+	case "zsh":
+		fmt.Print("autoload -U +X bashcompinit && bashcompinit\n" + bashCompletion())
+	case "fish":
+		fmt.Print(fishCompletion())
+	default:
+		failf(exitUsage, "Unsupported shell %q (want bash, zsh or fish)", args[0])
+	}
+}
+
+// operationNamesAndAliases returns every name -op accepts.
+func operationNamesAndAliases() []string {
+	var names []string
+	for _, op := range allOperations() {
+		names = append(names, op.Name)
+		names = append(names, op.Aliases...)
+	}
+	return names
+}
+
+// subcommandNames returns the names of the subcommands, sorted.
+func subcommandNames() []string {
+	names := []string{"repl"}
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// topLevelFlags returns the flags of the command line, sorted by name.
+func topLevelFlags() []*flag.Flag {
+	var flags []*flag.Flag
+	flag.VisitAll(func(f *flag.Flag) {
+		flags = append(flags, f)
+	})
+	return flags
+}
+
+// bashCompletion returns a bash completion script. Bash splits words at
+// '=', so -op=ad arrives as "-op", "=", "ad".
+func bashCompletion() string {
+	var b strings.Builder
+	b.WriteString("_mathreleaser() {\n")
+	b.WriteString("    local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]}\n")
+	b.WriteString("    if [[ $cur == = ]]; then\n        cur=\n    elif [[ $prev == = ]]; then\n        prev=${COMP_WORDS[COMP_CWORD-2]}\n    fi\n")
+	b.WriteString("    case $prev in\n")
+	fmt.Fprintf(&b, "    -op) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", strings.Join(operationNamesAndAliases(), " "))
+	for _, name := range sortedKeys(flagValues) {
+		fmt.Fprintf(&b, "    -%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", name, strings.Join(flagValues[name], " "))
+	}
+	b.WriteString("    esac\n")
+
+	var flags []string
+	for _, f := range topLevelFlags() {
+		flags = append(flags, "-"+f.Name)
+	}
+	fmt.Fprintf(&b, "    if [[ $cur == -* ]]; then\n        COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(flags, " "))
+	fmt.Fprintf(&b, "    elif [[ $COMP_CWORD -eq 1 ]]; then\n        COMPREPLY=($(compgen -W %q -- \"$cur\"))\n    fi\n", strings.Join(subcommandNames(), " "))
+	b.WriteString("}\ncomplete -F _mathreleaser mathreleaser\n")
+	return b.String()
+}
+
+// fishCompletion returns a fish completion script, which shows the
+// description of each operation and flag.
+func fishCompletion() string {
+	var b strings.Builder
+	b.WriteString("complete -c mathreleaser -f\n")
+	for _, name := range subcommandNames() {
+		fmt.Fprintf(&b, "complete -c mathreleaser -n __fish_use_subcommand -a %s\n", name)
+	}
+	for _, f := range topLevelFlags() {
+		switch {
+		case f.Name == "op":
+			fmt.Fprintf(&b, "complete -c mathreleaser -o op -x -d %s\n", fishQuote(f.Usage))
+			for _, op := range allOperations() {
+				for _, name := range append([]string{op.Name}, op.Aliases...) {
+					fmt.Fprintf(&b, "complete -c mathreleaser -o op -x -a %s -d %s\n", name, fishQuote(op.Description))
+				}
+			}
+		case flagValues[f.Name] != nil:
+			fmt.Fprintf(&b, "complete -c mathreleaser -o %s -x -a %s -d %s\n", f.Name, fishQuote(strings.Join(flagValues[f.Name], " ")), fishQuote(f.Usage))
+		default:
+			fmt.Fprintf(&b, "complete -c mathreleaser -o %s -d %s\n", f.Name, fishQuote(f.Usage))
+		}
+	}
+	return b.String()
+}
+
+// fishQuote quotes s as a single-quoted fish string.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// complexBackend works in the complex plane. It is selected with -complex,
+// and operands may be written as complex literals such as 3+4i.
+var complexBackend = &backend{
+	name:     "complex",
+	flag:     "-complex",
+	flags:    "-complex",
+	with:     "with -complex",
+	operand:  "number",
+	supports: func(op backendOperation) bool { return op.complex != nil },
+	run:      runComplex,
+}
+
+// complexFold adapts a two-operand function to backendOperation.complex,
+// applying it from left to right across the operands.
+func complexFold(fn func(a, b complex128) (complex128, error)) func([]complex128) (interface{}, string, error) {
+	return func(args []complex128) (interface{}, string, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			var err error
+			if result, err = fn(result, arg); err != nil {
+				return nil, "", err
+			}
+		}
+		return complexValue(result), numberFormat.FormatComplex(result), nil
+	}
+}
+
+// complex1 adapts a single-operand function to backendOperation.complex.
+func complex1(fn func(complex128) (complex128, error)) func([]complex128) (interface{}, string, error) {
+	return func(args []complex128) (interface{}, string, error) {
+		result, err := fn(args[0])
+		if err != nil {
+			return nil, "", err
+		}
+		return complexValue(result), numberFormat.FormatComplex(result), nil
+	}
+}
+
+// complexReal adapts a single-operand function with a real result, such as
+// the modulus, to backendOperation.complex. The result is reported like any
+// other real number.
+func complexReal(fn func(complex128) (float64, error)) func([]complex128) (interface{}, string, error) {
+	return func(args []complex128) (interface{}, string, error) {
+		result, err := fn(args[0])
+		if err != nil {
+			return nil, "", err
+		}
+		return output.Float(result), numberFormat.Format(result), nil
+	}
+}
+
+// runComplex performs an operation in the complex plane.
+func runComplex(op backendOperation, args []string) (opResult, string, error) {
+	values := make([]complex128, len(args))
+	operands := make([]interface{}, len(args))
+	for i, arg := range args {
+		c, err := calculator.ParseComplex(arg)
+		if err != nil {
+			return opResult{}, "", parseError(i, len(args), err)
+		}
+		values[i] = c
+		operands[i] = complexValue(c)
+	}
+
+	result, formatted, err := op.complex(values)
+	if err != nil {
+		return opResult{}, "", opError(op.Noun, err)
+	}
+
+	r := opResult{
+		Op:        op.Name,
+		Operands:  operands,
+		Result:    result,
+		Formatted: formatted,
+	}
+	return r, op.Format(args) + " = " + formatted, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestComplexFlag tests operations in complex mode
+func TestComplexFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"sqrt_negative", []string{"-complex", "-op=sqrt", "--", "-4"}, "sqrt(-4) = 2.00i", "", exitOK},
+		{"multiply", []string{"-complex", "-op=multiply", "1+2i", "3-i"}, "1+2i * 3-i = 5.00 + 5.00i", "", exitOK},
+		{"divide", []string{"-complex", "-op=divide", "1", "i"}, "1 / i = -1.00i", "", exitOK},
+		{"abs", []string{"-complex", "-op=abs", "3+4i"}, "abs(3+4i) = 5.00", "", exitOK},
+		{"abs_nan", []string{"-complex", "-op=abs", "NaN"}, "", "Error performing modulus: input is NaN or infinite", exitNonFinite},
+		{"arg_infinite", []string{"-complex", "-op=arg", "Inf"}, "", "Error performing argument: input is NaN or infinite", exitNonFinite},
+		{"conj", []string{"-complex", "-op=conj", "3+4i"}, "conj(3+4i) = 3.00 - 4.00i", "", exitOK},
+		{"divide_by_zero", []string{"-complex", "-op=divide", "1", "0"}, "", "Error performing division: division by zero", exitDivisionByZero},
+		{"ln_zero", []string{"-complex", "-op=ln", "0"}, "", "logarithm of zero", exitDomain},
+		{"log_base", []string{"-complex", "-op=log", "--", "-8", "2"}, "log(-8, 2) = 3.00 + 4.53i", "", exitOK},
+		{"log_missing_base", []string{"-complex", "-op=log", "8"}, "Usage: mathreleaser -complex -op=[subtract|divide|power|log] <number1> <number2>", "", exitUsage},
+		{"add_variadic", []string{"-complex", "-op=add", "1", "i", "2"}, "1 + i + 2 = 3.00 + 1.00i", "", exitOK},
+		{"alias", []string{"-complex", "-op=mul", "2i", "3"}, "2i * 3 = 6.00i", "", exitOK},
+		{"tan_pole", []string{"-complex", "-op=tan", "1.5707963267948966"}, "", "Error performing tangent: undefined result", exitDomain},
+		{"multiply_overflow", []string{"-complex", "-op=multiply", "1e308i", "10"}, "", "Error performing multiplication: result overflows", exitOverflow},
+		{"add_nan", []string{"-complex", "-op=add", "NaN", "1"}, "", "Error performing addition: input is NaN or infinite", exitNonFinite},
+		{"invalid_number", []string{"-complex", "-op=sqrt", "4+j"}, "", "Error parsing number", exitInvalidInput},
+		{"unsupported_op", []string{"-complex", "-op=random", "1", "2"}, "", "not supported with -complex", exitUsage},
+		{"combined_backends", []string{"-complex", "-exact", "-op=add", "1", "2"}, "", "cannot be combined", exitUsage},
+		{"missing_args", []string{"-complex", "-op=add", "1"}, "Usage:", "", exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
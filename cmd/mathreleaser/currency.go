@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PingDavidR/go-release-test/internal/helpers"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/currency"
+)
+
+// currencyOperation is the name -op accepts for currency conversion.
+const currencyOperation = "currency"
+
+// currencyBackend runs the currency operation, which takes a currency
+// amount and two currency codes.
+var currencyBackend = &backend{
+	name:     "currency",
+	flag:     "-rates",
+	flags:    "-rates=<file.json|file.csv> [-date=YYYY-MM-DD]",
+	supports: func(op backendOperation) bool { return op.convert != nil },
+	run: func(op backendOperation, args []string) (opResult, string, error) {
+		return op.convert(args)
+	},
+}
+
+// rateProvider supplies the exchange rates of the currency operation, read
+// from the file given with -rates, or is nil if none was given.
+var rateProvider currency.RateProvider
+
+// rateDate is the date set with -date, or zero to use the latest rates.
+var rateDate time.Time
+
+// currencyResult is the structured form of a currency conversion. Amounts
+// and rates are strings so they stay exact.
+type currencyResult struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+	// Rate is the exact rate used, e.g. "1339/1250".
+	Rate     string `json:"rate"`
+	RateDate string `json:"rateDate,omitempty"`
+}
+
+// calculateCurrency converts an amount of money from one currency to
+// another, e.g. "100 EUR USD", with exact arithmetic, rounding the result to
+// the minor units of the target currency. It returns the same as calculate.
+func calculateCurrency(args []string) (opResult, string, error) {
+	if rateProvider == nil {
+		return opResult{}, "", &errorResult{Code: exitUsage, Message: "The currency operation needs exchange rates: pass -rates=<file.json|file.csv>"}
+	}
+	amount, err := calculator.ParseRational(args[0])
+	if err != nil {
+		return opResult{}, "", &errorResult{Code: exitInvalidInput, Message: fmt.Sprintf("Error parsing amount: %v", err)}
+	}
+
+	result, rate, err := currency.Convert(rateProvider, amount, args[1], args[2], rateDate)
+	if err != nil {
+		return opResult{}, "", opError("currency conversion", err)
+	}
+	target, _ := currency.Lookup(rate.To)
+
+	formatted := helpers.FormatCurrency(result.Rat(), target.Symbol, target.MinorUnits)
+	structured := currencyResult{
+		Amount:   result.Rat().FloatString(target.MinorUnits),
+		Currency: target.Code,
+		Rate:     rate.Value.String(),
+	}
+	note := fmt.Sprintf("1 %s = %s %s", rate.From, rateText(rate.Value), rate.To)
+	if rate.From != rate.To {
+		structured.RateDate = rate.Date.Format(currency.DateFormat)
+		note += " on " + structured.RateDate
+	}
+
+	r := opResult{
+		Op:        currencyOperation,
+		Operands:  []interface{}{args[0], rate.From, rate.To},
+		Result:    structured,
+		Formatted: formatted,
+	}
+	return r, fmt.Sprintf("%s %s in %s = %s (%s)", args[0], rate.From, rate.To, formatted, note), nil
+}
+
+// rateText shows an exchange rate to at most six decimal places, e.g.
+// "1.0712" or "0.798544".
+func rateText(rate calculator.Rational) string {
+	s := rate.Rat().FloatString(6)
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
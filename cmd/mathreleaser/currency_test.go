@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCurrency tests the currency operation with JSON and CSV rate tables
+func TestCurrency(t *testing.T) {
+	dir := t.TempDir()
+	jsonRates := filepath.Join(dir, "rates.json")
+	if err := os.WriteFile(jsonRates, []byte(`[
+		{"date": "2024-04-01", "base": "EUR", "rates": {"USD": "1.08"}},
+		{"date": "2024-05-01", "base": "EUR", "rates": {"USD": "1.0712", "GBP": "0.8554", "JPY": "166.45"}}
+	]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	csvRates := filepath.Join(dir, "rates.csv")
+	if err := os.WriteFile(csvRates, []byte("date,from,to,rate\n2024-05-01,USD,CHF,0.9142\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	badRates := filepath.Join(dir, "bad.csv")
+	if err := os.WriteFile(badRates, []byte("2024-05-01,USD,CHF,-1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"latest", []string{"-rates=" + jsonRates, "-op=currency", "100", "EUR", "USD"}, "100 EUR in USD = $107.12 (1 EUR = 1.0712 USD on 2024-05-01)", "", exitOK},
+		{"dated", []string{"-rates=" + jsonRates, "-date=2024-04-15", "-op=currency", "100", "EUR", "USD"}, "= $108.00 (1 EUR = 1.08 USD on 2024-04-01)", "", exitOK},
+		{"cross_rate", []string{"-rates=" + jsonRates, "-op=currency", "1234.5", "usd", "gbp"}, "1234.5 USD in GBP = £985.80", "", exitOK},
+		{"no_minor_units", []string{"-rates=" + jsonRates, "-op=currency", "10", "EUR", "JPY"}, "= ¥1,665 (", "", exitOK},
+		{"fraction_amount", []string{"-rates=" + jsonRates, "-op=currency", "1/3", "EUR", "EUR"}, "1/3 EUR in EUR = €0.33 (1 EUR = 1 EUR)", "", exitOK},
+		{"csv", []string{"-rates=" + csvRates, "-op=currency", "250", "CHF", "USD"}, "250 CHF in USD = $273.46", "", exitOK},
+		{"json_output", []string{"-rates=" + jsonRates, "-output=json", "-op=currency", "100", "EUR", "USD"}, `"result":{"amount":"107.12","currency":"USD","rate":"1339/1250","rateDate":"2024-05-01"},"formatted":"$107.12"`, "", exitOK},
+		{"no_rate", []string{"-rates=" + jsonRates, "-op=currency", "100", "EUR", "CHF"}, "", "Error performing currency conversion: no exchange rate", exitDomain},
+		{"before_first_rate", []string{"-rates=" + jsonRates, "-date=2020-01-01", "-op=currency", "100", "EUR", "USD"}, "", "no exchange rate", exitDomain},
+		{"invalid_code", []string{"-rates=" + jsonRates, "-op=currency", "100", "EUR", "US$"}, "", "currency code must be three letters", exitInvalidInput},
+		{"invalid_amount", []string{"-rates=" + jsonRates, "-op=currency", "lots", "EUR", "USD"}, "", "Error parsing amount", exitInvalidInput},
+		{"missing_rates", []string{"-op=currency", "100", "EUR", "USD"}, "", "needs exchange rates", exitUsage},
+		{"wrong_arity", []string{"-rates=" + jsonRates, "-op=currency", "100", "EUR"}, "Usage: mathreleaser -rates=", "", exitUsage},
+		{"invalid_date", []string{"-rates=" + jsonRates, "-date=May 1", "-op=currency", "100", "EUR", "USD"}, "", "invalid date", exitUsage},
+		{"invalid_rates_file", []string{"-rates=" + badRates, "-op=currency", "100", "EUR", "USD"}, "", "Error reading rates: " + badRates + ": line 1: exchange rate must be positive", exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+)
+
+// decimalBackend works in fixed-point decimal arithmetic with the settings
+// in decimalSettings. It is selected with -decimal.
+var decimalBackend = &backend{
+	name:     "decimal",
+	flag:     "-decimal",
+	flags:    "-decimal [-scale=N] [-rounding=half-even]",
+	with:     "with -decimal",
+	operand:  "number",
+	supports: func(op backendOperation) bool { return op.decimal != nil },
+	run:      runDecimal,
+}
+
+// errNotDecimal is returned when evaluating an expression with -decimal
+// that uses a variable or function other than sqrt.
+var errNotDecimal = errors.New("not supported with -decimal")
+
+// decimalContext holds the settings of -decimal: the scale results that
+// cannot be exact are rounded to, whether every result is rounded to it, and
+// the rounding mode.
+type decimalContext struct {
+	scale    int
+	fixed    bool
+	rounding calculator.RoundingMode
+}
+
+// decimalSettings is the context of -decimal, or nil without it.
+var decimalSettings *decimalContext
+
+// inexact finishes a result rounded to the scale: without a fixed -scale,
+// trailing zeros are dropped, so 1/4 is 0.25 rather than 0.2500000000000000.
+func (c *decimalContext) inexact(d calculator.Decimal) calculator.Decimal {
+	if c.fixed {
+		return d
+	}
+	return d.Reduce()
+}
+
+// finish rounds a final result to -scale if it was given, and otherwise
+// leaves it as computed.
+func (c *decimalContext) finish(d calculator.Decimal) calculator.Decimal {
+	if !c.fixed {
+		return d
+	}
+	d, _ = d.Round(c.scale, c.rounding)
+	return d
+}
+
+// divide returns a ÷ b rounded to the scale.
+func (c *decimalContext) divide(a, b calculator.Decimal) (calculator.Decimal, error) {
+	q, err := calculator.DivideDecimal(a, b, c.scale, c.rounding)
+	if err != nil {
+		return calculator.Decimal{}, err
+	}
+	return c.inexact(q), nil
+}
+
+// power returns a raised to b, which must be an integer.
+func (c *decimalContext) power(a, b calculator.Decimal) (calculator.Decimal, error) {
+	exp := b.Reduce()
+	n, err := strconv.Atoi(exp.String())
+	if exp.Scale() != 0 || err != nil {
+		return calculator.Decimal{}, &calculator.DomainError{Op: "power", Operands: []interface{}{a, b}, Reason: calculator.ErrNonIntegerExponent}
+	}
+	p, err := calculator.PowerDecimal(a, n, c.scale, c.rounding)
+	if err != nil {
+		return calculator.Decimal{}, err
+	}
+	if n < 0 {
+		p = c.inexact(p)
+	}
+	return p, nil
+}
+
+// sqrt returns the square root of a rounded to the scale.
+func (c *decimalContext) sqrt(a calculator.Decimal) (calculator.Decimal, error) {
+	root, err := calculator.SquareRootDecimal(a, c.scale, c.rounding)
+	if err != nil {
+		return calculator.Decimal{}, err
+	}
+	return c.inexact(root), nil
+}
+
+// mean returns the arithmetic mean of values rounded to the scale.
+func (c *decimalContext) mean(values []calculator.Decimal) (calculator.Decimal, error) {
+	sum := values[0]
+	for _, v := range values[1:] {
+		sum = calculator.AddDecimal(sum, v)
+	}
+	return c.divide(sum, calculator.NewDecimal(int64(len(values)), 0))
+}
+
+// decimalFold adapts a two-operand function to backendOperation.decimal,
+// applying it from left to right across the operands.
+func decimalFold(fn func(a, b calculator.Decimal) calculator.Decimal) func(*decimalContext, []calculator.Decimal) (calculator.Decimal, error) {
+	return func(_ *decimalContext, args []calculator.Decimal) (calculator.Decimal, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = fn(result, arg)
+		}
+		return result, nil
+	}
+}
+
+// decimal2 adapts a two-operand method of decimalContext to
+// backendOperation.decimal.
+func decimal2(fn func(c *decimalContext, a, b calculator.Decimal) (calculator.Decimal, error)) func(*decimalContext, []calculator.Decimal) (calculator.Decimal, error) {
+	return func(c *decimalContext, args []calculator.Decimal) (calculator.Decimal, error) {
+		return fn(c, args[0], args[1])
+	}
+}
+
+// decimal1 adapts a single-operand method of decimalContext to
+// backendOperation.decimal.
+func decimal1(fn func(c *decimalContext, a calculator.Decimal) (calculator.Decimal, error)) func(*decimalContext, []calculator.Decimal) (calculator.Decimal, error) {
+	return func(c *decimalContext, args []calculator.Decimal) (calculator.Decimal, error) { return fn(c, args[0]) }
+}
+
+// roundDecimal implements the round operation, which rounds a number to a
+// whole number of decimal places with -rounding.
+func roundDecimal(c *decimalContext, args []calculator.Decimal) (calculator.Decimal, error) {
+	places := args[1].Reduce()
+	n, err := strconv.Atoi(places.String())
+	if places.Scale() != 0 || err != nil {
+		return calculator.Decimal{}, &calculator.DomainError{Op: "round", Operands: []interface{}{args[0], args[1]}, Reason: calculator.ErrNotInteger}
+	}
+	return args[0].Round(n, c.rounding)
+}
+
+// runDecimal performs an operation in fixed-point decimal arithmetic.
+// Results are rounded to -scale if it was given.
+func runDecimal(op backendOperation, args []string) (opResult, string, error) {
+	values := make([]calculator.Decimal, len(args))
+	operands := make([]interface{}, len(args))
+	for i, arg := range args {
+		d, err := calculator.ParseDecimal(arg)
+		if err != nil {
+			return opResult{}, "", parseError(i, len(args), err)
+		}
+		values[i] = d
+		operands[i] = d.String()
+	}
+
+	result, err := op.decimal(decimalSettings, values)
+	if err != nil {
+		return opResult{}, "", opError(op.Noun, err)
+	}
+
+	formatted := decimalSettings.finish(result).String()
+	r := opResult{
+		Op:        op.Name,
+		Operands:  operands,
+		Result:    formatted,
+		Formatted: formatted,
+	}
+	return r, op.Format(args) + " = " + formatted, nil
+}
+
+// evaluateDecimal evaluates a parsed expression in fixed-point decimal
+// arithmetic and prints the result.
+func evaluateDecimal(input string, node expr.Node) {
+	result, err := evalDecimal(node, decimalSettings)
+	if err != nil {
+		failEvaluation(input, err)
+		return
+	}
+	formatted := decimalSettings.finish(result).String()
+	printResult(fmt.Sprintf("%s = %s", input, formatted), opResult{
+		Op:         "eval",
+		Expression: input,
+		Result:     formatted,
+		Formatted:  formatted,
+	})
+}
+
+// evalDecimal evaluates an expression of numbers, the operators + - * / ^
+// and sqrt, reading literals exactly as written. Errors are *expr.EvalError
+// values carrying the column of the failing node.
+func evalDecimal(node expr.Node, c *decimalContext) (calculator.Decimal, error) {
+	failAt := func(col int, err error) (calculator.Decimal, error) {
+		return calculator.Decimal{}, &expr.EvalError{Col: col, Err: err}
+	}
+
+	switch n := node.(type) {
+	case *expr.NumberLit:
+		d, err := calculator.ParseDecimal(n.Text)
+		if err != nil {
+			return failAt(n.Col, err)
+		}
+		return d, nil
+	case *expr.UnaryExpr:
+		v, err := evalDecimal(n.Operand, c)
+		if err != nil || n.Op == "+" {
+			return v, err
+		}
+		return calculator.SubtractDecimal(calculator.Decimal{}, v), nil
+	case *expr.BinaryExpr:
+		left, err := evalDecimal(n.Left, c)
+		if err != nil {
+			return calculator.Decimal{}, err
+		}
+		right, err := evalDecimal(n.Right, c)
+		if err != nil {
+			return calculator.Decimal{}, err
+		}
+		var result calculator.Decimal
+		switch n.Op {
+		case "+":
+			result = calculator.AddDecimal(left, right)
+		case "-":
+			result = calculator.SubtractDecimal(left, right)
+		case "*":
+			result = calculator.MultiplyDecimal(left, right)
+		case "/":
+			result, err = c.divide(left, right)
+		case "^":
+			result, err = c.power(left, right)
+		default:
+			err = fmt.Errorf("unknown operator %q", n.Op)
+		}
+		if err != nil {
+			return failAt(n.Col, err)
+		}
+		return result, nil
+	case *expr.CallExpr:
+		if n.Name != "sqrt" {
+			return failAt(n.Col, fmt.Errorf("function %s is %w", n.Name, errNotDecimal))
+		}
+		if len(n.Args) != 1 {
+			return failAt(n.Col, fmt.Errorf("sqrt expects 1 argument(s), got %d", len(n.Args)))
+		}
+		v, err := evalDecimal(n.Args[0], c)
+		if err != nil {
+			return calculator.Decimal{}, err
+		}
+		root, err := c.sqrt(v)
+		if err != nil {
+			return failAt(n.Col, err)
+		}
+		return root, nil
+	case *expr.VarRef:
+		return failAt(n.Col, fmt.Errorf("variable %s is %w", n.Name, errNotDecimal))
+	case *expr.AssignExpr:
+		return failAt(n.Col, fmt.Errorf("assignment is %w", errNotDecimal))
+	case *expr.ConvertExpr:
+		return failAt(n.Col, fmt.Errorf("unit conversion is %w", errNotDecimal))
+	default:
+		return calculator.Decimal{}, fmt.Errorf("%s is %w", node, errNotDecimal)
+	}
+}
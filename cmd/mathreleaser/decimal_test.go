@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestDecimalFlag tests operations and expressions in fixed-point decimal
+// mode
+func TestDecimalFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"add_tenths", []string{"-decimal", "-op=add", "0.1", "0.2"}, "0.1 + 0.2 = 0.3\n", "", exitOK},
+		{"add_keeps_scale", []string{"-decimal", "-op=add", "0.10", "0.20", "0.05"}, "0.10 + 0.20 + 0.05 = 0.35\n", "", exitOK},
+		{"subtract", []string{"-decimal", "-op=sub", "1", "0.99"}, "1 - 0.99 = 0.01\n", "", exitOK},
+		{"divide_default_scale", []string{"-decimal", "-op=divide", "1", "3"}, "1 / 3 = 0.3333333333333333\n", "", exitOK},
+		{"divide_trims_zeros", []string{"-decimal", "-op=divide", "10", "4"}, "10 / 4 = 2.5\n", "", exitOK},
+		{"divide_fixed_scale", []string{"-decimal", "-scale=4", "-op=divide", "10", "4"}, "10 / 4 = 2.5000\n", "", exitOK},
+		{"multiply_rounded", []string{"-decimal", "-scale=2", "-rounding=half-up", "-op=multiply", "19.99", "0.0825"}, "19.99 * 0.0825 = 1.65\n", "", exitOK},
+		{"multiply_floor", []string{"-decimal", "-scale=2", "-rounding=floor", "-op=multiply", "19.99", "0.0825"}, "19.99 * 0.0825 = 1.64\n", "", exitOK},
+		{"power", []string{"-decimal", "-op=power", "1.1", "3"}, "1.1 ^ 3 = 1.331\n", "", exitOK},
+		{"negative_power", []string{"-decimal", "-op=power", "2", "-2"}, "2 ^ -2 = 0.25\n", "", exitOK},
+		{"sqrt", []string{"-decimal", "-scale=10", "-op=sqrt", "2"}, "sqrt(2) = 1.4142135624\n", "", exitOK},
+		{"mean", []string{"-decimal", "-scale=2", "-op=mean", "1", "2", "4"}, "mean(1, 2, 4) = 2.33\n", "", exitOK},
+		{"round_half_even", []string{"-decimal", "-op=round", "2.665", "2"}, "round(2.665, 2) = 2.66\n", "", exitOK},
+		{"round_half_up", []string{"-decimal", "-rounding=half-up", "-op=round", "2.665", "2"}, "round(2.665, 2) = 2.67\n", "", exitOK},
+		{"round_up", []string{"-decimal", "-rounding=up", "-op=round", "2.661", "2"}, "round(2.661, 2) = 2.67\n", "", exitOK},
+		{"expression", []string{"-decimal", "-e", "0.1+0.2"}, "0.1+0.2 = 0.3\n", "", exitOK},
+		{"expression_operators", []string{"-decimal", "-e", "(1.5 + 2.25) * 2^-1 - sqrt(0.25)"}, "= 1.375\n", "", exitOK},
+		{"expression_scale", []string{"-decimal", "-scale=2", "-e", "10/3"}, "10/3 = 3.33\n", "", exitOK},
+		{"json", []string{"-output=json", "-decimal", "-op=add", "0.1", "0.2"}, `{"op":"add","operands":["0.1","0.2"],"result":"0.3","formatted":"0.3"}`, "", exitOK},
+		{"divide_by_zero", []string{"-decimal", "-op=divide", "1", "0"}, "", "division by zero", exitDivisionByZero},
+		{"fractional_exponent", []string{"-decimal", "-op=power", "2", "0.5"}, "", "exponent must be an integer", exitDomain},
+		{"negative_sqrt", []string{"-decimal", "-op=sqrt", "--", "-4"}, "", "square root of negative number", exitDomain},
+		{"invalid_number", []string{"-decimal", "-op=add", "1", "1e"}, "", "Error parsing second number", exitInvalidInput},
+		{"expression_variable", []string{"-decimal", "-e", "2*pi"}, "", "column 3: variable pi is not supported with -decimal", exitUsage},
+		{"expression_function", []string{"-decimal", "-e", "sin(1)"}, "", "function sin is not supported with -decimal", exitUsage},
+		{"unsupported_op", []string{"-decimal", "-op=ln", "2"}, "", "not supported with -decimal", exitUsage},
+		{"unknown_rounding", []string{"-decimal", "-rounding=bankers", "-op=add", "1", "2"}, "", "unknown rounding mode", exitUsage},
+		{"negative_scale", []string{"-decimal", "-scale=-1", "-op=add", "1", "2"}, "", "-scale must be between 0 and 100000", exitUsage},
+		{"huge_scale", []string{"-decimal", "-scale=100000000000", "-op=divide", "1", "3"}, "", "-scale must be between 0 and 100000", exitUsage},
+		{"round_fractional_places", []string{"-decimal", "-op=round", "2.345", "1.5"}, "", "Error performing rounding: operand must be an integer", exitDomain},
+		{"round_huge_places", []string{"-decimal", "-op=round", "2.345", "100000000000"}, "", "result too large", exitOverflow},
+		{"scale_without_decimal", []string{"-scale=2", "-op=add", "1", "2"}, "", "-scale and -rounding need -decimal", exitUsage},
+		{"with_exact", []string{"-decimal", "-exact", "-op=add", "1", "2"}, "", "cannot be combined", exitUsage},
+		{"missing_args", []string{"-decimal", "-op=divide", "1"}, "Usage: mathreleaser -decimal", "", exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -23,7 +23,7 @@ func TestDefaultWithArgs(t *testing.T) {
 		t.Errorf("Expected 'Unknown operation' error, got stdout: %s, stderr: %s", stdout, stderr)
 	}
 
-	if exitCode != 1 {
-		t.Errorf("Expected exit code 1 for unknown operation, got %d", exitCode)
+	if exitCode != exitUsage {
+		t.Errorf("Expected exit code %d for unknown operation, got %d", exitUsage, exitCode)
 	}
 }
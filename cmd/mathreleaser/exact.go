@@ -0,0 +1,68 @@
+package main
+
+import (
+	"github.com/PingDavidR/go-release-test/internal/helpers"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// fractionFormat is the format of -exact results, set with -format.
+var fractionFormat = helpers.FractionImproper
+
+// exactBackend works on exact fractions. It is selected with -exact.
+var exactBackend = &backend{
+	name:     "exact",
+	flag:     "-exact",
+	flags:    "-exact [-format=improper|mixed|decimal]",
+	with:     "with -exact",
+	operand:  "number",
+	supports: func(op backendOperation) bool { return op.exact != nil },
+	run:      runExact,
+}
+
+// exactFold adapts a two-operand function to backendOperation.exact,
+// applying it from left to right across the operands.
+func exactFold(fn func(a, b calculator.Rational) calculator.Rational) func([]calculator.Rational) (calculator.Rational, error) {
+	return func(args []calculator.Rational) (calculator.Rational, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = fn(result, arg)
+		}
+		return result, nil
+	}
+}
+
+// exact2 adapts a two-operand function to backendOperation.exact.
+func exact2(fn func(a, b calculator.Rational) (calculator.Rational, error)) func([]calculator.Rational) (calculator.Rational, error) {
+	return func(args []calculator.Rational) (calculator.Rational, error) { return fn(args[0], args[1]) }
+}
+
+// runExact performs an operation on exact fractions, and formats the result
+// in fractionFormat.
+func runExact(op backendOperation, args []string) (opResult, string, error) {
+	values := make([]calculator.Rational, len(args))
+	operands := make([]interface{}, len(args))
+	for i, arg := range args {
+		q, err := calculator.ParseRational(arg)
+		if err != nil {
+			return opResult{}, "", parseError(i, len(args), err)
+		}
+		values[i] = q
+		// Fractions are not JSON numbers, so operands and result are
+		// reported as strings in lowest terms, such as "1/3".
+		operands[i] = q.String()
+	}
+
+	result, err := op.exact(values)
+	if err != nil {
+		return opResult{}, "", opError(op.Noun, err)
+	}
+
+	formatted := helpers.FormatFraction(result.Rat(), fractionFormat)
+	r := opResult{
+		Op:        op.Name,
+		Operands:  operands,
+		Result:    result.String(),
+		Formatted: formatted,
+	}
+	return r, op.Format(args) + " = " + formatted, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestExactFlag tests operations in exact fraction mode
+func TestExactFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"divide", []string{"-op=divide", "-exact", "1", "3"}, "1 / 3 = 1/3", "", exitOK},
+		{"add_tenths", []string{"-exact", "-op=add", "0.1", "0.2"}, "0.1 + 0.2 = 3/10", "", exitOK},
+		{"fraction_input", []string{"-exact", "-op=multiply", "2/3", "3/4"}, "2/3 * 3/4 = 1/2", "", exitOK},
+		{"mixed", []string{"-exact", "-format=mixed", "-op=divide", "7", "3"}, "7 / 3 = 2 1/3", "", exitOK},
+		{"decimal", []string{"-exact", "-format=decimal", "-op=divide", "1", "6"}, "1 / 6 = 0.1(6)", "", exitOK},
+		{"power", []string{"-exact", "-op=power", "2/3", "3"}, "2/3 ^ 3 = 8/27", "", exitOK},
+		{"add_variadic", []string{"-exact", "-op=add", "1/2", "1/3", "1/6"}, "1/2 + 1/3 + 1/6 = 1", "", exitOK},
+		{"alias", []string{"-exact", "-op=div", "1", "4"}, "1 / 4 = 1/4", "", exitOK},
+		{"divide_by_zero", []string{"-exact", "-op=divide", "1", "0"}, "", "Error performing division: division by zero", exitDivisionByZero},
+		{"fractional_power", []string{"-exact", "-op=power", "2", "0.5"}, "", "exponent must be an integer", exitDomain},
+		{"invalid_number", []string{"-exact", "-op=add", "1", "x"}, "", "Error parsing second number", exitInvalidInput},
+		{"bad_format", []string{"-exact", "-format=roman", "-op=add", "1", "2"}, "", "unknown fraction format", exitUsage},
+		{"unsupported_op", []string{"-exact", "-op=sqrt", "2"}, "", "not supported with -exact", exitUsage},
+		{"with_precision", []string{"-exact", "-precision=64", "-op=add", "1", "2"}, "", "cannot be combined", exitUsage},
+		{"missing_args", []string{"-exact", "-op=add", "1"}, "Usage:", "", exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
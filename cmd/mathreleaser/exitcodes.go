@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/currency"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/finance"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/linalg"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/numeric"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/poly"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/stats"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/units"
+)
+
+// Exit codes returned by mathreleaser. Each class of failure has its own
+// code so that scripts can react without parsing error messages. These
+// values are documented in the README and must not be renumbered.
+const (
+	exitOK             = 0 // success
+	exitError          = 1 // failure not covered by a more specific code
+	exitUsage          = 2 // wrong arguments, unknown operation or bad flag
+	exitInvalidInput   = 3 // a number or expression could not be parsed
+	exitDivisionByZero = 4 // calculator.ErrDivisionByZero
+	exitDomain         = 5 // operand outside the domain of the operation
+	exitOverflow       = 6 // result too large to represent or compute
+	exitNonFinite      = 7 // an operand is NaN or infinite
+	exitNoConvergence  = 8 // an iterative method did not converge
+)
+
+// exitCodeFor maps an error returned by the calculator packages to the exit
+// code of its class.
+func exitCodeFor(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, calculator.ErrDivisionByZero):
+		return exitDivisionByZero
+	case errors.Is(err, calculator.ErrOverflow), errors.Is(err, calculator.ErrResultTooLarge):
+		return exitOverflow
+	case errors.Is(err, calculator.ErrNonFinite):
+		return exitNonFinite
+	case errors.Is(err, numeric.ErrNoConvergence), errors.Is(err, poly.ErrNoConvergence),
+		errors.Is(err, numeric.ErrZeroDerivative), errors.Is(err, calculator.ErrFactorizationFailed):
+		return exitNoConvergence
+	case errors.Is(err, calculator.ErrNegativeSquareRoot),
+		errors.Is(err, calculator.ErrNegativeFractionalPower),
+		errors.Is(err, calculator.ErrNonIntegerExponent),
+		errors.Is(err, calculator.ErrLogOfZero),
+		errors.Is(err, calculator.ErrLogOfNegative),
+		errors.Is(err, calculator.ErrInvalidBase),
+		errors.Is(err, calculator.ErrNegativeEvenRoot),
+		errors.Is(err, calculator.ErrNotInteger),
+		errors.Is(err, calculator.ErrNoOperands),
+		errors.Is(err, calculator.ErrNoInverse),
+		errors.Is(err, calculator.ErrOutOfRange),
+		errors.Is(err, calculator.ErrUndefined),
+		errors.Is(err, stats.ErrEmpty),
+		errors.Is(err, stats.ErrTooFewValues),
+		errors.Is(err, stats.ErrZeroVariance),
+		errors.Is(err, linalg.ErrDimensionMismatch),
+		errors.Is(err, units.ErrDimensionMismatch),
+		errors.Is(err, currency.ErrNoRate),
+		errors.Is(err, finance.ErrInvalidRate),
+		errors.Is(err, finance.ErrInvalidPeriods),
+		errors.Is(err, finance.ErrInvalidAmount),
+		errors.Is(err, finance.ErrInvalidSalvage),
+		errors.Is(err, finance.ErrNoSolution),
+		errors.Is(err, finance.ErrNoSignChange),
+		errors.Is(err, units.ErrFractionalPower),
+		errors.Is(err, units.ErrNotDimensionless),
+		errors.Is(err, linalg.ErrNotSquare),
+		errors.Is(err, linalg.ErrSingular),
+		errors.Is(err, poly.ErrZeroPolynomial),
+		errors.Is(err, numeric.ErrNoBracket):
+		return exitDomain
+	case errors.Is(err, linalg.ErrRagged), errors.Is(err, linalg.ErrEmpty), errors.Is(err, units.ErrUnknownUnit),
+		errors.Is(err, currency.ErrInvalidCode):
+		return exitInvalidInput
+	case errors.Is(err, stats.ErrInvalidPercentile), errors.Is(err, stats.ErrInvalidBins),
+		errors.Is(err, numeric.ErrInvalidTolerance), errors.Is(err, numeric.ErrInvalidSteps),
+		errors.Is(err, numeric.ErrInvalidIterations), errors.Is(err, finance.ErrInvalidPlaces),
+		errors.Is(err, calculator.ErrNegativeScale), errors.Is(err, calculator.ErrPrecisionTooLarge),
+		errors.Is(err, errNotDecimal):
+		return exitUsage
+	default:
+		return exitError
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/currency"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/finance"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/linalg"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/numeric"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/poly"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/stats"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/units"
+)
+
+// TestExitCodeFor tests the mapping from calculator errors to exit codes
+func TestExitCodeFor(t *testing.T) {
+	_, divErr := calculator.Divide(1, 0)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"nil", nil, exitOK},
+		{"division_by_zero", divErr, exitDivisionByZero},
+		{"negative_sqrt", calculator.ErrNegativeSquareRoot, exitDomain},
+		{"undefined", calculator.ErrUndefined, exitDomain},
+		{"non_integer_exponent", calculator.ErrNonIntegerExponent, exitDomain},
+		{"log_of_negative", calculator.ErrLogOfNegative, exitDomain},
+		{"invalid_base", calculator.ErrInvalidBase, exitDomain},
+		{"negative_even_root", calculator.ErrNegativeEvenRoot, exitDomain},
+		{"not_integer", calculator.ErrNotInteger, exitDomain},
+		{"no_inverse", calculator.ErrNoInverse, exitDomain},
+		{"out_of_range", calculator.ErrOutOfRange, exitDomain},
+		{"overflow", calculator.ErrOverflow, exitOverflow},
+		{"too_large", calculator.ErrResultTooLarge, exitOverflow},
+		{"non_finite", calculator.ErrNonFinite, exitNonFinite},
+		{"too_few_values", stats.ErrTooFewValues, exitDomain},
+		{"invalid_percentile", stats.ErrInvalidPercentile, exitUsage},
+		{"singular_matrix", linalg.ErrSingular, exitDomain},
+		{"ragged_matrix", linalg.ErrRagged, exitInvalidInput},
+		{"zero_polynomial", poly.ErrZeroPolynomial, exitDomain},
+		{"roots_no_convergence", poly.ErrNoConvergence, exitNoConvergence},
+		{"no_convergence", &numeric.ConvergenceError{Op: "integrate"}, exitNoConvergence},
+		{"invalid_tolerance", numeric.ErrInvalidTolerance, exitUsage},
+		{"no_bracket", numeric.ErrNoBracket, exitDomain},
+		{"zero_derivative", numeric.ErrZeroDerivative, exitNoConvergence},
+		{"factorization_failed", calculator.ErrFactorizationFailed, exitNoConvergence},
+		{"dimension_mismatch", units.ErrDimensionMismatch, exitDomain},
+		{"unknown_unit", units.ErrUnknownUnit, exitInvalidInput},
+		{"no_exchange_rate", currency.ErrNoRate, exitDomain},
+		{"invalid_currency_code", currency.ErrInvalidCode, exitInvalidInput},
+		{"invalid_finance_rate", finance.ErrInvalidRate, exitDomain},
+		{"no_finance_solution", finance.ErrNoSolution, exitDomain},
+		{"no_sign_change", finance.ErrNoSignChange, exitDomain},
+		{"invalid_places", finance.ErrInvalidPlaces, exitUsage},
+		{"negative_scale", calculator.ErrNegativeScale, exitUsage},
+		{"precision_too_large", calculator.ErrPrecisionTooLarge, exitUsage},
+		{"not_decimal", errNotDecimal, exitUsage},
+		{"other", errors.New("something else"), exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCodeFor(tt.err); got != tt.expected {
+				t.Errorf("exitCodeFor(%v) = %d, want %d", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestOperationExitCodes tests that domain failures exit with their class code
+func TestOperationExitCodes(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+		exitCode    int
+	}{
+		{"tan_at_pole", []string{"-op=tan", "1.5707963267948966"}, "Error performing tangent: undefined result", exitDomain},
+		{"power_zero_negative", []string{"-op=power", "0", "-1"}, "Error performing power: division by zero", exitDivisionByZero},
+		{"power_overflow", []string{"-op=power", "10", "400"}, "Error performing power: result overflows", exitOverflow},
+		{"exp_overflow", []string{"-op=exp", "1000"}, "Error performing exponential: result overflows", exitOverflow},
+		{"ln_zero", []string{"-op=ln", "0"}, "Error performing logarithm: logarithm of zero", exitDomain},
+		{"sqrt_infinite", []string{"-op=sqrt", "Inf"}, "Error performing square root: input is NaN or infinite", exitNonFinite},
+		{"add_nan", []string{"-op=add", "NaN", "1"}, "Error performing addition: input is NaN or infinite", exitNonFinite},
+		{"subtract_overflow", []string{"-op=subtract", "--", "-1e308", "1e308"}, "Error performing subtraction: result overflows", exitOverflow},
+		{"multiply_overflow", []string{"-op=multiply", "1e308", "10"}, "Error performing multiplication: result overflows", exitOverflow},
+		{"sin_infinite", []string{"-op=sin", "Inf"}, "Error performing sine: input is NaN or infinite", exitNonFinite},
+		{"cos_infinite", []string{"-op=cos", "Inf"}, "Error performing cosine: input is NaN or infinite", exitNonFinite},
+		{"random_nan", []string{"-op=random", "NaN", "1"}, "Error performing random number: input is NaN or infinite", exitNonFinite},
+		{"random_infinite", []string{"-op=random", "1", "Inf"}, "Error performing random number: input is NaN or infinite", exitNonFinite},
+		{"atan2_nan", []string{"-op=atan2", "NaN", "1"}, "Error performing arctangent: input is NaN or infinite", exitNonFinite},
+		{"integrate_no_convergence", []string{"integrate", "-tol=1e-15", "sin(1/x)", "1e-300", "1"}, "Error performing integration: integrate did not converge", exitNoConvergence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/units"
+)
+
+// unitTable holds the units -e expressions may use: the default units and
+// any loaded with -units.
+var unitTable = units.DefaultTable()
+
+// evaluateExpression parses and evaluates an infix expression passed with -e
+// and prints the result.
+func evaluateExpression(input string) {
+	node, err := expr.Parse(input)
+	if err != nil {
+		e := errorResult{Code: exitInvalidInput, Message: fmt.Sprintf("Error parsing expression: %v", err)}
+		var syntaxErr *expr.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			e.Column = syntaxErr.Col
+		}
+		failExpression(input, e)
+		return
+	}
+
+	if decimalSettings != nil {
+		evaluateDecimal(input, node)
+		return
+	}
+
+	env := newEnv()
+	if unitTable.Mentions(node, env) {
+		evaluateQuantity(input, node, env)
+		return
+	}
+
+	result, err := node.Eval(env)
+	if err != nil {
+		failEvaluation(input, err)
+		return
+	}
+
+	formatted := numberFormat.Format(result)
+	printResult(fmt.Sprintf("%s = %s", input, formatted), opResult{
+		Op:         "eval",
+		Expression: input,
+		Result:     output.Float(result),
+		Formatted:  formatted,
+	})
+}
+
+// evaluateQuantity evaluates an expression with units, such as
+// "3 km + 200 m in mi", and prints the result with its unit.
+func evaluateQuantity(input string, node expr.Node, env *expr.Env) {
+	q, err := unitTable.EvalNode(node, env)
+	if err != nil {
+		failEvaluation(input, err)
+		return
+	}
+
+	unit := q.Unit().Name
+	formatted := numberFormat.Format(q.Value())
+	if unit != "" {
+		formatted += " " + unit
+	}
+	printResult(fmt.Sprintf("%s = %s", input, formatted), opResult{
+		Op:         "eval",
+		Expression: input,
+		Result:     output.Float(q.Value()),
+		Unit:       unit,
+		Formatted:  formatted,
+	})
+}
+
+// failEvaluation reports an error from evaluating an expression, with the
+// column of the node that failed and, for calculation errors, the operation
+// and operands, e.g. "add(5 kg, 3 s): dimension mismatch".
+func failEvaluation(input string, err error) {
+	e := errorResult{Code: exitCodeFor(err), Message: fmt.Sprintf("Error evaluating expression: %v", err)}
+	var evalErr *expr.EvalError
+	if errors.As(err, &evalErr) {
+		e.Column = evalErr.Col
+	}
+	var domainErr *calculator.DomainError
+	if errors.As(err, &domainErr) {
+		e.Detail = domainErr.Detail()
+	}
+	failExpression(input, e)
+}
+
+// failExpression reports an expression error. Text output points at the
+// offending column with a caret; the structured formats carry the column.
+func failExpression(input string, e errorResult) {
+	if outputFormat == output.Text {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", e.Message)
+		if e.Column > 0 {
+			printErrorCaret(input, e.Column)
+		}
+		osExit(e.Code)
+		return
+	}
+	fail(e)
+}
+
+// printErrorCaret prints the expression to stderr with a caret under the
+// given 1-based column.
+func printErrorCaret(input string, col int) {
+	fmt.Fprintf(os.Stderr, "  %s\n  %s^\n", input, strings.Repeat(" ", col-1))
+}
+
+// loadUnits adds the unit definitions in the file at path, given with
+// -units, to unitTable.
+func loadUnits(path string) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return &errorResult{Code: exitError, Message: fmt.Sprintf("Error reading units: %v", err)}
+	}
+	defer f.Close()
+	if err := unitTable.Load(f); err != nil {
+		return &errorResult{Code: exitInvalidInput, Message: fmt.Sprintf("Error loading units from %s: %v", path, err)}
+	}
+	return nil
+}
+
+// newEnv returns the default expression environment, with angles in the
+// unit selected with -angle.
+func newEnv() *expr.Env {
+	return expr.NewAngleEnv(angleUnit)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestExpressionFlag tests evaluating infix expressions with -e
+func TestExpressionFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		expression  string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"precedence_and_parens", "2*(3+4)^2", "2*(3+4)^2 = 98.00", "", exitOK},
+		{"function_call", "sqrt(16) + 1", "sqrt(16) + 1 = 5.00", "", exitOK},
+		{"unary_minus", "-2^2", "-2^2 = -4.00", "", exitOK},
+		{"syntax_error_column", "2 * (3 + ", "", "Error parsing expression: column 10", exitInvalidInput},
+		{"division_by_zero", "1 / 0", "", "Error evaluating expression: column 3: division by zero", exitDivisionByZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = []string{"mathreleaser", "-e", tt.expression}
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,476 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/finance"
+)
+
+// financeOps maps each finance operation to its least and greatest number
+// of arguments; a greatest of -1 means any number.
+var financeOps = map[string][2]int{
+	"pv":         {3, 4},
+	"fv":         {3, 4},
+	"pmt":        {3, 4},
+	"nper":       {3, 4},
+	"rate":       {3, 4},
+	"npv":        {2, -1},
+	"irr":        {2, -1},
+	"xirr":       {2, -1},
+	"amortize":   {3, 3},
+	"interest":   {3, 3},
+	"depreciate": {3, 3},
+}
+
+// financeUsage is the usage text of the finance subcommand.
+var financeUsage = []string{
+	"Usage: mathreleaser finance [-begin] pv <rate> <nper> <pmt> [fv]",
+	"       mathreleaser finance [-begin] fv <rate> <nper> <pmt> [pv]",
+	"       mathreleaser finance [-begin] pmt <rate> <nper> <pv> [fv]",
+	"       mathreleaser finance [-begin] nper <rate> <pmt> <pv> [fv]",
+	"       mathreleaser finance [-begin] rate <nper> <pmt> <pv> [fv]",
+	"       mathreleaser finance npv <rate> <cash flow>...",
+	"       mathreleaser finance irr <cash flow>...",
+	"       mathreleaser finance xirr <YYYY-MM-DD:cash flow>...",
+	"       mathreleaser finance [-csv] amortize <principal> <rate> <nper>",
+	"       mathreleaser finance interest <principal> <rate> <periods>",
+	"       mathreleaser finance [-csv] [-method=straight-line] depreciate <cost> <salvage> <life>",
+}
+
+// financeResult is the structured form of a finance operation. Amounts are
+// strings rounded to -places decimal places, computed exactly; rates and
+// numbers of periods are numbers.
+type financeResult struct {
+	Op       string        `json:"op"`
+	Operands []interface{} `json:"operands"`
+	Result   interface{}   `json:"result"`
+}
+
+// installmentResult is one row of an amortization schedule.
+type installmentResult struct {
+	Period    int    `json:"period"`
+	Payment   string `json:"payment"`
+	Interest  string `json:"interest"`
+	Principal string `json:"principal"`
+	Balance   string `json:"balance"`
+}
+
+// depreciationResult is one year of a depreciation schedule.
+type depreciationResult struct {
+	Year        int    `json:"year"`
+	Expense     string `json:"expense"`
+	Accumulated string `json:"accumulated"`
+	BookValue   string `json:"bookValue"`
+}
+
+// interestResult compares simple and compound interest on the same
+// principal.
+type interestResult struct {
+	Simple   string `json:"simple"`
+	Compound string `json:"compound"`
+}
+
+// runFinance implements "mathreleaser finance".
+func runFinance(args []string) {
+	flags := newSubcommandFlags("finance")
+	begin := flags.Bool("begin", false, "Make payments at the start of each period instead of the end")
+	places := flags.Int("places", 2, "Decimal places amounts are rounded to")
+	csvTable := flags.Bool("csv", false, "Print amortization and depreciation schedules as CSV")
+	methodName := flags.String("method", "straight-line", "Depreciation method: straight-line, declining-balance or sum-of-years-digits")
+	positional, ok := parseSubcommandFlags(flags, args)
+	if !ok {
+		return
+	}
+
+	if len(positional) == 0 {
+		usage(financeUsage...)
+		return
+	}
+	op, operands := positional[0], positional[1:]
+	arity, known := financeOps[op]
+	if !known {
+		failf(exitUsage, "Unknown finance operation: %s", op)
+		return
+	}
+	if len(operands) < arity[0] || arity[1] >= 0 && len(operands) > arity[1] {
+		usage(financeUsage...)
+		return
+	}
+	if *places < 0 {
+		failf(exitUsage, "-places must not be negative")
+		return
+	}
+	if *csvTable && (op != "amortize" && op != "depreciate" || outputFormat != output.Text) {
+		failf(exitUsage, "-csv only applies to the text output of amortize and depreciate")
+		return
+	}
+	method, err := finance.ParseMethod(*methodName)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+	when := finance.End
+	if *begin {
+		when = finance.Begin
+	}
+
+	f := financeCalculation{places: *places, r: financeResult{Op: op, Operands: make([]interface{}, len(operands))}}
+	for i, arg := range operands {
+		f.r.Operands[i] = arg
+	}
+	var text string
+	switch op {
+	case "pv", "fv", "pmt":
+		text, ok = f.timeValue(op, operands, when)
+	case "nper", "rate":
+		text, ok = f.solve(op, operands, when)
+	case "npv":
+		text, ok = f.npv(operands)
+	case "irr", "xirr":
+		text, ok = f.irr(op, operands)
+	case "amortize":
+		text, ok = f.amortize(operands, *csvTable)
+	case "interest":
+		text, ok = f.interest(operands)
+	case "depreciate":
+		text, ok = f.depreciate(operands, method, *csvTable)
+	}
+	if !ok {
+		return
+	}
+
+	if outputFormat == output.Text {
+		fmt.Print(text)
+		return
+	}
+	encode(os.Stdout, f.r)
+}
+
+// financeCalculation carries the settings and structured result of one
+// finance operation. Its methods parse the operands, store the result in r
+// and return the text output, or report an error and return false.
+type financeCalculation struct {
+	places int
+	r      financeResult
+}
+
+// timeValue implements pv, fv and pmt, whose operands are a rate, a number
+// of periods and two amounts, the second defaulting to zero.
+func (f *financeCalculation) timeValue(op string, operands []string, when finance.Timing) (string, bool) {
+	rate, ok := parseFinanceRate(operands[0])
+	if !ok {
+		return "", false
+	}
+	nper, ok := parsePeriods(operands[1])
+	if !ok {
+		return "", false
+	}
+	amounts, ok := parseAmounts(padAmounts(operands[2:], 2))
+	if !ok {
+		return "", false
+	}
+
+	fn := map[string]func(calculator.Rational, int, calculator.Rational, calculator.Rational, finance.Timing) (calculator.Rational, error){
+		"pv":  finance.PV,
+		"fv":  finance.FV,
+		"pmt": finance.PMT,
+	}[op]
+	result, err := fn(rate, nper, amounts[0], amounts[1], when)
+	if err != nil {
+		failOp(strings.ToUpper(op)+" calculation", err)
+		return "", false
+	}
+	f.r.Result = f.amount(result)
+	return fmt.Sprintf("%s = %s\n", op, f.r.Result), true
+}
+
+// solve implements nper and rate, which are found numerically.
+func (f *financeCalculation) solve(op string, operands []string, when finance.Timing) (string, bool) {
+	amounts, ok := parseAmounts(padAmounts(operands[1:], 3))
+	if !ok {
+		return "", false
+	}
+	pmt, pv, fv := amounts[0], amounts[1], amounts[2]
+
+	if op == "nper" {
+		rate, ok := parseFinanceRate(operands[0])
+		if !ok {
+			return "", false
+		}
+		n, err := finance.NPER(rate, pmt, pv, fv, when)
+		if err != nil {
+			failOp("NPER calculation", err)
+			return "", false
+		}
+		f.r.Result = output.Float(n)
+		return fmt.Sprintf("nper = %s\n", strconv.FormatFloat(n, 'f', 4, 64)), true
+	}
+
+	nper, ok := parsePeriods(operands[0])
+	if !ok {
+		return "", false
+	}
+	rate, err := finance.RATE(nper, pmt, pv, fv, when)
+	if err != nil {
+		failOp("RATE calculation", err)
+		return "", false
+	}
+	f.r.Result = output.Float(rate)
+	return fmt.Sprintf("rate = %s per period\n", percent(rate)), true
+}
+
+// npv implements npv, whose operands are a rate and the cash flows.
+func (f *financeCalculation) npv(operands []string) (string, bool) {
+	rate, ok := parseFinanceRate(operands[0])
+	if !ok {
+		return "", false
+	}
+	flows, ok := parseAmounts(operands[1:])
+	if !ok {
+		return "", false
+	}
+	result, err := finance.NPV(rate, flows)
+	if err != nil {
+		failOp("NPV calculation", err)
+		return "", false
+	}
+	f.r.Result = f.amount(result)
+	return fmt.Sprintf("npv = %s\n", f.r.Result), true
+}
+
+// irr implements irr, whose operands are cash flows one period apart, and
+// xirr, whose operands are dated cash flows such as "2024-01-31:-1000".
+func (f *financeCalculation) irr(op string, operands []string) (string, bool) {
+	var rate float64
+	var err error
+	if op == "irr" {
+		flows, ok := parseAmounts(operands)
+		if !ok {
+			return "", false
+		}
+		rate, err = finance.IRR(flows)
+	} else {
+		flows := make([]finance.CashFlow, len(operands))
+		for i, arg := range operands {
+			dateText, amountText, found := strings.Cut(arg, ":")
+			date, dateErr := time.Parse("2006-01-02", dateText)
+			if !found || dateErr != nil {
+				failf(exitInvalidInput, "Error parsing cash flow %q: want YYYY-MM-DD:amount", arg)
+				return "", false
+			}
+			amount, ok := parseAmounts([]string{amountText})
+			if !ok {
+				return "", false
+			}
+			flows[i] = finance.CashFlow{Date: date, Amount: amount[0]}
+		}
+		rate, err = finance.XIRR(flows)
+	}
+	if err != nil {
+		failOp(strings.ToUpper(op)+" calculation", err)
+		return "", false
+	}
+	f.r.Result = output.Float(rate)
+	return fmt.Sprintf("%s = %s\n", op, percent(rate)), true
+}
+
+// amortize implements amortize, printing the schedule as a table or CSV.
+func (f *financeCalculation) amortize(operands []string, asCSV bool) (string, bool) {
+	amounts, ok := parseAmounts(operands[:1])
+	if !ok {
+		return "", false
+	}
+	rate, ok := parseFinanceRate(operands[1])
+	if !ok {
+		return "", false
+	}
+	nper, ok := parsePeriods(operands[2])
+	if !ok {
+		return "", false
+	}
+	schedule, err := finance.Amortize(amounts[0], rate, nper, f.places)
+	if err != nil {
+		failOp("amortization", err)
+		return "", false
+	}
+
+	rows := make([]installmentResult, len(schedule))
+	table := make([][]string, len(schedule))
+	totalPaid, totalInterest := new(big.Rat), new(big.Rat)
+	for i, p := range schedule {
+		rows[i] = installmentResult{
+			Period:    p.Period,
+			Payment:   f.amount(p.Payment),
+			Interest:  f.amount(p.Interest),
+			Principal: f.amount(p.Principal),
+			Balance:   f.amount(p.Balance),
+		}
+		table[i] = []string{strconv.Itoa(p.Period), rows[i].Payment, rows[i].Interest, rows[i].Principal, rows[i].Balance}
+		totalPaid.Add(totalPaid, p.Payment.Rat())
+		totalInterest.Add(totalInterest, p.Interest.Rat())
+	}
+	f.r.Result = rows
+
+	header := []string{"period", "payment", "interest", "principal", "balance"}
+	if asCSV {
+		return csvText(header, table), true
+	}
+	total := []string{"total", totalPaid.FloatString(f.places), totalInterest.FloatString(f.places), amounts[0].Rat().FloatString(f.places)}
+	return tableText(header, append(table, total)), true
+}
+
+// interest implements interest, comparing simple interest with interest
+// compounded every period.
+func (f *financeCalculation) interest(operands []string) (string, bool) {
+	amounts, ok := parseAmounts(operands[:1])
+	if !ok {
+		return "", false
+	}
+	rate, ok := parseFinanceRate(operands[1])
+	if !ok {
+		return "", false
+	}
+	periods, ok := parsePeriods(operands[2])
+	if !ok {
+		return "", false
+	}
+	compound, err := finance.CompoundInterest(amounts[0], rate, periods)
+	if err != nil {
+		failOp("interest calculation", err)
+		return "", false
+	}
+	simple := finance.SimpleInterest(amounts[0], rate, calculator.NewRational(int64(periods), 1))
+
+	r := interestResult{Simple: f.amount(simple), Compound: f.amount(compound)}
+	f.r.Result = r
+	return fmt.Sprintf("simple interest = %s\ncompound interest = %s\n", r.Simple, r.Compound), true
+}
+
+// depreciate implements depreciate, printing the schedule as a table or
+// CSV.
+func (f *financeCalculation) depreciate(operands []string, method finance.Method, asCSV bool) (string, bool) {
+	amounts, ok := parseAmounts(operands[:2])
+	if !ok {
+		return "", false
+	}
+	life, ok := parsePeriods(operands[2])
+	if !ok {
+		return "", false
+	}
+	schedule, err := finance.Depreciate(amounts[0], amounts[1], life, method)
+	if err != nil {
+		failOp("depreciation", err)
+		return "", false
+	}
+
+	rows := make([]depreciationResult, len(schedule))
+	table := make([][]string, len(schedule))
+	for i, d := range schedule {
+		rows[i] = depreciationResult{
+			Year:        d.Year,
+			Expense:     f.amount(d.Expense),
+			Accumulated: f.amount(d.Accumulated),
+			BookValue:   f.amount(d.BookValue),
+		}
+		table[i] = []string{strconv.Itoa(d.Year), rows[i].Expense, rows[i].Accumulated, rows[i].BookValue}
+	}
+	f.r.Result = rows
+
+	header := []string{"year", "expense", "accumulated", "book value"}
+	if asCSV {
+		return csvText(header, table), true
+	}
+	return tableText(header, table), true
+}
+
+// amount rounds an amount to the -places decimal places.
+func (f *financeCalculation) amount(q calculator.Rational) string {
+	return q.Rat().FloatString(f.places)
+}
+
+// parseFinanceRate parses a rate per period written as a fraction, such as
+// "0.05" or "5/1200", or as a percentage, such as "5%".
+// It reports an error and returns false on failure.
+func parseFinanceRate(s string) (calculator.Rational, bool) {
+	text := strings.TrimSuffix(s, "%")
+	rate, err := calculator.ParseRational(text)
+	if err != nil {
+		failf(exitInvalidInput, "Error parsing rate: %v", err)
+		return calculator.Rational{}, false
+	}
+	if text != s {
+		rate, _ = calculator.DivideRational(rate, calculator.NewRational(100, 1))
+	}
+	return rate, true
+}
+
+// parsePeriods parses a whole number of periods.
+// It reports an error and returns false on failure.
+func parsePeriods(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		failf(exitInvalidInput, "Error parsing number of periods: %q is not a whole number", s)
+		return 0, false
+	}
+	return n, true
+}
+
+// parseAmounts parses amounts of money exactly.
+// It reports an error and returns false on failure.
+func parseAmounts(args []string) ([]calculator.Rational, bool) {
+	amounts := make([]calculator.Rational, len(args))
+	for i, arg := range args {
+		q, err := calculator.ParseRational(arg)
+		if err != nil {
+			failf(exitInvalidInput, "Error parsing amount: %v", err)
+			return nil, false
+		}
+		amounts[i] = q
+	}
+	return amounts, true
+}
+
+// padAmounts returns args followed by as many zeros as needed to make n
+// amounts, for trailing amounts that may be left out.
+func padAmounts(args []string, n int) []string {
+	padded := append([]string(nil), args...)
+	for len(padded) < n {
+		padded = append(padded, "0")
+	}
+	return padded
+}
+
+// percent writes a rate as a percentage with four decimal places, e.g.
+// "8.8963%".
+func percent(rate float64) string {
+	return strconv.FormatFloat(rate*100, 'f', 4, 64) + "%"
+}
+
+// tableText lays out a header and rows as right-aligned columns.
+func tableText(header []string, rows [][]string) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	for _, row := range append([][]string{header}, rows...) {
+		fmt.Fprintf(w, "%s\t\n", strings.Join(row, "\t"))
+	}
+	w.Flush()
+	return b.String()
+}
+
+// csvText writes a header and rows as CSV.
+func csvText(header []string, rows [][]string) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write(header)
+	w.WriteAll(rows)
+	return b.String()
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestFinance tests the finance subcommand
+func TestFinance(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{
+			name:        "pmt",
+			args:        []string{"finance", "pmt", "5/1200", "360", "200000"},
+			expectedOut: []string{"pmt = -1073.64\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "pmt_begin_percent",
+			args:        []string{"finance", "-begin", "pmt", "5%", "12", "1000"},
+			expectedOut: []string{"pmt = -107.45\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "fv",
+			args:        []string{"finance", "fv", "0.005", "120", "-100", "-1000"},
+			expectedOut: []string{"fv = 18207.33\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "pv_places",
+			args:        []string{"finance", "-places=4", "pv", "0.05", "10", "0", "-1000"},
+			expectedOut: []string{"pv = 613.9133\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "nper",
+			args:        []string{"finance", "nper", "1%", "-100", "1000"},
+			expectedOut: []string{"nper = 10.5886\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "rate",
+			args:        []string{"finance", "rate", "360", "-1073.64", "200000"},
+			expectedOut: []string{"rate = 0.4167% per period\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "npv",
+			args:        []string{"finance", "npv", "10%", "-1000", "300", "400", "500"},
+			expectedOut: []string{"npv = -21.04\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "irr_json",
+			args:        []string{"-output=json", "finance", "irr", "-100", "110"},
+			expectedOut: []string{`{"op":"irr","operands":["-100","110"],"result":0.1}` + "\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "xirr",
+			args:        []string{"finance", "xirr", "2008-01-01:-10000", "2008-03-01:2750", "2008-10-30:4250", "2009-02-15:3250", "2009-04-01:2750"},
+			expectedOut: []string{"xirr = 37.3363%\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name: "amortize",
+			args: []string{"finance", "amortize", "1000", "1%", "12"},
+			expectedOut: []string{
+				"  period  payment  interest  principal  balance\n       1    88.85     10.00      78.85   921.15\n",
+				"      12    88.84      0.88      87.96     0.00\n   total  1066.19     66.19    1000.00\n",
+			},
+			exitCode: exitOK,
+		},
+		{
+			name:        "amortize_csv",
+			args:        []string{"finance", "-csv", "amortize", "1000", "1%", "3"},
+			expectedOut: []string{"period,payment,interest,principal,balance\n1,340.02,10.00,330.02,669.98\n2,340.02,6.70,333.32,336.66\n3,340.03,3.37,336.66,0.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "amortize_json",
+			args:        []string{"-output=json", "finance", "amortize", "100", "0", "2"},
+			expectedOut: []string{`"result":[{"period":1,"payment":"50.00","interest":"0.00","principal":"50.00","balance":"50.00"},{"period":2,"payment":"50.00","interest":"0.00","principal":"50.00","balance":"0.00"}]}`},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "interest",
+			args:        []string{"finance", "interest", "1000", "5%", "10"},
+			expectedOut: []string{"simple interest = 500.00\ncompound interest = 628.89\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "depreciate_declining_balance",
+			args:        []string{"finance", "-method=declining-balance", "depreciate", "10000", "1000", "5"},
+			expectedOut: []string{"  year  expense  accumulated  book value\n     1  4000.00      4000.00     6000.00\n", "     5   296.00      9000.00     1000.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "depreciate_csv",
+			args:        []string{"finance", "-csv", "depreciate", "900", "0", "3"},
+			expectedOut: []string{"year,expense,accumulated,book value\n1,300.00,300.00,600.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "no_sign_change",
+			args:        []string{"finance", "irr", "1", "2"},
+			expectedErr: "cash flows must include both payments and receipts",
+			exitCode:    exitDomain,
+		},
+		{
+			name:        "invalid_rate",
+			args:        []string{"finance", "pmt", "-100%", "12", "1000"},
+			expectedErr: "rate must be greater than -100%",
+			exitCode:    exitDomain,
+		},
+		{
+			name:        "invalid_amount",
+			args:        []string{"finance", "pmt", "1%", "12", "lots"},
+			expectedErr: "Error parsing amount",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "invalid_periods",
+			args:        []string{"finance", "pmt", "1%", "1.5", "1000"},
+			expectedErr: "Error parsing number of periods",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "invalid_cash_flow",
+			args:        []string{"finance", "xirr", "2024-01-01:-100", "110"},
+			expectedErr: "want YYYY-MM-DD:amount",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "unknown_method",
+			args:        []string{"finance", "-method=fast", "depreciate", "900", "0", "3"},
+			expectedErr: "unknown depreciation method",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "csv_without_table",
+			args:        []string{"finance", "-csv", "pmt", "1%", "12", "1000"},
+			expectedErr: "-csv only applies",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "unknown_operation",
+			args:        []string{"finance", "mirr", "1"},
+			expectedErr: "Unknown finance operation: mirr",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "wrong_arity",
+			args:        []string{"finance", "pmt", "1%"},
+			expectedOut: []string{"Usage: mathreleaser finance"},
+			exitCode:    exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			for _, want := range tt.expectedOut {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("Expected %q, got stdout: %s, stderr: %s", want, stdout, stderr)
+				}
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
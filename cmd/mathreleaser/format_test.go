@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestNumberFormatFlags tests how -places, -sig, -notation and -locale
+// format results
+func TestNumberFormatFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"default", []string{"-op=divide", "10000", "3"}, "10000 / 3 = 3,333.33\n", "", exitOK},
+		{"places", []string{"-places=4", "-op=divide", "1", "3"}, "1 / 3 = 0.3333\n", "", exitOK},
+		{"no_decimals", []string{"-places=0", "-op=add", "2.5", "1"}, "2.5 + 1 = 4\n", "", exitOK},
+		{"significant", []string{"-sig=3", "-op=multiply", "1234.5", "1"}, "1234.5 * 1 = 1,230\n", "", exitOK},
+		{"negative_below_one", []string{"-op=subtract", "0.25", "0.75"}, "0.25 - 0.75 = -0.50\n", "", exitOK},
+		{"rounds_to_zero", []string{"-e", "0-0.001"}, "0-0.001 = 0.00\n", "", exitOK},
+		{"overflow", []string{"-op=multiply", "1e308", "10"}, "", "Error performing multiplication: result overflows", exitOverflow},
+		{"scientific", []string{"-notation=scientific", "-op=multiply", "12345.678", "1"}, "12345.678 * 1 = 1.23e4\n", "", exitOK},
+		{"engineering", []string{"-notation=engineering", "-places=1", "-e", "0.00047"}, "0.00047 = 470.0e-6\n", "", exitOK},
+		{"si", []string{"-notation=si", "-places=1", "-op=divide", "4700", "1"}, "4700 / 1 = 4.7k\n", "", exitOK},
+		{"de_DE", []string{"-locale=de-DE", "-op=add", "1234567", "0.891"}, "1234567 + 0.891 = 1.234.567,89\n", "", exitOK},
+		{"en_IN", []string{"-locale=en-IN", "-e", "123456789.5"}, "123456789.5 = 12,34,56,789.50\n", "", exitOK},
+		{"complex", []string{"-complex", "-notation=engineering", "-op=multiply", "1000+2000i", "3"}, "= 3.00e3 + 6.00e3i\n", "", exitOK},
+		{"json", []string{"-output=json", "-locale=de-DE", "-op=add", "1000", "0.5"}, `"result":1000.5,"formatted":"1.000,50"`, "", exitOK},
+		{"precision_keeps_digits", []string{"-precision=64", "-locale=de-DE", "-op=divide", "1", "4"}, "1 / 4 = 0,25\n", "", exitOK},
+		{"precision_places", []string{"-precision=128", "-places=30", "-op=divide", "1", "3"}, "1 / 3 = 0.333333333333333333333333333333\n", "", exitOK},
+		{"precision_scientific", []string{"-precision=128", "-notation=scientific", "-sig=5", "-op=divide", "2", "3"}, "2 / 3 = 6.6667e-1\n", "", exitOK},
+		{"stats", []string{"-sig=2", "stats", "1", "2", "4"}, "mean             2.3\n", "", exitOK},
+		{"negative_places", []string{"-places=-1", "-op=add", "1", "2"}, "", "-places and -sig must not be negative", exitUsage},
+		{"max_places", []string{"-places=10000", "-op=add", "1", "2"}, "1 + 2 = 3.000", "", exitOK},
+		{"too_many_places", []string{"-places=2000000000", "-op=add", "1", "2"}, "", "-places and -sig must be at most 10000", exitUsage},
+		{"too_many_significant", []string{"-sig=2000000000", "-op=add", "1", "2"}, "", "-places and -sig must be at most 10000", exitUsage},
+		{"unknown_notation", []string{"-notation=roman", "-op=add", "1", "2"}, "", "unknown notation", exitUsage},
+		{"unknown_locale", []string{"-locale=xx-XX", "-op=add", "1", "2"}, "", "unknown locale", exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// integerBase is the base integer results are printed in, set with -base.
+var integerBase = 10
+
+// integerWord is the word size set with -word, or nil if results may have
+// any size.
+var integerWord *calculator.Word
+
+// defaultWord is the word size of bitwise operations when -word is not
+// given.
+var defaultWord = calculator.Word{Bits: 64, Signed: true}
+
+// integerBases lists the bases -base accepts.
+var integerBases = []int{2, 8, 10, 16, 36}
+
+// factorResult is the structured form of a prime factor.
+type factorResult struct {
+	Prime    string `json:"prime"`
+	Exponent int    `json:"exponent"`
+}
+
+// factorInteger implements the factor operation, reporting each prime
+// factor with its exponent.
+func factorInteger(a []calculator.Integer) (interface{}, string, error) {
+	factors, err := calculator.Factorize(a[0])
+	if err != nil {
+		return nil, "", err
+	}
+	results := make([]factorResult, len(factors))
+	parts := make([]string, len(factors))
+	for i, f := range factors {
+		results[i] = factorResult{Prime: f.Prime.String(), Exponent: f.Exponent}
+		parts[i] = f.Prime.Text(integerBase)
+		if f.Exponent > 1 {
+			parts[i] += "^" + strconv.Itoa(f.Exponent)
+		}
+	}
+	if len(parts) == 0 {
+		// 1 is the empty product
+		parts = []string{"1"}
+	}
+	return results, strings.Join(parts, " * "), nil
+}
+
+// bitwiseWord returns the word size of bitwise operations.
+func bitwiseWord() calculator.Word {
+	if integerWord != nil {
+		return *integerWord
+	}
+	return defaultWord
+}
+
+// arithmetic adapts a variadic integer function, and its overflow-checked
+// form for when -word is given, to backendOperation.integer.
+func arithmetic(exact func(...calculator.Integer) calculator.Integer, checked func(calculator.Word, ...calculator.Integer) (calculator.Integer, error)) func([]calculator.Integer) (interface{}, string, error) {
+	return func(args []calculator.Integer) (interface{}, string, error) {
+		if integerWord != nil {
+			return integerResult(checked(*integerWord, args...))
+		}
+		return integerResult(exact(args...), nil)
+	}
+}
+
+// bitwise2 adapts a two-operand word method to backendOperation.integer.
+func bitwise2(fn func(calculator.Word, calculator.Integer, calculator.Integer) (calculator.Integer, error)) func([]calculator.Integer) (interface{}, string, error) {
+	return func(args []calculator.Integer) (interface{}, string, error) {
+		return integerResult(fn(bitwiseWord(), args[0], args[1]))
+	}
+}
+
+// integer1 adapts a single-operand integer function to backendOperation.integer.
+func integer1(fn func(calculator.Integer) (calculator.Integer, error)) func([]calculator.Integer) (interface{}, string, error) {
+	return func(args []calculator.Integer) (interface{}, string, error) { return integerResult(fn(args[0])) }
+}
+
+// integerN adapts a variadic integer function to backendOperation.integer.
+func integerN(fn func(...calculator.Integer) (calculator.Integer, error)) func([]calculator.Integer) (interface{}, string, error) {
+	return func(args []calculator.Integer) (interface{}, string, error) { return integerResult(fn(args...)) }
+}
+
+// integerResult reports an integer result as a decimal string, and its text
+// form in the base set with -base.
+func integerResult(n calculator.Integer, err error) (interface{}, string, error) {
+	if err != nil {
+		return nil, "", err
+	}
+	return n.String(), n.Text(integerBase), nil
+}
+
+// integerBackend works on integers of any size. It is selected with
+// -integer, -base or -word, or by naming an operation only it has.
+var integerBackend = &backend{
+	name:     "integer",
+	flag:     "-integer",
+	flags:    "[-integer] [-base=2|8|10|16|36] [-word=int8|...|uint64]",
+	with:     "on integers",
+	operand:  "integer",
+	supports: func(op backendOperation) bool { return op.integer != nil },
+	run:      runInteger,
+}
+
+// runInteger performs an operation on integers. Operands may be written in
+// binary, octal or hexadecimal, and operands that are not integers are
+// rejected rather than truncated.
+func runInteger(op backendOperation, args []string) (opResult, string, error) {
+	values := make([]calculator.Integer, len(args))
+	operands := make([]interface{}, len(args))
+	for i, arg := range args {
+		n, err := calculator.ParseInteger(arg)
+		if err != nil {
+			code := exitInvalidInput
+			if errors.Is(err, calculator.ErrNotInteger) {
+				code = exitDomain
+			}
+			return opResult{}, "", &errorResult{Code: code, Message: fmt.Sprintf("Error parsing %s: %v", operandName(i, len(args)), err)}
+		}
+		values[i] = n
+		operands[i] = n.String()
+	}
+
+	result, formatted, err := op.integer(values)
+	if err != nil {
+		return opResult{}, "", opError(op.Noun, err)
+	}
+
+	r := opResult{
+		Op:        op.Name,
+		Operands:  operands,
+		Result:    result,
+		Formatted: formatted,
+	}
+	return r, op.Format(args) + " = " + formatted, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestIntegerOperations tests the number theory operations on integers of any size
+func TestIntegerOperations(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"isprime", []string{"-op=isprime", "97"}, "isprime(97) = true", "", exitOK},
+		{"isprime_carmichael", []string{"-op=isprime", "561"}, "isprime(561) = false", "", exitOK},
+		{"factor", []string{"-op=factor", "360"}, "factor(360) = 2^3 * 3^2 * 5", "", exitOK},
+		{"factor_one", []string{"-op=factor", "1"}, "factor(1) = 1", "", exitOK},
+		{"factor_rho", []string{"-op=factor", "18446744073709551617"}, "= 274177 * 67280421310721", "", exitOK},
+		{"factor_zero", []string{"-op=factor", "0"}, "", "Error performing factorization: undefined result", exitDomain},
+		{"modpow", []string{"-op=modpow", "4", "13", "497"}, "modpow(4, 13, 497) = 445", "", exitOK},
+		{"modpow_zero_modulus", []string{"-op=modpow", "4", "13", "0"}, "", "division by zero", exitDivisionByZero},
+		{"modinv", []string{"-op=modinv", "3", "11"}, "modinv(3, 11) = 4", "", exitOK},
+		{"modinv_not_coprime", []string{"-op=modinv", "6", "9"}, "", "Error performing modular inverse: no modular inverse", exitDomain},
+		{"nextprime", []string{"-op=nextprime", "1000000000000"}, "nextprime(1000000000000) = 1000000000039", "", exitOK},
+		{"prevprime", []string{"-op=prevprime", "100"}, "prevprime(100) = 97", "", exitOK},
+		{"totient", []string{"-op=totient", "36"}, "totient(36) = 12", "", exitOK},
+		{"binomial", []string{"-op=binomial", "100", "50"}, "binomial(100, 50) = 100891344545564193334812497256", "", exitOK},
+		{"integer_factorial", []string{"-integer", "-op=factorial", "25"}, "25! = 15511210043330985984000000", "", exitOK},
+		{"integer_gcd", []string{"-integer", "-op=gcd", "1000000000000000000000", "1500000000000000000000"}, "= 500000000000000000000", "", exitOK},
+		{"float_gcd_unchanged", []string{"-op=gcd", "12", "18"}, "gcd(12, 18) = 6.00", "", exitOK},
+		{"not_integer", []string{"-op=isprime", "7.5"}, "", `Error parsing number: operand must be an integer: "7.5"`, exitDomain},
+		{"integral_decimal", []string{"-op=isprime", "7.0"}, "isprime(7.0) = true", "", exitOK},
+		{"invalid", []string{"-op=totient", "seven"}, "", "Error parsing number", exitInvalidInput},
+		{"json_result_string", []string{"-output=json", "-integer", "-op=factorial", "20"}, `"result":"2432902008176640000"`, "", exitOK},
+		{"json_factors", []string{"-output=json", "-op=factor", "12"}, `"result":[{"prime":"2","exponent":2},{"prime":"3","exponent":1}]`, "", exitOK},
+		{"wrong_arity", []string{"-op=modpow", "4", "13"}, "Usage: mathreleaser [-integer] [-base=2|8|10|16|36] [-word=int8|...|uint64] -op=modpow <base> <exponent> <modulus>", "", exitUsage},
+		{"integer_alias", []string{"-integer", "-op=mul", "4", "5"}, "4 * 5 = 20", "", exitOK},
+		{"unsupported", []string{"-integer", "-op=sqrt", "4"}, "", "Operation sqrt is not supported on integers", exitUsage},
+		{"combined_backend", []string{"-integer", "-exact", "-op=add", "1", "2"}, "", "cannot be combined", exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -2,13 +2,19 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"math/big"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/PingDavidR/go-release-test/internal/helpers"
+	"github.com/PingDavidR/go-release-test/internal/output"
 	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/currency"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/units"
 	"github.com/PingDavidR/go-release-test/pkg/version"
 )
 
@@ -18,119 +24,346 @@ var osExit = os.Exit
 // Variable to hold the mainInternal function, allowing it to be mocked in tests
 var mainInternalFunc = mainInternal
 
+// angleUnit is the unit selected with -angle. Trigonometric operations and
+// expression functions take and return angles in it.
+var angleUnit = calculator.Radians
+
+// operations holds the operations available to -op, with angles in
+// angleUnit.
+var operations = calculator.NewDefaultRegistry(calculator.Radians)
+
 // mainInternal is a version of main that allows for exit code testing
 func mainInternal() {
 	// Define command-line flags
 	versionFlag := flag.Bool("version", false, "Print version information")
-	operation := flag.String("op", "add", "Operation to perform: add, subtract, multiply, divide, power, sqrt, sin, cos, tan, random")
+	operation := flag.String("op", "add", "Operation to perform, e.g. add, sqrt or ln; -list shows them all")
+	list := flag.Bool("list", false, "List the operations available to -op")
+	expression := flag.String("e", "", "Evaluate an infix expression, e.g. \"2*(3+4)^2\"")
+	precision := flag.Uint("precision", 0, "Use arbitrary-precision arithmetic with a mantissa of this many bits (0 uses float64)")
+	exact := flag.Bool("exact", false, "Use exact fraction arithmetic for add, subtract, multiply, divide and power")
+	decimal := flag.Bool("decimal", false, "Use fixed-point decimal arithmetic, so 0.1+0.2 is exactly 0.3")
+	scale := flag.Int("scale", 16, "Decimal places of -decimal results that cannot be exact; when given, every result is rounded to it")
+	roundingName := flag.String("rounding", "half-even", "Rounding mode of -decimal: half-up, half-even, down, up, ceiling or floor")
+	fractions := flag.String("format", "improper", "Fraction format for -exact results: improper, mixed or decimal")
+	complexMode := flag.Bool("complex", false, "Work in the complex plane; operands may be written like 3+4i")
+	integer := flag.Bool("integer", false, "Use integers of any size for add, subtract, multiply, gcd, lcm, factorial and the number theory operations")
+	base := flag.Int("base", 10, "Base for integer results: 2, 8, 10, 16 or 36 (implies -integer)")
+	wordName := flag.String("word", "", "Word size for bitwise operations and overflow-checked integer arithmetic, e.g. int32 or uint8 (implies -integer; bitwise operations default to int64)")
+	outputName := flag.String("output", "text", "Output format: text, json or yaml")
+	places := flag.Int("places", 2, "Decimal places of results, in the mantissa outside fixed notation")
+	significant := flag.Int("sig", 0, "Round results to this many significant figures instead of -places decimal places")
+	notationName := flag.String("notation", "fixed", "Notation of results: fixed, scientific, engineering or si")
+	localeName := flag.String("locale", "en-US", "Digit grouping and decimal separator of results: en-US, de-DE, fr-FR or en-IN")
+	angleName := flag.String("angle", "rad", "Angle unit for trigonometric functions: rad, deg, grad or turn")
+	unitsFile := flag.String("units", "", "Load extra unit definitions for -e from a file")
+	ratesFile := flag.String("rates", "", "Exchange rate table for the currency operation: a .json or .csv file")
+	dateName := flag.String("date", "", "Convert currencies at the latest rates on or before this date, YYYY-MM-DD (default: the latest rates)")
 
 	// Parse command-line flags
 	flag.Parse()
 
-	// Print version information if requested
-	if *versionFlag {
-		fmt.Println(version.Info())
+	// Select the output format before anything is printed
+	format, err := output.ParseFormat(*outputName)
+	if err != nil {
+		outputFormat = output.Text
+		failf(exitUsage, "%v", err)
 		return
 	}
+	outputFormat = format
 
-	// Check arguments based on operation
-	args := flag.Args()
-	var a, b float64
-	var err error
-
-	switch *operation {
-	case "add", "subtract", "multiply", "divide", "power", "random":
-		if len(args) != 2 {
-			fmt.Println("Usage: mathreleaser -op=[add|subtract|multiply|divide|power|random] <number1> <number2>")
-			fmt.Println("       mathreleaser -version")
-			osExit(1)
+	numberFormat = helpers.DefaultFormatter()
+	if *places < 0 || *significant < 0 {
+		failf(exitUsage, "-places and -sig must not be negative")
+		return
+	}
+	if *places > helpers.MaxPlaces || *significant > helpers.MaxPlaces {
+		failf(exitUsage, "-places and -sig must be at most %d", helpers.MaxPlaces)
+		return
+	}
+	notation, err := helpers.ParseNotation(*notationName)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+	locale, err := helpers.ParseLocale(*localeName)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+	numberFormat = helpers.Formatter{Precision: *places, Significant: *significant, Notation: notation, Locale: locale}
+
+	unit, err := calculator.ParseAngleUnit(*angleName)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+	angleUnit = unit
+	operations = calculator.NewDefaultRegistry(unit)
+
+	unitTable = units.DefaultTable()
+	if *unitsFile != "" {
+		if err := loadUnits(*unitsFile); err != nil {
+			report(err)
 			return
 		}
-		a, err = strconv.ParseFloat(args[0], 64)
+	}
+
+	if !containsInt(integerBases, *base) {
+		failf(exitUsage, "unsupported base %d (want 2, 8, 10, 16 or 36)", *base)
+		return
+	}
+	integerBase = *base
+	integerWord = nil
+	if *wordName != "" {
+		word, err := calculator.ParseWord(*wordName)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Error parsing first number: %v\n", err)
-			osExit(1)
+			failf(exitUsage, "%v", err)
 			return
 		}
-		b, err = strconv.ParseFloat(args[1], 64)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Error parsing second number: %v\n", err)
-			osExit(1)
+		integerWord = &word
+	}
+	integerMode := *integer || isFlagSet("base") || isFlagSet("word")
+
+	rateDate = time.Time{}
+	if *dateName != "" {
+		if rateDate, err = time.Parse(currency.DateFormat, *dateName); err != nil {
+			failf(exitUsage, "invalid date %q (want YYYY-MM-DD)", *dateName)
 			return
 		}
-	case "sqrt", "sin", "cos", "tan":
-		if len(args) != 1 {
-			fmt.Println("Usage: mathreleaser -op=[sqrt|sin|cos|tan] <number>")
-			fmt.Println("       mathreleaser -version")
-			osExit(1)
+	}
+	rateProvider = nil
+	if *ratesFile != "" {
+		provider, err := currency.NewFileProvider(*ratesFile)
+		if err != nil {
+			failf(exitError, "Error reading rates: %v", err)
 			return
 		}
-		a, err = strconv.ParseFloat(args[0], 64)
+		rateProvider = provider
+	}
+
+	decimalSettings = nil
+	if *decimal {
+		rounding, err := calculator.ParseRoundingMode(*roundingName)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Error parsing number: %v\n", err)
-			osExit(1)
+			failf(exitUsage, "%v", err)
 			return
 		}
-	default:
-		if len(args) == 0 {
-			fmt.Println("Usage: mathreleaser -op=[add|subtract|multiply|divide|power|random] <number1> <number2>")
-			fmt.Println("       mathreleaser -op=[sqrt|sin|cos|tan] <number>")
-			fmt.Println("       mathreleaser -version")
-			osExit(1)
+		if *scale < 0 || *scale > calculator.MaxDecimalScale {
+			failf(exitUsage, "-scale must be between 0 and %d", calculator.MaxDecimalScale)
+			return
+		}
+		decimalSettings = &decimalContext{scale: *scale, fixed: isFlagSet("scale"), rounding: rounding}
+	} else if isFlagSet("scale") || isFlagSet("rounding") {
+		failf(exitUsage, "-scale and -rounding need -decimal")
+		return
+	}
+
+	// Print version information if requested
+	if *versionFlag {
+		if outputFormat == output.Text {
+			fmt.Println(version.Info())
+		} else {
+			encode(os.Stdout, version.Current())
+		}
+		return
+	}
+
+	// List the operations if requested
+	if *list {
+		listOperations()
+		return
+	}
+
+	// Evaluate an expression if one was given instead of an operation
+	if *expression != "" {
+		evaluateExpression(*expression)
+		return
+	}
+
+	args := flag.Args()
+
+	// Only one alternative number backend can be active at a time
+	if countTrue(*exact, *precision > 0, *complexMode, integerMode, *decimal) > 1 {
+		failf(exitUsage, "-exact, -precision, -complex, -integer and -decimal cannot be combined")
+		return
+	}
+	if angleUnit != calculator.Radians && countTrue(*exact, *precision > 0, *complexMode, integerMode, *decimal) > 0 {
+		failf(exitUsage, "-angle cannot be combined with -exact, -precision, -complex, -integer or -decimal")
+		return
+	}
+	if *precision > calculator.MaxPrecision {
+		failf(exitUsage, "-precision must be between 0 and %d", calculator.MaxPrecision)
+		return
+	}
+	activeBackend = nil
+	switch {
+	case *complexMode:
+		activeBackend = complexBackend
+	case *exact:
+		if fractionFormat, err = helpers.ParseFractionFormat(*fractions); err != nil {
+			failf(exitUsage, "%v", err)
 			return
 		}
+		activeBackend = exactBackend
+	case *decimal:
+		activeBackend = decimalBackend
+	case *precision > 0:
+		numberPrecision = *precision
+		activeBackend = preciseBackend
+	case integerMode:
+		activeBackend = integerBackend
 	}
 
-	// Perform the requested operation
-	var result float64
-	var opErr error
-
-	switch *operation {
-	case "add":
-		result = calculator.Add(a, b)
-		fmt.Printf("%s + %s = %s\n", args[0], args[1], helpers.FormatNumber(result))
-	case "subtract":
-		result = calculator.Subtract(a, b)
-		fmt.Printf("%s - %s = %s\n", args[0], args[1], helpers.FormatNumber(result))
-	case "multiply":
-		result = calculator.Multiply(a, b)
-		fmt.Printf("%s * %s = %s\n", args[0], args[1], helpers.FormatNumber(result))
-	case "divide":
-		result, opErr = calculator.Divide(a, b)
-		if opErr != nil {
-			fmt.Fprintf(os.Stderr, "Error: Error performing division: %v\n", opErr)
-			osExit(1)
+	// Run a subcommand if one was named
+	if len(args) > 0 {
+		if run, ok := subcommands[args[0]]; ok {
+			run(args[1:])
 			return
 		}
-		fmt.Printf("%s / %s = %s\n", args[0], args[1], helpers.FormatNumber(result))
-	case "power":
-		result = calculator.Power(a, b)
-		fmt.Printf("%s ^ %s = %s\n", args[0], args[1], helpers.FormatNumber(result))
-	case "sqrt":
-		result, opErr = calculator.SquareRoot(a)
-		if opErr != nil {
-			fmt.Fprintf(os.Stderr, "Error: Error performing square root: %v\n", opErr)
-			osExit(1)
+	}
+
+	// Start the REPL when asked to, or when run interactively with nothing to do
+	if (len(args) == 1 && args[0] == "repl") || (len(args) == 0 && !isFlagSet("op") && stdinIsTerminal()) {
+		runREPL()
+		return
+	}
+
+	// Variadic operations read their operands from stdin when none are given
+	if op, ok := lookupOperation(*operation); ok && op.Variadic && len(args) == 0 && isFlagSet("op") && !stdinIsTerminal() {
+		if args, err = readOperands(os.Stdin); err != nil {
+			failf(exitInvalidInput, "Error reading operands: %v", err)
 			return
 		}
-		fmt.Printf("sqrt(%s) = %s\n", args[0], helpers.FormatNumber(result))
-	case "sin":
-		result = calculator.Sin(a)
-		fmt.Printf("sin(%s) = %s\n", args[0], helpers.FormatNumber(result))
-	case "cos":
-		result = calculator.Cos(a)
-		fmt.Printf("cos(%s) = %s\n", args[0], helpers.FormatNumber(result))
-	case "tan":
-		result = calculator.Tan(a)
-		fmt.Printf("tan(%s) = %s\n", args[0], helpers.FormatNumber(result))
-	case "random":
-		result = calculator.Random(a, b)
-		fmt.Printf("random(%s, %s) = %s\n", args[0], args[1], helpers.FormatNumber(result))
-	default:
-		fmt.Fprintf(os.Stderr, "Error: Unknown operation: %s\n", *operation)
-		osExit(1)
+	}
+
+	r, text, err := calculate(*operation, args)
+	if err != nil {
+		report(err)
 		return
 	}
+	printResult(text, r)
+}
+
+// floatBackend works on float64 numbers with the operations of the
+// registry in operations. It is the default backend.
+var floatBackend = &backend{
+	name:     "float64",
+	operand:  "number",
+	angle:    true,
+	supports: func(op backendOperation) bool { return op.Func != nil },
+	run:      runFloat,
+}
+
+// calculate performs an operation on textual operands, on the backend
+// selected with a flag or else the first default backend supporting it. It
+// returns the structured result and its text form, e.g. "5 + 3 = 8.00", or
+// an error that is either a *usageError or an *errorResult.
+func calculate(operation string, args []string) (opResult, string, error) {
+	op, ok := lookupOperation(operation)
+	if !ok {
+		if len(args) == 0 {
+			return opResult{}, "", newUsageError(usageLines()...)
+		}
+		return opResult{}, "", &errorResult{Code: exitUsage, Message: fmt.Sprintf("Unknown operation: %s", operation)}
+	}
+	b, err := backendFor(op)
+	if err != nil {
+		return opResult{}, "", err
+	}
+	if !op.Accepts(len(args)) {
+		return opResult{}, "", newUsageError(b.usage(op), "       mathreleaser -version")
+	}
+	return b.run(op, args)
+}
+
+// runFloat performs a float64 operation.
+func runFloat(op backendOperation, args []string) (opResult, string, error) {
+	values := make([]float64, len(args))
+	operands := make([]interface{}, len(args))
+	for i, arg := range args {
+		v, err := parseNumber(arg)
+		if err != nil {
+			return opResult{}, "", parseError(i, len(args), err)
+		}
+		values[i] = v
+		operands[i] = output.Float(v)
+	}
+
+	result, err := op.Func(values)
+	if err != nil {
+		return opResult{}, "", opError(op.Noun, err)
+	}
+
+	formatted := numberFormat.Format(result)
+	r := opResult{
+		Op:        op.Name,
+		Operands:  operands,
+		Result:    output.Float(result),
+		Formatted: formatted,
+	}
+	return r, op.Format(args) + " = " + formatted, nil
+}
+
+// parseNumber parses a float64 operand, also accepting integers written in
+// binary, octal or hexadecimal, such as 0b101 or 0xff.
+func parseNumber(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if errors.Is(err, strconv.ErrSyntax) {
+		if n, intErr := calculator.ParseInteger(s); intErr == nil {
+			v, _ = new(big.Float).SetInt(n.Int()).Float64()
+			return v, nil
+		}
+	}
+	return v, err
+}
+
+// parseError reports that operand i of n could not be parsed.
+func parseError(i, n int, err error) error {
+	return &errorResult{Code: exitInvalidInput, Message: fmt.Sprintf("Error parsing %s: %v", operandName(i, n), err)}
+}
+
+// operandName names the operand at index i of n in error messages.
+func operandName(i, n int) string {
+	switch n {
+	case 1:
+		return "number"
+	case 2:
+		return []string{"first", "second"}[i] + " number"
+	default:
+		return fmt.Sprintf("number %d", i+1)
+	}
+}
+
+// countTrue returns how many of the given conditions hold.
+func countTrue(conditions ...bool) int {
+	n := 0
+	for _, c := range conditions {
+		if c {
+			n++
+		}
+	}
+	return n
+}
+
+// containsInt reports whether values includes v.
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// isFlagSet reports whether the named flag was given on the command line.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
 }
 
 func main() {
@@ -304,7 +304,7 @@ func TestBinaryOpMissingArgs(t *testing.T) {
 	}{
 		{"add_no_args", "add", []string{}},
 		{"add_one_arg", "add", []string{"5"}},
-		{"add_too_many_args", "add", []string{"5", "3", "1"}},
+		{"subtract_too_many_args", "subtract", []string{"5", "3", "1"}},
 		{"subtract_no_args", "subtract", []string{}},
 		{"multiply_no_args", "multiply", []string{}},
 		{"divide_no_args", "divide", []string{}},
@@ -328,8 +328,8 @@ func TestBinaryOpMissingArgs(t *testing.T) {
 				t.Errorf("Expected usage information for missing arguments, got stdout: %s, stderr: %s", stdout, stderr)
 			}
 
-			if exitCode != 1 {
-				t.Errorf("Expected exit code 1 for missing arguments, got %d", exitCode)
+			if exitCode != exitUsage {
+				t.Errorf("Expected exit code %d for missing arguments, got %d", exitUsage, exitCode)
 			}
 		})
 	}
@@ -365,8 +365,8 @@ func TestUnaryOpMissingArgs(t *testing.T) {
 				t.Errorf("Expected usage information for missing arguments, got stdout: %s, stderr: %s", stdout, stderr)
 			}
 
-			if exitCode != 1 {
-				t.Errorf("Expected exit code 1 for missing arguments, got %d", exitCode)
+			if exitCode != exitUsage {
+				t.Errorf("Expected exit code %d for missing arguments, got %d", exitUsage, exitCode)
 			}
 		})
 	}
@@ -402,8 +402,8 @@ func TestInvalidNumberArgs(t *testing.T) {
 				t.Errorf("Expected error message '%s', got stdout: %s, stderr: %s", tt.errorMsg, stdout, stderr)
 			}
 
-			if exitCode != 1 {
-				t.Errorf("Expected exit code 1 for invalid number argument, got %d", exitCode)
+			if exitCode != exitInvalidInput {
+				t.Errorf("Expected exit code %d for invalid number argument, got %d", exitInvalidInput, exitCode)
 			}
 		})
 	}
@@ -424,8 +424,8 @@ func TestDivideByZero(t *testing.T) {
 		t.Errorf("Expected division by zero error, got stdout: %s, stderr: %s", stdout, stderr)
 	}
 
-	if exitCode != 1 {
-		t.Errorf("Expected exit code 1 for division by zero, got %d", exitCode)
+	if exitCode != exitDivisionByZero {
+		t.Errorf("Expected exit code %d for division by zero, got %d", exitDivisionByZero, exitCode)
 	}
 }
 
@@ -444,8 +444,8 @@ func TestNegativeSqrt(t *testing.T) {
 		t.Errorf("Expected negative square root error, got stdout: %s, stderr: %s", stdout, stderr)
 	}
 
-	if exitCode != 1 {
-		t.Errorf("Expected exit code 1 for negative square root, got %d", exitCode)
+	if exitCode != exitDomain {
+		t.Errorf("Expected exit code %d for negative square root, got %d", exitDomain, exitCode)
 	}
 }
 
@@ -464,7 +464,7 @@ func TestNoOpNoArgs(t *testing.T) {
 		t.Errorf("Expected usage information for no operation and no arguments, got stdout: %s, stderr: %s", stdout, stderr)
 	}
 
-	if exitCode != 1 {
-		t.Errorf("Expected exit code 1 for no operation and no arguments, got %d", exitCode)
+	if exitCode != exitUsage {
+		t.Errorf("Expected exit code %d for no operation and no arguments, got %d", exitUsage, exitCode)
 	}
 }
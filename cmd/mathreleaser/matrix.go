@@ -0,0 +1,431 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/linalg"
+)
+
+// matrixOps maps each matrix operation to its number of operands.
+var matrixOps = map[string]int{
+	"add":       2,
+	"subtract":  2,
+	"multiply":  2,
+	"transpose": 1,
+	"trace":     1,
+	"det":       1,
+	"inverse":   1,
+	"lu":        1,
+	"qr":        1,
+	"solve":     2,
+	"dot":       2,
+	"cross":     2,
+	"norm":      1,
+}
+
+// matrixUsage is the usage text of the matrix subcommand.
+var matrixUsage = []string{
+	"Usage: mathreleaser matrix [add|subtract|multiply|solve|dot|cross] <operand> <operand>",
+	"       mathreleaser matrix [transpose|trace|det|inverse|lu|qr] <operand>",
+	"       mathreleaser matrix norm [-norm=1|2|inf|fro] <operand>",
+	"Operands are CSV or JSON files, - for stdin, or inline JSON such as [[1,2],[3,4]]",
+}
+
+// matrixOperand is a matrix or vector read from the command line. A vector
+// is held as a 1xn matrix.
+type matrixOperand struct {
+	m      linalg.Matrix
+	vector bool
+}
+
+// matrixResult is the structured form of a matrix operation. Result holds a
+// scalar, vector or matrix; the decompositions fill their factors instead.
+type matrixResult struct {
+	Op     string          `json:"op"`
+	Result interface{}     `json:"result,omitempty"`
+	P      [][]interface{} `json:"p,omitempty"`
+	L      [][]interface{} `json:"l,omitempty"`
+	U      [][]interface{} `json:"u,omitempty"`
+	Q      [][]interface{} `json:"q,omitempty"`
+	R      [][]interface{} `json:"r,omitempty"`
+}
+
+// runMatrix implements "mathreleaser matrix". It applies one operation of
+// the linalg package to matrices and vectors read from files, stdin or the
+// arguments.
+func runMatrix(args []string) {
+	flags := newSubcommandFlags("matrix")
+	normName := flags.String("norm", "", "Norm for the norm operation: 1, 2, inf or fro (default 2 for vectors, fro for matrices)")
+	positional, ok := parseSubcommandFlags(flags, args)
+	if !ok {
+		return
+	}
+
+	if len(positional) == 0 {
+		usage(matrixUsage...)
+		return
+	}
+	op := positional[0]
+	arity, known := matrixOps[op]
+	if !known {
+		failf(exitUsage, "Unknown matrix operation: %s", op)
+		return
+	}
+	if len(positional)-1 != arity {
+		usage(matrixUsage...)
+		return
+	}
+	if isSubcommandFlagSet(flags, "norm") && op != "norm" {
+		failf(exitUsage, "-norm can only be used with the norm operation")
+		return
+	}
+
+	operands, err := readMatrixOperands(positional[1:])
+	if err != nil {
+		fail(*err)
+		return
+	}
+
+	result, err := applyMatrixOp(op, operands, *normName)
+	if err != nil {
+		fail(*err)
+		return
+	}
+
+	if outputFormat == output.Text {
+		printMatrixText(result)
+		return
+	}
+	encode(os.Stdout, result)
+}
+
+// applyMatrixOp performs op on its operands.
+func applyMatrixOp(op string, operands []matrixOperand, normName string) (matrixResult, *errorResult) {
+	r := matrixResult{Op: op}
+	a := operands[0]
+
+	var err error
+	switch op {
+	case "add", "subtract":
+		fn := linalg.Add
+		if op == "subtract" {
+			fn = linalg.Subtract
+		}
+		var m linalg.Matrix
+		m, err = fn(a.m, operands[1].m)
+		r.Result = matrixValue(m)
+	case "multiply":
+		b := operands[1]
+		if b.vector {
+			// A vector on the right is a column, so the product is a vector
+			var v linalg.Vector
+			v, err = linalg.MultiplyVector(a.m, b.m.Row(0))
+			r.Result = vectorValue(v)
+			break
+		}
+		var m linalg.Matrix
+		m, err = linalg.Multiply(a.m, b.m)
+		r.Result = matrixValue(m)
+	case "transpose":
+		r.Result = matrixValue(linalg.Transpose(a.m))
+	case "trace":
+		var trace float64
+		trace, err = linalg.Trace(a.m)
+		r.Result = elementValue(trace)
+	case "det":
+		var det float64
+		det, err = linalg.Determinant(a.m)
+		r.Result = elementValue(det)
+	case "inverse":
+		var inv linalg.Matrix
+		inv, err = linalg.Inverse(a.m)
+		r.Result = matrixValue(inv)
+	case "lu":
+		var lu linalg.LU
+		lu, err = linalg.DecomposeLU(a.m)
+		r.P, r.L, r.U = matrixValue(lu.P()), matrixValue(lu.L()), matrixValue(lu.U())
+	case "qr":
+		var qr linalg.QR
+		qr, err = linalg.DecomposeQR(a.m)
+		r.Q, r.R = matrixValue(qr.Q()), matrixValue(qr.R())
+	case "solve":
+		b, vecErr := operandVector(operands[1], 2)
+		if vecErr != nil {
+			return matrixResult{}, vecErr
+		}
+		var x linalg.Vector
+		x, err = linalg.Solve(a.m, b)
+		r.Result = vectorValue(x)
+	case "dot", "cross":
+		u, vecErr := operandVector(a, 1)
+		if vecErr != nil {
+			return matrixResult{}, vecErr
+		}
+		v, vecErr := operandVector(operands[1], 2)
+		if vecErr != nil {
+			return matrixResult{}, vecErr
+		}
+		if op == "dot" {
+			var d float64
+			d, err = linalg.Dot(u, v)
+			r.Result = elementValue(d)
+		} else {
+			var c linalg.Vector
+			c, err = linalg.Cross(u, v)
+			r.Result = vectorValue(c)
+		}
+	case "norm":
+		norm, normErr := matrixNorm(a, normName)
+		if normErr != nil {
+			return matrixResult{}, normErr
+		}
+		r.Result = elementValue(norm)
+	}
+
+	if err != nil {
+		e := opError("matrix "+op, err)
+		if e.Detail != "" {
+			// The shapes of the operands are the useful part of the message
+			e.Message = fmt.Sprintf("Error performing matrix %s: %s", op, e.Detail)
+		}
+		return matrixResult{}, e
+	}
+	return r, nil
+}
+
+// matrixNorm returns the named norm of a vector or matrix. The default is
+// the Euclidean norm for vectors and the Frobenius norm for matrices; the
+// matrix 2-norm needs singular values and is not supported.
+func matrixNorm(o matrixOperand, name string) (float64, *errorResult) {
+	if v, err := operandVector(o, 1); err == nil {
+		switch name {
+		case "", "2", "fro":
+			return v.Norm(2), nil
+		case "1":
+			return v.Norm(1), nil
+		case "inf":
+			return v.Norm(math.Inf(1)), nil
+		}
+	} else {
+		switch name {
+		case "", "fro":
+			return linalg.FrobeniusNorm(o.m), nil
+		case "1":
+			return linalg.OneNorm(o.m), nil
+		case "inf":
+			return linalg.InfNorm(o.m), nil
+		case "2":
+			return 0, &errorResult{Code: exitUsage, Message: "the 2-norm of a matrix is not supported (want 1, inf or fro)"}
+		}
+	}
+	return 0, &errorResult{Code: exitUsage, Message: fmt.Sprintf("unknown norm %q (want 1, 2, inf or fro)", name)}
+}
+
+// operandVector returns o as a vector. Besides JSON arrays of numbers, a
+// matrix with a single row or column is accepted. n is the position of the
+// operand, for the error message.
+func operandVector(o matrixOperand, n int) (linalg.Vector, *errorResult) {
+	switch {
+	case o.vector || o.m.Rows() == 1:
+		return o.m.Row(0), nil
+	case o.m.Cols() == 1:
+		return o.m.Col(0), nil
+	default:
+		return nil, &errorResult{
+			Code:    exitInvalidInput,
+			Message: fmt.Sprintf("operand %d must be a vector, got a %dx%d matrix", n, o.m.Rows(), o.m.Cols()),
+		}
+	}
+}
+
+// readMatrixOperands reads each operand. Only one can come from stdin.
+func readMatrixOperands(args []string) ([]matrixOperand, *errorResult) {
+	stdinUses := 0
+	for _, arg := range args {
+		if arg == "-" {
+			stdinUses++
+		}
+	}
+	if stdinUses > 1 {
+		return nil, &errorResult{Code: exitUsage, Message: "stdin (-) can be given for only one operand"}
+	}
+
+	operands := make([]matrixOperand, len(args))
+	for i, arg := range args {
+		var data []byte
+		var err error
+		source := arg
+		isJSON := false
+
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(arg), "["):
+			data, source, isJSON = []byte(arg), fmt.Sprintf("argument %d", i+1), true
+		case arg == "-":
+			data, err = io.ReadAll(os.Stdin)
+			source = "stdin"
+		default:
+			data, err = os.ReadFile(filepath.Clean(arg))
+			isJSON = strings.EqualFold(filepath.Ext(arg), ".json")
+		}
+		if err != nil {
+			return nil, &errorResult{Code: exitError, Message: fmt.Sprintf("Error reading matrix: %v", err)}
+		}
+
+		// Files without a .json extension and stdin hold JSON if they look
+		// like it and CSV otherwise
+		if !isJSON {
+			isJSON = bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))
+		}
+		parse := parseCSVMatrix
+		if isJSON {
+			parse = parseJSONMatrix
+		}
+		operand, err := parse(data)
+		if err != nil {
+			return nil, &errorResult{Code: exitInvalidInput, Message: fmt.Sprintf("Error reading matrix from %s: %v", source, err)}
+		}
+		operands[i] = operand
+	}
+	return operands, nil
+}
+
+// parseJSONMatrix parses a JSON array of rows, or a JSON array of numbers
+// as a vector.
+func parseJSONMatrix(data []byte) (matrixOperand, error) {
+	var rows [][]float64
+	if err := json.Unmarshal(data, &rows); err == nil {
+		m, err := linalg.NewMatrix(rows)
+		return matrixOperand{m: m}, err
+	}
+
+	var v []float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return matrixOperand{}, errors.New("want an array of numbers or an array of rows of numbers")
+	}
+	m, err := linalg.NewMatrix([][]float64{v})
+	return matrixOperand{m: m, vector: true}, err
+}
+
+// parseCSVMatrix parses one row of numbers per line. Lines starting with #
+// are skipped.
+func parseCSVMatrix(data []byte) (matrixOperand, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return matrixOperand{}, err
+	}
+
+	rows := make([][]float64, len(records))
+	for i, record := range records {
+		for _, field := range record {
+			x, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				return matrixOperand{}, fmt.Errorf("row %d: %v", i+1, err)
+			}
+			rows[i] = append(rows[i], x)
+		}
+	}
+	m, err := linalg.NewMatrix(rows)
+	return matrixOperand{m: m}, err
+}
+
+// matrixValue returns the rows of m for structured output.
+func matrixValue(m linalg.Matrix) [][]interface{} {
+	rows := make([][]interface{}, m.Rows())
+	for i := range rows {
+		rows[i] = vectorValue(m.Row(i))
+	}
+	return rows
+}
+
+// vectorValue returns the elements of v for structured output.
+func vectorValue(v linalg.Vector) []interface{} {
+	values := make([]interface{}, len(v))
+	for i, x := range v {
+		values[i] = elementValue(x)
+	}
+	return values
+}
+
+// printMatrixText prints the result of a matrix operation on stdout. Scalars
+// print on one line, vectors as a row, and matrices as aligned rows.
+func printMatrixText(r matrixResult) {
+	switch result := r.Result.(type) {
+	case [][]interface{}:
+		printMatrixRows(result)
+	case []interface{}:
+		printMatrixRows([][]interface{}{result})
+	case nil:
+		factors := []struct {
+			name string
+			rows [][]interface{}
+		}{{"P", r.P}, {"L", r.L}, {"U", r.U}, {"Q", r.Q}, {"R", r.R}}
+		first := true
+		for _, f := range factors {
+			if f.rows == nil {
+				continue
+			}
+			if !first {
+				fmt.Println()
+			}
+			first = false
+			fmt.Printf("%s:\n", f.name)
+			printMatrixRows(f.rows)
+		}
+	default:
+		fmt.Println(formatElement(result))
+	}
+}
+
+// printMatrixRows prints rows with each column right-aligned.
+func printMatrixRows(rows [][]interface{}) {
+	cells := make([][]string, len(rows))
+	var widths []int
+	for i, row := range rows {
+		cells[i] = make([]string, len(row))
+		for j, x := range row {
+			cells[i][j] = formatElement(x)
+			if j == len(widths) {
+				widths = append(widths, 0)
+			}
+			if len(cells[i][j]) > widths[j] {
+				widths[j] = len(cells[i][j])
+			}
+		}
+	}
+	for _, row := range cells {
+		for j, cell := range row {
+			row[j] = strings.Repeat(" ", widths[j]-len(cell)) + cell
+		}
+		fmt.Println(strings.Join(row, "  "))
+	}
+}
+
+// formatElement formats an element of matrixResult for text output. Values
+// that overflowed are held as strings by output.Float and print as such.
+func formatElement(v interface{}) string {
+	if x, ok := v.(float64); ok {
+		return numberFormat.Format(x)
+	}
+	return fmt.Sprint(v)
+}
+
+// elementValue returns x for structured output. Adding zero turns the
+// negative zeros left by sign changes into 0, so they do not print as -0.
+func elementValue(x float64) interface{} {
+	return output.Float(x + 0)
+}
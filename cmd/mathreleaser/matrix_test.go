@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMatrix tests the matrix subcommand
+func TestMatrix(t *testing.T) {
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "a.csv")
+	jsonFile := filepath.Join(dir, "b.json")
+	if err := os.WriteFile(csvFile, []byte("# coefficients\n2, 1\n1, 3\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonFile, []byte("[3, 5]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		stdin       string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{
+			name:        "solve_files",
+			args:        []string{"matrix", "solve", csvFile, jsonFile},
+			expectedOut: []string{"0.80  1.40\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "multiply_inline",
+			args:        []string{"matrix", "multiply", "[[1,2],[3,4]]", "[[5,6],[7,8]]"},
+			expectedOut: []string{"19.00  22.00\n43.00  50.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "multiply_vector",
+			args:        []string{"matrix", "multiply", "[[1,2],[3,4]]", "[1,1]"},
+			expectedOut: []string{"3.00  7.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "alignment",
+			args:        []string{"matrix", "transpose", "[[1000,-2]]"},
+			expectedOut: []string{"1,000.00\n   -2.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "det_stdin",
+			args:        []string{"matrix", "det", "-"},
+			stdin:       "1,2\n3,4\n",
+			expectedOut: []string{"-2.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "lu",
+			args:        []string{"matrix", "lu", "[[0,1],[1,0]]"},
+			expectedOut: []string{"P:\n0.00  1.00\n1.00  0.00\n\nL:\n", "\nU:\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "cross",
+			args:        []string{"matrix", "cross", "[1,0,0]", "[0,1,0]"},
+			expectedOut: []string{"0.00  0.00  1.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "dot_column",
+			args:        []string{"matrix", "dot", "[[1],[2]]", "[3,4]"},
+			expectedOut: []string{"11.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "norm_vector",
+			args:        []string{"matrix", "norm", "[3,-4]", "-norm=1"},
+			expectedOut: []string{"7.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "norm_matrix_default",
+			args:        []string{"matrix", "norm", "[[3,0],[0,4]]"},
+			expectedOut: []string{"5.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "json",
+			args:        []string{"-output=json", "matrix", "inverse", "[[2,0],[0,4]]"},
+			expectedOut: []string{`{"op":"inverse","result":[[0.5,0],[0,0.25]]}` + "\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "json_qr",
+			args:        []string{"-output=json", "matrix", "qr", "[[2,0],[0,3]]"},
+			expectedOut: []string{`{"op":"qr","q":[[1,0],[0,1]],"r":[[2,0],[0,3]]}` + "\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "singular",
+			args:        []string{"matrix", "inverse", "[[1,2],[2,4]]"},
+			expectedErr: "Error: Error performing matrix inverse: inverse(2x2): matrix is singular\n",
+			exitCode:    exitDomain,
+		},
+		{
+			name:        "dimension_mismatch",
+			args:        []string{"matrix", "add", "[[1,2]]", "[[1],[2]]"},
+			expectedErr: "add(1x2, 2x1): dimension mismatch",
+			exitCode:    exitDomain,
+		},
+		{
+			name:        "json_error_detail",
+			args:        []string{"-output=json", "matrix", "det", "[[1,2]]"},
+			expectedErr: `"detail":"det(1x2): matrix is not square"`,
+			exitCode:    exitDomain,
+		},
+		{
+			name:        "not_a_vector",
+			args:        []string{"matrix", "solve", "[[1,0],[0,1]]", "[[1,2],[3,4]]"},
+			expectedErr: "operand 2 must be a vector",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "ragged",
+			args:        []string{"matrix", "det", "-"},
+			stdin:       "1,2\n3\n",
+			expectedErr: "Error reading matrix from stdin: rows have different lengths",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "bad_number",
+			args:        []string{"matrix", "det", "-"},
+			stdin:       "1,x\n",
+			expectedErr: "row 1",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "bad_json",
+			args:        []string{"matrix", "det", "[[1,\"a\"]]"},
+			expectedErr: "Error reading matrix from argument 1",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "missing_file",
+			args:        []string{"matrix", "det", filepath.Join(dir, "missing.csv")},
+			expectedErr: "Error reading matrix",
+			exitCode:    exitError,
+		},
+		{
+			name:        "stdin_twice",
+			args:        []string{"matrix", "add", "-", "-"},
+			expectedErr: "only one operand",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "unknown_operation",
+			args:        []string{"matrix", "eigen", "[[1]]"},
+			expectedErr: "Unknown matrix operation: eigen",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "wrong_arity",
+			args:        []string{"matrix", "det"},
+			expectedOut: []string{"Usage: mathreleaser matrix"},
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "matrix_two_norm",
+			args:        []string{"matrix", "norm", "-norm=2", "[[1,2],[3,4]]"},
+			expectedErr: "2-norm of a matrix is not supported",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "norm_flag_misused",
+			args:        []string{"matrix", "det", "-norm=1", "[[1]]"},
+			expectedErr: "-norm can only be used with the norm operation",
+			exitCode:    exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			rIn, wIn, _ := os.Pipe()
+			originalStdin := os.Stdin
+			os.Stdin = rIn
+			defer func() { os.Stdin = originalStdin }()
+			fmt.Fprint(wIn, tt.stdin)
+			wIn.Close()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			for _, want := range tt.expectedOut {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("Expected %q, got stdout: %s, stderr: %s", want, stdout, stderr)
+				}
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
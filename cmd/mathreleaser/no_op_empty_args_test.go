@@ -22,7 +22,7 @@ func TestNoOpEmptyArgs(t *testing.T) {
 		t.Errorf("Expected usage information for no operation and empty args, got stdout: %s, stderr: %s", stdout, stderr)
 	}
 
-	if exitCode != 1 {
-		t.Errorf("Expected exit code 1 for no operation and empty args, got %d", exitCode)
+	if exitCode != exitUsage {
+		t.Errorf("Expected exit code %d for no operation and empty args, got %d", exitUsage, exitCode)
 	}
 }
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// operationInfo is the structured form of an operation for -list.
+type operationInfo struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Arity       int      `json:"arity"`
+	Variadic    bool     `json:"variadic,omitempty"`
+	Usage       string   `json:"usage"`
+	Backends    []string `json:"backends"`
+	Description string   `json:"description"`
+}
+
+// backendOperations is the one table of what each backend can do. Float64
+// operations come from the registry in operations and are listed here by
+// name alone, with their implementations on the other backends. The
+// operations float64 lacks are described in full.
+var backendOperations = []backendOperation{
+	{Operation: calculator.Operation{Name: "add"}, precise: preciseFold(calculator.AddNumber), exact: exactFold(calculator.AddRational), complex: complexFold(calculator.AddComplex), decimal: decimalFold(calculator.AddDecimal), integer: arithmetic(calculator.AddInteger, calculator.Word.Add)},
+	{Operation: calculator.Operation{Name: "subtract"}, precise: preciseFold(calculator.SubtractNumber), exact: exactFold(calculator.SubtractRational), complex: complexFold(calculator.SubtractComplex), decimal: decimalFold(calculator.SubtractDecimal), integer: func(a []calculator.Integer) (interface{}, string, error) {
+		if integerWord != nil {
+			return integerResult(integerWord.Subtract(a[0], a[1]))
+		}
+		return integerResult(calculator.SubtractInteger(a[0], a[1]), nil)
+	}},
+	{Operation: calculator.Operation{Name: "multiply"}, precise: preciseFold(calculator.MultiplyNumber), exact: exactFold(calculator.MultiplyRational), complex: complexFold(calculator.MultiplyComplex), decimal: decimalFold(calculator.MultiplyDecimal), integer: arithmetic(calculator.MultiplyInteger, calculator.Word.Multiply)},
+	{Operation: calculator.Operation{Name: "divide"}, precise: precise2(calculator.DivideNumber), exact: exact2(calculator.DivideRational), complex: complexFold(calculator.DivideComplex), decimal: decimal2((*decimalContext).divide)},
+	{Operation: calculator.Operation{Name: "power"}, precise: precise2(calculator.PowerNumber), exact: exact2(calculator.PowerRational), complex: complexFold(calculator.PowerComplex), decimal: decimal2((*decimalContext).power)},
+	{Operation: calculator.Operation{Name: "mean"}, decimal: (*decimalContext).mean},
+	{Operation: calculator.Operation{Name: "gcd"}, integer: integerN(calculator.GCDInteger)},
+	{Operation: calculator.Operation{Name: "lcm"}, integer: integerN(calculator.LCMInteger)},
+	{Operation: calculator.Operation{Name: "log"}, complex: complexFold(calculator.LogBaseComplex)},
+	{Operation: calculator.Operation{Name: "sqrt"}, precise: precise1(calculator.SquareRootNumber), complex: complex1(calculator.SquareRootComplex), decimal: decimal1((*decimalContext).sqrt)},
+	{Operation: calculator.Operation{Name: "sin"}, complex: complex1(calculator.SinComplex)},
+	{Operation: calculator.Operation{Name: "cos"}, complex: complex1(calculator.CosComplex)},
+	{Operation: calculator.Operation{Name: "tan"}, complex: complex1(calculator.TanComplex)},
+	{Operation: calculator.Operation{Name: "ln"}, complex: complex1(calculator.LogComplex)},
+	{Operation: calculator.Operation{Name: "exp"}, complex: complex1(calculator.ExpComplex)},
+	{Operation: calculator.Operation{Name: "factorial"}, integer: integer1(calculator.FactorialInteger)},
+
+	{Operation: calculator.Operation{Name: "binomial", Arity: 2, Noun: "binomial coefficient", Description: "Calculates the number of ways to choose k items from n"}, operands: "<n> <k>", integer: func(a []calculator.Integer) (interface{}, string, error) {
+		return integerResult(calculator.Binomial(a[0], a[1]))
+	}},
+	{Operation: calculator.Operation{Name: "modpow", Arity: 3, Noun: "modular exponentiation", Description: "Raises a number to a power modulo a modulus"}, operands: "<base> <exponent> <modulus>", integer: func(a []calculator.Integer) (interface{}, string, error) {
+		return integerResult(calculator.ModPow(a[0], a[1], a[2]))
+	}},
+	{Operation: calculator.Operation{Name: "modinv", Arity: 2, Noun: "modular inverse", Description: "Calculates the inverse of a number modulo a modulus"}, operands: "<integer> <modulus>", integer: func(a []calculator.Integer) (interface{}, string, error) {
+		return integerResult(calculator.ModInverse(a[0], a[1]))
+	}},
+	{Operation: calculator.Operation{Name: "isprime", Arity: 1, Noun: "primality test", Description: "Tests whether an integer is prime"}, integer: func(a []calculator.Integer) (interface{}, string, error) {
+		prime := calculator.IsPrime(a[0])
+		return prime, strconv.FormatBool(prime), nil
+	}},
+	{Operation: calculator.Operation{Name: "factor", Arity: 1, Noun: "factorization", Description: "Finds the prime factors of a positive integer"}, integer: factorInteger},
+	{Operation: calculator.Operation{Name: "nextprime", Arity: 1, Noun: "next prime", Description: "Finds the smallest prime greater than an integer"}, integer: integer1(func(n calculator.Integer) (calculator.Integer, error) {
+		return calculator.NextPrime(n), nil
+	})},
+	{Operation: calculator.Operation{Name: "prevprime", Arity: 1, Noun: "previous prime", Description: "Finds the largest prime less than an integer"}, integer: integer1(calculator.PrevPrime)},
+	{Operation: calculator.Operation{Name: "totient", Arity: 1, Noun: "totient", Description: "Calculates Euler's totient of a positive integer"}, integer: integer1(calculator.Totient)},
+	{Operation: calculator.Operation{Name: "and", Arity: 2, Noun: "bitwise and", Description: "Calculates the bitwise AND of two integers"}, integer: bitwise2(calculator.Word.And)},
+	{Operation: calculator.Operation{Name: "or", Arity: 2, Noun: "bitwise or", Description: "Calculates the bitwise OR of two integers"}, integer: bitwise2(calculator.Word.Or)},
+	{Operation: calculator.Operation{Name: "xor", Arity: 2, Noun: "bitwise xor", Description: "Calculates the bitwise exclusive OR of two integers"}, integer: bitwise2(calculator.Word.Xor)},
+	{Operation: calculator.Operation{Name: "not", Arity: 1, Noun: "bitwise not", Description: "Inverts the bits of an integer"}, integer: func(a []calculator.Integer) (interface{}, string, error) {
+		return integerResult(bitwiseWord().Not(a[0]))
+	}},
+	{Operation: calculator.Operation{Name: "shl", Arity: 2, Noun: "shift", Description: "Shifts the bits of an integer left, failing on overflow"}, operands: "<integer> <count>", integer: bitwise2(calculator.Word.ShiftLeft)},
+	{Operation: calculator.Operation{Name: "shr", Arity: 2, Noun: "shift", Description: "Shifts the bits of an integer right, keeping its sign"}, operands: "<integer> <count>", integer: bitwise2(calculator.Word.ShiftRight)},
+	{Operation: calculator.Operation{Name: "rotl", Arity: 2, Noun: "rotation", Description: "Rotates the bits of an integer left within the word"}, operands: "<integer> <count>", integer: bitwise2(calculator.Word.RotateLeft)},
+	{Operation: calculator.Operation{Name: "rotr", Arity: 2, Noun: "rotation", Description: "Rotates the bits of an integer right within the word"}, operands: "<integer> <count>", integer: bitwise2(calculator.Word.RotateRight)},
+	{Operation: calculator.Operation{Name: "popcount", Arity: 1, Noun: "population count", Description: "Counts the set bits of an integer"}, integer: func(a []calculator.Integer) (interface{}, string, error) {
+		return integerResult(bitwiseWord().PopCount(a[0]))
+	}},
+	{Operation: calculator.Operation{Name: "abs", Arity: 1, Noun: "modulus", Description: "Calculates the modulus of a complex number"}, complex: complexReal(calculator.AbsComplex)},
+	{Operation: calculator.Operation{Name: "arg", Arity: 1, Noun: "argument", Description: "Calculates the argument of a complex number in radians"}, complex: complexReal(calculator.ArgComplex)},
+	{Operation: calculator.Operation{Name: "conj", Arity: 1, Noun: "conjugate", Description: "Calculates the complex conjugate"}, complex: complex1(func(a complex128) (complex128, error) {
+		return calculator.ConjComplex(a), nil
+	})},
+	{Operation: calculator.Operation{Name: "round", Arity: 2, Noun: "rounding", Description: "Rounds a decimal to a number of places with -rounding"}, operands: "<number> <places>", decimal: roundDecimal},
+	{Operation: calculator.Operation{Name: currencyOperation, Arity: 3, Noun: "currency conversion", Description: "Converts an amount between currencies at the rates given with -rates"}, operands: "<amount> <from> <to>", convert: calculateCurrency},
+}
+
+// usageLines returns the full usage text, listing every form of the
+// command.
+func usageLines() []string {
+	lines := []string{
+		floatBackend.usage(backendOperation{Operation: calculator.Operation{Arity: 2}}),
+		"       " + strings.TrimPrefix(floatBackend.usage(backendOperation{Operation: calculator.Operation{Arity: 1}}), "Usage: "),
+		"       " + strings.TrimPrefix(floatBackend.usage(backendOperation{Operation: calculator.Operation{Variadic: true}}), "Usage: "),
+	}
+	for _, b := range backends[1:] {
+		lines = append(lines, b.summary())
+	}
+	return append(lines,
+		"       mathreleaser -list",
+		"       mathreleaser -e <expression>",
+		"       mathreleaser repl",
+		"       mathreleaser batch [-in file] [-format=lines|csv|jsonl] [-workers=N]",
+		"       mathreleaser stats [-percentiles=25,50,75] [-method=linear] [-bins=N] [numbers...]",
+		"       mathreleaser matrix <operation> <operand>...",
+		"       mathreleaser poly <operation> <polynomial>...",
+		"       mathreleaser finance <operation> <argument>...",
+		"       mathreleaser integrate <expression in x> <from> <to>",
+		"       mathreleaser derivative <expression in x> <x>",
+		"       mathreleaser solve [-method=brent] -bracket=<a>,<b> <expression in x>",
+		"       mathreleaser minimize [-method=golden] -bracket=<a>,<b> <expression in x>",
+		"       mathreleaser completion bash|zsh|fish",
+		"       mathreleaser -version")
+}
+
+// listOperations prints every operation with its usage, the backends
+// supporting it and its description, as a table in text mode.
+func listOperations() {
+	var infos []operationInfo
+	for _, op := range allOperations() {
+		placeholders := []string{"x"}
+		switch {
+		case op.operands != "":
+			placeholders = strings.Fields(strings.NewReplacer("<", "", ">", "").Replace(op.operands))
+		case op.Variadic:
+			placeholders = []string{"a", "b", "..."}
+		case op.Arity == 2:
+			placeholders = []string{"a", "b"}
+		case op.Arity == 3:
+			placeholders = []string{"a", "b", "c"}
+		}
+		var names []string
+		for _, b := range backends {
+			if b.supports(op) {
+				names = append(names, b.name)
+			}
+		}
+		infos = append(infos, operationInfo{
+			Name:        op.Name,
+			Aliases:     op.Aliases,
+			Arity:       op.Arity,
+			Variadic:    op.Variadic,
+			Usage:       op.Format(placeholders),
+			Backends:    names,
+			Description: op.Description,
+		})
+	}
+
+	if outputFormat != output.Text {
+		encode(os.Stdout, infos)
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, info := range infos {
+		name := info.Name
+		if len(info.Aliases) > 0 {
+			name += " (" + strings.Join(info.Aliases, ", ") + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, info.Usage, strings.Join(info.Backends, ", "), info.Description)
+	}
+	_ = w.Flush()
+}
+
+// readOperands reads whitespace- or comma-separated operands from r.
+func readOperands(r io.Reader) ([]string, error) {
+	var operands []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		operands = append(operands, splitNumbers(scanner.Text())...)
+	}
+	return operands, scanner.Err()
+}
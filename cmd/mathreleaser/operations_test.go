@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// TestOperationRegistry tests that -op is driven by the operation registry
+func TestOperationRegistry(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{"alias", []string{"-op=pow", "2", "3"}, []string{"2 ^ 3 = 8.00"}, "", exitOK},
+		{"postfix_symbol", []string{"-op=factorial", "4"}, []string{"4! = 24.00"}, "", exitOK},
+		{"function_notation", []string{"-op=hypot", "5", "12"}, []string{"hypot(5, 12) = 13.00"}, "", exitOK},
+		{"canonical_name_in_json", []string{"-output=json", "-op=mul", "2", "3"}, []string{`"op":"multiply"`}, "", exitOK},
+		{"list", []string{"-list"}, []string{"power (pow)", "a ^ b", "Raises the first number to the power of the second", "sqrt(x)", "x!"}, "", exitOK},
+		{"list_json", []string{"-output=json", "-list"}, []string{`{"name":"subtract","aliases":["sub"],"arity":2,"usage":"a - b"`}, "", exitOK},
+		{"usage_lists_operations", []string{"-op=unknown"}, []string{"-op=[subtract|divide|", "|factorial|erf|erfc] <number>", "-op=[add|multiply|min|max|mean|gcd|lcm] <number>...", "mathreleaser -list"}, "", exitUsage},
+		{"list_backends", []string{"-output=json", "-list"}, []string{`"name":"divide","aliases":["div"],"arity":2,"usage":"a / b","backends":["float64","precision","exact","complex","decimal"]`, `"name":"abs","arity":1,"usage":"abs(x)","backends":["complex"]`}, "", exitOK},
+		{"usage_lists_backends", []string{"-op=unknown"}, []string{"mathreleaser -exact [-format=improper|mixed|decimal] -op=[add|subtract|multiply|divide|power] <number>...", "mathreleaser -rates=<file.json|file.csv> [-date=YYYY-MM-DD] -op=currency <amount> <from> <to>"}, "", exitUsage},
+		{"needs_backend", []string{"-op=abs", "3"}, nil, "Operation abs needs -complex", exitUsage},
+		{"parse_error_names_operand", []string{"-op=hypot", "3", "x"}, nil, "Error parsing second number", exitInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			for _, expected := range tt.expectedOut {
+				if !strings.Contains(stdout, expected) {
+					t.Errorf("Expected %q, got stdout: %s, stderr: %s", expected, stdout, stderr)
+				}
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
+
+// TestCustomOperation tests that an operation registered from Go is usable
+// with -op and appears in -list
+func TestCustomOperation(t *testing.T) {
+	resetFlags()
+	defer func() { operations = calculator.NewDefaultRegistry(calculator.Radians) }()
+
+	operations = calculator.NewDefaultRegistry(calculator.Radians)
+	err := operations.Register(calculator.Operation{
+		Name:        "average",
+		Arity:       2,
+		Description: "Averages two numbers",
+		Func:        func(args []float64) (float64, error) { return (args[0] + args[1]) / 2, nil },
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	r, text, err := calculate("average", []string{"3", "4"})
+	if err != nil || text != "average(3, 4) = 3.50" || r.Op != "average" {
+		t.Errorf("calculate() = %+v, %q, %v, want average(3, 4) = 3.50", r, text, err)
+	}
+
+	outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+	defer teardown()
+	outputFormat = output.JSON
+	defer func() { outputFormat = output.Text }()
+	listOperations()
+	stdout, _ := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+	var infos []operationInfo
+	if err := json.Unmarshal([]byte(stdout), &infos); err != nil {
+		t.Fatalf("-list output is not JSON: %v\n%s", err, stdout)
+	}
+	found := false
+	for _, info := range infos {
+		if info.Name == "average" {
+			found = info.Usage == "average(a, b)"
+		}
+	}
+	if !found {
+		t.Errorf("-list output does not include average(a, b):\n%s", stdout)
+	}
+}
+
+// TestCompletion tests the shell completion scripts
+func TestCompletion(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{"bash", []string{"completion", "bash"}, []string{"complete -F _mathreleaser mathreleaser", "-op) COMPREPLY=($(compgen -W \"add subtract sub", "factorial erf erfc binomial modpow", "prevprime totient and or xor not shl shr rotl rotr popcount abs arg conj round currency\"", "-word) COMPREPLY=($(compgen -W \"int8 int16 int32 int64 uint8 uint16 uint32 uint64\"", "-angle) COMPREPLY=($(compgen -W \"rad deg grad turn\"", "completion derivative finance integrate"}, "", exitOK},
+		{"zsh", []string{"completion", "zsh"}, []string{"bashcompinit", "complete -F _mathreleaser mathreleaser"}, "", exitOK},
+		{"fish", []string{"completion", "fish"}, []string{"complete -c mathreleaser -o op -x -a pow -d 'Raises the first number to the power of the second'", "complete -c mathreleaser -n __fish_use_subcommand -a stats", "complete -c mathreleaser -o output -x -a 'text json yaml'"}, "", exitOK},
+		{"unknown_shell", []string{"completion", "tcsh"}, nil, `Unsupported shell "tcsh"`, exitUsage},
+		{"missing_shell", []string{"completion"}, []string{"Usage: mathreleaser completion bash|zsh|fish"}, "", exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			for _, expected := range tt.expectedOut {
+				if !strings.Contains(stdout, expected) {
+					t.Errorf("Expected %q, got stdout: %s, stderr: %s", expected, stdout, stderr)
+				}
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
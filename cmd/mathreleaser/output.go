@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/internal/helpers"
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// outputFormat is the format selected with -output. Results go to stdout and
+// errors to stderr in every format.
+var outputFormat = output.Text
+
+// numberFormat formats floating-point results in text output and the
+// formatted field, as selected with -places, -sig, -notation and -locale.
+var numberFormat = helpers.DefaultFormatter()
+
+// opResult is the structured form of a successful calculation.
+type opResult struct {
+	Line       int           `json:"line,omitempty"`
+	Op         string        `json:"op"`
+	Expression string        `json:"expression,omitempty"`
+	Operands   []interface{} `json:"operands,omitempty"`
+	Result     interface{}   `json:"result"`
+	Unit       string        `json:"unit,omitempty"`
+	Formatted  string        `json:"formatted"`
+}
+
+// errorResult is the structured form of a failure. Code is the process exit
+// code and Message the text that would follow "Error: " in text output.
+type errorResult struct {
+	Line    int      `json:"line,omitempty"`
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Detail  string   `json:"detail,omitempty"`
+	Column  int      `json:"column,omitempty"`
+	Usage   []string `json:"usage,omitempty"`
+}
+
+// Error returns the message of e.
+func (e *errorResult) Error() string {
+	return e.Message
+}
+
+// usageError reports that an operation was given the wrong number of
+// arguments. lines is the usage text printed in text mode.
+type usageError struct {
+	lines []string
+}
+
+// newUsageError returns a usageError with the given usage lines.
+func newUsageError(lines ...string) *usageError {
+	return &usageError{lines: lines}
+}
+
+// Error returns a one-line description of e.
+func (e *usageError) Error() string {
+	return "wrong number of arguments"
+}
+
+// forms returns the usage lines without the "Usage:" label and alignment.
+func (e *usageError) forms() []string {
+	forms := make([]string, len(e.lines))
+	for i, line := range e.lines {
+		forms[i] = strings.TrimSpace(strings.TrimPrefix(line, "Usage:"))
+	}
+	return forms
+}
+
+// errorResultFor converts an error returned by calculate into an errorResult.
+func errorResultFor(err error) errorResult {
+	var e *errorResult
+	if errors.As(err, &e) {
+		return *e
+	}
+	var u *usageError
+	if errors.As(err, &u) {
+		return errorResult{Code: exitUsage, Message: u.Error(), Usage: u.forms()}
+	}
+	return errorResult{Code: exitError, Message: err.Error()}
+}
+
+// printResult prints text in text mode and r in the structured formats.
+func printResult(text string, r opResult) {
+	if outputFormat == output.Text {
+		fmt.Println(text)
+		return
+	}
+	encode(os.Stdout, r)
+}
+
+// failf reports an error with the given exit code.
+func failf(code int, format string, args ...interface{}) {
+	fail(errorResult{Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// failOp reports an error returned by a calculator operation, using the exit
+// code of its class. what describes the operation, e.g. "division".
+func failOp(what string, err error) {
+	fail(*opError(what, err))
+}
+
+// opError wraps an error returned by a calculator operation in an
+// errorResult with the exit code of its class.
+func opError(what string, err error) *errorResult {
+	e := &errorResult{
+		Code:    exitCodeFor(err),
+		Message: fmt.Sprintf("Error performing %s: %v", what, err),
+	}
+	var domainErr *calculator.DomainError
+	if errors.As(err, &domainErr) {
+		e.Detail = domainErr.Detail()
+	}
+	return e
+}
+
+// report prints an error returned by calculate and exits.
+func report(err error) {
+	var u *usageError
+	if errors.As(err, &u) {
+		usage(u.lines...)
+		return
+	}
+	fail(errorResultFor(err))
+}
+
+// fail prints e to stderr and exits with its code.
+func fail(e errorResult) {
+	if outputFormat == output.Text {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", e.Message)
+	} else {
+		encode(os.Stderr, e)
+	}
+	osExit(e.Code)
+}
+
+// usage prints usage lines and exits with exitUsage. In text mode the lines
+// go to stdout, as they always have; the structured formats report them as
+// an error on stderr, without the "Usage:" label and alignment.
+func usage(lines ...string) {
+	if outputFormat == output.Text {
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		osExit(exitUsage)
+		return
+	}
+
+	fail(errorResultFor(newUsageError(lines...)))
+}
+
+// encode writes v to f in the selected structured format.
+// A value that cannot be encoded is reported in text on stderr, since it
+// may be the error being reported, and exits with exitError.
+func encode(f *os.File, v interface{}) {
+	if err := output.Encode(f, outputFormat, v); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Error writing output: %v\n", err)
+		osExit(exitError)
+	}
+}
+
+// decimalValue returns a decimal string as a JSON number so that no digits
+// are lost to float64 conversion. Infinities and NaN, which JSON numbers
+// cannot hold, stay strings such as "+Inf", as output.Float writes them.
+func decimalValue(s string) interface{} {
+	if strings.HasSuffix(s, "Inf") || s == "NaN" {
+		return s
+	}
+	return json.Number(s)
+}
+
+// complexValue returns c in the form accepted by calculator.ParseComplex,
+// e.g. "3+4i".
+func complexValue(c complex128) string {
+	s := strconv.FormatComplex(c, 'g', -1, 128)
+	return strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/version"
+)
+
+// TestOutputFlag tests the structured output formats
+func TestOutputFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"json_add", []string{"-output=json", "-op=add", "5", "3"}, `{"op":"add","operands":[5,3],"result":8,"formatted":"8.00"}` + "\n", "", exitOK},
+		{"json_sqrt", []string{"-output=json", "-op=sqrt", "16"}, `{"op":"sqrt","operands":[16],"result":4,"formatted":"4.00"}` + "\n", "", exitOK},
+		{"yaml_add", []string{"-output=yaml", "-op=add", "5", "3"}, "op: add\noperands:\n  - 5\n  - 3\nresult: 8\nformatted: \"8.00\"\n", "", exitOK},
+		{"text_add", []string{"-output=text", "-op=add", "5", "3"}, "5 + 3 = 8.00\n", "", exitOK},
+		{"json_expression", []string{"-output=json", "-e", "2*(3+4)^2"}, `{"op":"eval","expression":"2*(3+4)^2","result":98,"formatted":"98.00"}` + "\n", "", exitOK},
+		{"json_precise", []string{"-output=json", "-precision=64", "-op=divide", "1", "4"}, `{"op":"divide","operands":[1,4],"result":0.25,"formatted":"0.25"}` + "\n", "", exitOK},
+		{"json_exact", []string{"-output=json", "-exact", "-op=divide", "1", "3"}, `{"op":"divide","operands":["1","3"],"result":"1/3","formatted":"1/3"}` + "\n", "", exitOK},
+		{"json_complex", []string{"-output=json", "-complex", "-op=multiply", "1+2i", "3-i"}, `{"op":"multiply","operands":["1+2i","3-1i"],"result":"5+5i","formatted":"5.00 + 5.00i"}` + "\n", "", exitOK},
+		{"json_divide_by_zero", []string{"-output=json", "-op=divide", "1", "0"}, "", `{"code":4,"message":"Error performing division: division by zero","detail":"divide(1, 0): division by zero"}` + "\n", exitDivisionByZero},
+		{"yaml_invalid_number", []string{"-output=yaml", "-op=add", "1", "x"}, "", "code: 3\nmessage: \"Error parsing second number:", exitInvalidInput},
+		{"json_syntax_error", []string{"-output=json", "-e", "1 +"}, "", `"column":4`, exitInvalidInput},
+		{"json_usage", []string{"-output=json", "-op=add", "1"}, "", `"usage":["mathreleaser -op=[add|multiply|min|max|mean|gcd|lcm] <number>... (or numbers on stdin)"`, exitUsage},
+		{"json_unknown_op", []string{"-output=json", "-op=modulo", "1", "2"}, "", `{"code":2,"message":"Unknown operation: modulo"}`, exitUsage},
+		{"bad_output", []string{"-output=xml", "-op=add", "1", "2"}, "", `Error: unknown output format "xml"`, exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if tt.expectedOut == "" && stdout != "" {
+				t.Errorf("Expected no stdout, got: %s", stdout)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
+
+// TestVersionJSON tests that -version -output=json serializes every version field
+func TestVersionJSON(t *testing.T) {
+	resetFlags()
+	outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+	defer teardown()
+
+	os.Args = []string{"mathreleaser", "-version", "-output=json"}
+	mainInternal()
+
+	stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+	var got version.BuildInfo
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Invalid JSON %q: %v (stderr: %s)", stdout, err, stderr)
+	}
+	if got != version.Current() {
+		t.Errorf("Expected %+v, got %+v", version.Current(), got)
+	}
+	for _, key := range []string{"version", "gitCommit", "buildDate", "goVersion", "platform"} {
+		if !strings.Contains(stdout, `"`+key+`"`) {
+			t.Errorf("Expected key %q in %s", key, stdout)
+		}
+	}
+}
+
+// TestEncodeFailure tests that a result that cannot be encoded exits with an
+// error instead of printing nothing
+func TestEncodeFailure(t *testing.T) {
+	resetFlags()
+	outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+	defer teardown()
+
+	outputFormat = output.JSON
+	defer func() { outputFormat = output.Text }()
+	exitCode = exitOK
+	encode(os.Stdout, json.Number("+Inf"))
+
+	_, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+	if !strings.Contains(stderr, "Error writing output") {
+		t.Errorf("Expected an encoding error, got stderr: %s", stderr)
+	}
+	if exitCode != exitError {
+		t.Errorf("Expected exit code %d, got %d", exitError, exitCode)
+	}
+}
+
+// TestDecimalValue tests that non-finite decimal text is kept as a string
+func TestDecimalValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0.25", "0.25"},
+		{"-1234567890123456789.5", "-1234567890123456789.5"},
+		{"+Inf", `"+Inf"`},
+		{"-Inf", `"-Inf"`},
+		{"NaN", `"NaN"`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(decimalValue(tt.input))
+		if err != nil {
+			t.Errorf("json.Marshal(decimalValue(%q)) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if string(got) != tt.expected {
+			t.Errorf("json.Marshal(decimalValue(%q)) = %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/poly"
+)
+
+// polyOps maps each polynomial operation to its number of arguments.
+var polyOps = map[string]int{
+	"roots":      1,
+	"eval":       2,
+	"derivative": 1,
+	"integral":   1,
+	"add":        2,
+	"subtract":   2,
+	"multiply":   2,
+	"divide":     2,
+}
+
+// polyUsage is the usage text of the poly subcommand.
+var polyUsage = []string{
+	"Usage: mathreleaser poly [roots|derivative|integral] <polynomial>",
+	"       mathreleaser poly eval <polynomial> <number>",
+	"       mathreleaser poly [add|subtract|multiply|divide] <polynomial> <polynomial>",
+}
+
+// polyResult is the structured form of a polynomial operation. Polynomials
+// are written in the form accepted by poly.Parse.
+type polyResult struct {
+	Op         string        `json:"op"`
+	Polynomial string        `json:"polynomial"`
+	Operands   []interface{} `json:"operands,omitempty"`
+	Result     interface{}   `json:"result"`
+	Remainder  string        `json:"remainder,omitempty"`
+}
+
+// runPoly implements "mathreleaser poly".
+func runPoly(args []string) {
+	flags := newSubcommandFlags("poly")
+	positional, ok := parseSubcommandFlags(flags, args)
+	if !ok {
+		return
+	}
+
+	if len(positional) == 0 {
+		usage(polyUsage...)
+		return
+	}
+	op := positional[0]
+	arity, known := polyOps[op]
+	if !known {
+		failf(exitUsage, "Unknown polynomial operation: %s", op)
+		return
+	}
+	if len(positional)-1 != arity {
+		usage(polyUsage...)
+		return
+	}
+
+	p, ok := parsePolynomial(positional[1])
+	if !ok {
+		return
+	}
+	r := polyResult{Op: op, Polynomial: p.String()}
+
+	var text string
+	switch op {
+	case "roots":
+		roots, err := p.Roots()
+		if err != nil {
+			failOp("root finding", err)
+			return
+		}
+		values := make([]interface{}, len(roots))
+		lines := make([]string, len(roots))
+		for i, z := range roots {
+			if imag(z) == 0 {
+				values[i] = output.Float(real(z))
+			} else {
+				values[i] = complexValue(z)
+			}
+			lines[i] = "x = " + numberFormat.FormatComplex(z)
+		}
+		r.Result = values
+		text = strings.Join(lines, "\n")
+		if len(roots) == 0 {
+			text = "no roots"
+		}
+	case "eval":
+		x, err := strconv.ParseFloat(positional[2], 64)
+		if err != nil {
+			failf(exitInvalidInput, "Error parsing number: %v", err)
+			return
+		}
+		value := p.Eval(x)
+		r.Operands = []interface{}{output.Float(x)}
+		r.Result = output.Float(value)
+		text = fmt.Sprintf("p(%s) = %s", positional[2], numberFormat.Format(value))
+	case "derivative":
+		r.Result = p.Derivative().String()
+		text = r.Result.(string)
+	case "integral":
+		r.Result = p.Integral(0).String()
+		text = r.Result.(string) + " + C"
+	default:
+		q, ok := parsePolynomial(positional[2])
+		if !ok {
+			return
+		}
+		r.Operands = []interface{}{q.String()}
+
+		var result poly.Polynomial
+		switch op {
+		case "add":
+			result = poly.Add(p, q)
+		case "subtract":
+			result = poly.Subtract(p, q)
+		case "multiply":
+			result = poly.Multiply(p, q)
+		case "divide":
+			quotient, remainder, err := poly.Divide(p, q)
+			if err != nil {
+				failOp("polynomial division", err)
+				return
+			}
+			result = quotient
+			r.Remainder = remainder.String()
+		}
+		r.Result = result.String()
+		text = r.Result.(string)
+		if op == "divide" {
+			text = fmt.Sprintf("quotient = %s\nremainder = %s", r.Result, r.Remainder)
+		}
+	}
+
+	if outputFormat == output.Text {
+		fmt.Println(text)
+		return
+	}
+	encode(os.Stdout, r)
+}
+
+// parsePolynomial parses a polynomial argument, reporting a syntax error
+// with a caret under the offending column. It returns false on failure.
+func parsePolynomial(input string) (poly.Polynomial, bool) {
+	p, err := poly.Parse(input)
+	if err != nil {
+		e := errorResult{Code: exitInvalidInput, Message: fmt.Sprintf("Error parsing polynomial: %v", err)}
+		var syntaxErr *expr.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			e.Column = syntaxErr.Col
+		}
+		failExpression(input, e)
+		return poly.Polynomial{}, false
+	}
+	return p, true
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestPoly tests the poly subcommand
+func TestPoly(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{
+			name:        "real_roots",
+			args:        []string{"poly", "roots", "x^3-6x^2+11x-6"},
+			expectedOut: []string{"x = 1.00\nx = 2.00\nx = 3.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "complex_roots",
+			args:        []string{"poly", "roots", "x^2 + 2x + 5"},
+			expectedOut: []string{"x = -1.00 - 2.00i\nx = -1.00 + 2.00i\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "quintic_roots",
+			args:        []string{"poly", "roots", "x^5 - 1"},
+			expectedOut: []string{"x = 1.00\n", "x = 0.31 + 0.95i\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "leading_minus",
+			args:        []string{"poly", "roots", "-x^2+1"},
+			expectedOut: []string{"x = -1.00\nx = 1.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "no_roots",
+			args:        []string{"poly", "roots", "7"},
+			expectedOut: []string{"no roots\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "roots_json",
+			args:        []string{"-output=json", "poly", "roots", "x^2+1"},
+			expectedOut: []string{`{"op":"roots","polynomial":"x^2 + 1","result":["0-1i","0+1i"]}` + "\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "eval",
+			args:        []string{"poly", "eval", "3x^2 - 2x + 1", "-2"},
+			expectedOut: []string{"p(-2) = 17.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "derivative",
+			args:        []string{"poly", "derivative", "x^3 + x"},
+			expectedOut: []string{"3x^2 + 1\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "integral",
+			args:        []string{"poly", "integral", "3x^2 + 1"},
+			expectedOut: []string{"x^3 + x + C\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "multiply",
+			args:        []string{"poly", "multiply", "x + 1", "x - 1"},
+			expectedOut: []string{"x^2 - 1\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "divide_yaml",
+			args:        []string{"-output=yaml", "poly", "divide", "2x^2+3x+4", "x+1"},
+			expectedOut: []string{"result: \"2x + 1\"\nremainder: \"3\"\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "divide_by_zero",
+			args:        []string{"poly", "divide", "x", "0"},
+			expectedErr: "division by zero",
+			exitCode:    exitDivisionByZero,
+		},
+		{
+			name:        "zero_polynomial",
+			args:        []string{"poly", "roots", "x - x"},
+			expectedErr: "every number is a root of the zero polynomial",
+			exitCode:    exitDomain,
+		},
+		{
+			name:        "syntax_error",
+			args:        []string{"poly", "roots", "3x^^2"},
+			expectedErr: "column 4: exponent must be a non-negative integer, found \"^\"\n  3x^^2\n     ^\n",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "invalid_number",
+			args:        []string{"poly", "eval", "x", "abc"},
+			expectedErr: "Error parsing number",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "unknown_operation",
+			args:        []string{"poly", "factor", "x"},
+			expectedErr: "Unknown polynomial operation: factor",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "wrong_arity",
+			args:        []string{"poly", "eval", "x"},
+			expectedOut: []string{"Usage: mathreleaser poly"},
+			exitCode:    exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			for _, want := range tt.expectedOut {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("Expected %q, got stdout: %s, stderr: %s", want, stdout, stderr)
+				}
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
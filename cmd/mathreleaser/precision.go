@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/PingDavidR/go-release-test/internal/helpers"
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// numberPrecision is the mantissa size in bits set with -precision.
+var numberPrecision uint
+
+// preciseBackend works on arbitrary-precision floating-point numbers, with
+// a mantissa of numberPrecision bits. It is selected with -precision.
+var preciseBackend = &backend{
+	name:     "precision",
+	flag:     "-precision",
+	flags:    "-precision=<n>",
+	with:     "with -precision",
+	operand:  "number",
+	supports: func(op backendOperation) bool { return op.precise != nil },
+	run:      runPrecise,
+}
+
+// preciseFold adapts a two-operand function to backendOperation.precise,
+// applying it from left to right across the operands.
+func preciseFold(fn func(a, b calculator.Number) calculator.Number) func([]calculator.Number) (calculator.Number, error) {
+	return func(args []calculator.Number) (calculator.Number, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = fn(result, arg)
+		}
+		return result, nil
+	}
+}
+
+// precise2 adapts a two-operand function to backendOperation.precise.
+func precise2(fn func(a, b calculator.Number) (calculator.Number, error)) func([]calculator.Number) (calculator.Number, error) {
+	return func(args []calculator.Number) (calculator.Number, error) { return fn(args[0], args[1]) }
+}
+
+// precise1 adapts a single-operand function to backendOperation.precise.
+func precise1(fn func(calculator.Number) (calculator.Number, error)) func([]calculator.Number) (calculator.Number, error) {
+	return func(args []calculator.Number) (calculator.Number, error) { return fn(args[0]) }
+}
+
+// runPrecise performs an operation on arbitrary-precision numbers.
+func runPrecise(op backendOperation, args []string) (opResult, string, error) {
+	values := make([]calculator.Number, len(args))
+	operands := make([]interface{}, len(args))
+	for i, arg := range args {
+		n, err := calculator.ParseNumber(arg, numberPrecision)
+		if err != nil {
+			return opResult{}, "", parseError(i, len(args), err)
+		}
+		values[i] = n
+		operands[i] = decimalValue(n.String())
+	}
+
+	result, err := op.precise(values)
+	if err != nil {
+		return opResult{}, "", opError(op.Noun, err)
+	}
+
+	// Results keep all their digits unless the number format was chosen
+	formatted := numberFormat.FormatDecimal(result.String())
+	if isFlagSet("places") || isFlagSet("sig") || numberFormat.Notation != helpers.NotationFixed {
+		formatted = numberFormat.FormatFloat(result.Float())
+	}
+	r := opResult{
+		Op:        op.Name,
+		Operands:  operands,
+		Result:    decimalValue(result.String()),
+		Formatted: formatted,
+	}
+	return r, op.Format(args) + " = " + formatted, nil
+}
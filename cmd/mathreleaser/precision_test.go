@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestPrecisionFlag tests operations on the arbitrary-precision backend
+func TestPrecisionFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"add_tenths", []string{"-precision=256", "-op=add", "0.1", "0.2"}, "0.1 + 0.2 = 0.30", "", exitOK},
+		{"divide", []string{"-precision=64", "-op=divide", "1", "3"}, "1 / 3 = 0.3333333333333333333", "", exitOK},
+		{"power_exact", []string{"-precision=256", "-op=power", "2", "70"}, "2 ^ 70 = 1,180,591,620,717,411,303,424.00", "", exitOK},
+		{"sqrt", []string{"-precision=128", "-op=sqrt", "2"}, "sqrt(2) = 1.41421356237309504880168872420969807", "", exitOK},
+		{"power_overflow", []string{"-precision=64", "-op=power", "2", "1e18"}, "", "Error performing power: result overflows", exitOverflow},
+		{"divide_by_zero", []string{"-precision=128", "-op=divide", "1", "0"}, "", "Error performing division: division by zero", exitDivisionByZero},
+		{"negative_sqrt", []string{"-precision=128", "-op=sqrt", "--", "-4"}, "", "Error performing square root: square root of negative number", exitDomain},
+		{"invalid_number", []string{"-precision=128", "-op=add", "x", "1"}, "", "Error parsing first number", exitInvalidInput},
+		{"missing_args", []string{"-precision=128", "-op=add", "1"}, "Usage:", "", exitUsage},
+		{"unsupported_op", []string{"-precision=128", "-op=sin", "1"}, "", "not supported with -precision", exitUsage},
+		{"max_precision", []string{"-precision=16384", "-op=add", "1", "1"}, "1 + 1 = 2", "", exitOK},
+		{"precision_too_large", []string{"-precision=16385", "-op=add", "1", "1"}, "", "-precision must be between 0 and 16384", exitUsage},
+		{"largest_precision", []string{"-precision=4294967295", "-op=divide", "1", "3"}, "", "-precision must be between 0 and 16384", exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestProgrammerMode tests base conversion and bitwise operations on fixed-size words
+func TestProgrammerMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"base_16", []string{"-base=16", "-op=add", "255", "1"}, "255 + 1 = 0x100", "", exitOK},
+		{"base_2", []string{"-base=2", "-op=multiply", "5", "2"}, "5 * 2 = 0b1010", "", exitOK},
+		{"base_8", []string{"-base=8", "-op=subtract", "0x10", "0b1000"}, "0x10 - 0b1000 = 0o10", "", exitOK},
+		{"base_36", []string{"-base=36", "-op=add", "35", "1"}, "35 + 1 = 10", "", exitOK},
+		{"base_negative", []string{"-base=16", "-op=subtract", "0", "255"}, "0 - 255 = -0xff", "", exitOK},
+		{"base_factor", []string{"-base=16", "-op=factor", "360"}, "factor(360) = 0x2^3 * 0x3^2 * 0x5", "", exitOK},
+		{"unsupported_base", []string{"-base=7", "-op=add", "1", "2"}, "", "unsupported base 7", exitUsage},
+		{"and", []string{"-op=and", "0b1100", "0b1010"}, "and(0b1100, 0b1010) = 8", "", exitOK},
+		{"or", []string{"-base=2", "-op=or", "0b1100", "0b1010"}, "= 0b1110", "", exitOK},
+		{"xor", []string{"-base=16", "-op=xor", "0xff", "0x0f"}, "xor(0xff, 0x0f) = 0xf0", "", exitOK},
+		{"not_default_int64", []string{"-op=not", "0"}, "not(0) = -1", "", exitOK},
+		{"not_uint8", []string{"-word=uint8", "-op=not", "0"}, "not(0) = 255", "", exitOK},
+		{"shl", []string{"-op=shl", "1", "10"}, "shl(1, 10) = 1024", "", exitOK},
+		{"shl_overflow", []string{"-word=uint8", "-op=shl", "0x80", "1"}, "", "Error performing shift: result overflows", exitOverflow},
+		{"shr_arithmetic", []string{"-word=int8", "-op=shr", "--", "-128", "3"}, "shr(-128, 3) = -16", "", exitOK},
+		{"rotl", []string{"-word=uint8", "-base=2", "-op=rotl", "0b10000001", "1"}, "= 0b11", "", exitOK},
+		{"rotr", []string{"-word=uint16", "-base=16", "-op=rotr", "1", "4"}, "= 0x1000", "", exitOK},
+		{"popcount", []string{"-op=popcount", "0xff0f"}, "popcount(0xff0f) = 12", "", exitOK},
+		{"popcount_negative", []string{"-word=int32", "-op=popcount", "--", "-1"}, "popcount(-1) = 32", "", exitOK},
+		{"word_add_overflow", []string{"-word=int8", "-op=add", "100", "28"}, "", "Error performing addition: result overflows", exitOverflow},
+		{"word_add", []string{"-word=int8", "-op=add", "100", "27"}, "100 + 27 = 127", "", exitOK},
+		{"word_out_of_range", []string{"-word=uint8", "-op=and", "256", "1"}, "", "operand out of range for word size", exitDomain},
+		{"unbounded_without_word", []string{"-integer", "-op=multiply", "4294967296", "4294967296"}, "= 18446744073709551616", "", exitOK},
+		{"unknown_word", []string{"-word=int9", "-op=and", "1", "2"}, "", `unknown word size "int9"`, exitUsage},
+		{"prefixed_float_operands", []string{"-op=add", "0x10", "0b1"}, "0x10 + 0b1 = 17.00", "", exitOK},
+		{"invalid_prefixed", []string{"-op=and", "0xzz", "1"}, "", "Error parsing first number", exitInvalidInput},
+		{"not_integer", []string{"-op=xor", "1.5", "1"}, "", "operand must be an integer", exitDomain},
+		{"float_only_op", []string{"-base=16", "-op=sqrt", "4"}, "", "Operation sqrt is not supported on integers", exitUsage},
+		{"combined_backend", []string{"-word=int32", "-exact", "-op=add", "1", "2"}, "", "cannot be combined", exitUsage},
+		{"json", []string{"-output=json", "-base=16", "-op=xor", "0xff", "0x0f"}, `"result":"240","formatted":"0xf0"`, "", exitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/internal/helpers"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+)
+
+// maxHistory is the number of history entries kept in memory and in the
+// history file; older entries are dropped.
+const maxHistory = 1000
+
+// replHelp is printed by the :help command.
+const replHelp = `Enter an expression to evaluate it, e.g. 2*(3+4)^2 or sqrt(ans).
+  name = expr   assign a variable
+  ans           the result of the last calculation
+  !!            re-run the previous entry
+  !N            re-run history entry N
+Commands:
+  :vars         list variables
+  :clear        remove all variables and reset ans
+  :history      list the history of this and previous sessions
+  :help         show this help
+  :quit         leave the REPL (Ctrl-D also works)`
+
+// Variable to hold the function that locates the REPL history directory,
+// allowing it to be mocked in tests
+var replHistoryDir = func() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mathreleaser"), nil
+}
+
+// Variable to hold the terminal check for stdin, allowing it to be mocked in tests
+var stdinIsTerminal = func() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	// /dev/null is a character device too, but nobody is typing into it
+	if null, err := os.Stat(os.DevNull); err == nil && os.SameFile(info, null) {
+		return false
+	}
+	return true
+}
+
+// repl is an interactive read-eval-print loop over the expression evaluator.
+type repl struct {
+	in          *bufio.Scanner
+	out         io.Writer
+	errOut      io.Writer
+	env         *expr.Env
+	history     []string
+	historyFile string
+}
+
+// runREPL starts an interactive session on stdin and stdout.
+func runREPL() {
+	r := newREPL(os.Stdin, os.Stdout, os.Stderr)
+	r.run()
+}
+
+// newREPL creates a REPL and loads the history of previous sessions. A
+// history file that cannot be opened disables persistence but is not fatal.
+func newREPL(in io.Reader, out, errOut io.Writer) *repl {
+	r := &repl{
+		in:     bufio.NewScanner(in),
+		out:    out,
+		errOut: errOut,
+		env:    newREPLEnv(),
+	}
+
+	dir, err := replHistoryDir()
+	if err == nil {
+		err = helpers.EnsureDir(dir)
+	}
+	if err != nil {
+		fmt.Fprintf(errOut, "Warning: history will not be saved: %v\n", err)
+		return r
+	}
+	r.historyFile = filepath.Join(dir, "history")
+	history, trimmed := loadHistory(r.historyFile)
+	r.history = history
+	if trimmed {
+		r.saveHistory()
+	}
+	return r
+}
+
+// newREPLEnv returns the default expression environment with ans set to 0.
+func newREPLEnv() *expr.Env {
+	env := newEnv()
+	env.Vars["ans"] = 0
+	return env
+}
+
+// run reads lines until EOF or :quit.
+func (r *repl) run() {
+	fmt.Fprintln(r.out, "mathreleaser REPL - type :help for help, :quit to exit")
+	for {
+		fmt.Fprint(r.out, "> ")
+		if !r.in.Scan() {
+			fmt.Fprintln(r.out)
+			return
+		}
+		if !r.handle(strings.TrimSpace(r.in.Text())) {
+			return
+		}
+	}
+}
+
+// handle processes a single line of input. It returns false when the
+// session should end.
+func (r *repl) handle(line string) bool {
+	if line == "" {
+		return true
+	}
+
+	if strings.HasPrefix(line, "!") {
+		recalled, err := r.recall(line)
+		if err != nil {
+			fmt.Fprintf(r.errOut, "Error: %v\n", err)
+			return true
+		}
+		fmt.Fprintln(r.out, recalled)
+		line = recalled
+	}
+	r.record(line)
+
+	switch line {
+	case ":quit", ":exit", ":q":
+		return false
+	case ":help":
+		fmt.Fprintln(r.out, replHelp)
+	case ":vars":
+		r.printVars()
+	case ":clear":
+		r.env = newREPLEnv()
+		fmt.Fprintln(r.out, "Variables cleared")
+	case ":history":
+		for i, entry := range r.history {
+			fmt.Fprintf(r.out, "%5d  %s\n", i+1, entry)
+		}
+	default:
+		if strings.HasPrefix(line, ":") {
+			fmt.Fprintf(r.errOut, "Error: Unknown command: %s (type :help for help)\n", line)
+			return true
+		}
+		r.evaluate(line)
+	}
+	return true
+}
+
+// evaluate evaluates an expression or assignment and updates ans.
+func (r *repl) evaluate(line string) {
+	node, err := expr.Parse(line)
+	if err != nil {
+		fmt.Fprintf(r.errOut, "Error: Error parsing expression: %v\n", err)
+		return
+	}
+
+	result, err := node.Eval(r.env)
+	if err != nil {
+		fmt.Fprintf(r.errOut, "Error: Error evaluating expression: %v\n", err)
+		return
+	}
+	r.env.Vars["ans"] = result
+
+	if assign, ok := node.(*expr.AssignExpr); ok {
+		fmt.Fprintf(r.out, "%s = %s\n", assign.Name, numberFormat.Format(result))
+		return
+	}
+	fmt.Fprintln(r.out, numberFormat.Format(result))
+}
+
+// printVars lists all variables in name order.
+func (r *repl) printVars() {
+	names := make([]string, 0, len(r.env.Vars))
+	for name := range r.env.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(r.out, "%s = %s\n", name, numberFormat.Format(r.env.Vars[name]))
+	}
+}
+
+// recall resolves !! and !N references against the history.
+func (r *repl) recall(line string) (string, error) {
+	if len(r.history) == 0 {
+		return "", errors.New("history is empty")
+	}
+	if line == "!!" {
+		return r.history[len(r.history)-1], nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 1 || n > len(r.history) {
+		return "", fmt.Errorf("no history entry %s", line[1:])
+	}
+	return r.history[n-1], nil
+}
+
+// record appends a line to the history and the history file. Once the
+// history holds maxHistory entries the oldest is dropped and the file is
+// rewritten, so it never grows beyond maxHistory lines.
+func (r *repl) record(line string) {
+	r.history = append(r.history, line)
+	if len(r.history) > maxHistory {
+		r.history = r.history[len(r.history)-maxHistory:]
+		r.saveHistory()
+		return
+	}
+	if r.historyFile == "" {
+		return
+	}
+
+	f, err := os.OpenFile(r.historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		fmt.Fprintf(r.errOut, "Warning: could not save history: %v\n", err)
+		r.historyFile = ""
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, line)
+}
+
+// saveHistory replaces the history file with the entries in memory.
+func (r *repl) saveHistory() {
+	if r.historyFile == "" {
+		return
+	}
+	data := strings.Join(r.history, "\n") + "\n"
+	if err := os.WriteFile(r.historyFile, []byte(data), 0600); err != nil {
+		fmt.Fprintf(r.errOut, "Warning: could not save history: %v\n", err)
+		r.historyFile = ""
+	}
+}
+
+// loadHistory reads up to maxHistory of the most recent entries from path.
+// The second result reports whether older entries were dropped, so the
+// file should be rewritten.
+func loadHistory(path string) ([]string, bool) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	var history []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			history = append(history, line)
+		}
+	}
+	if len(history) > maxHistory {
+		return history[len(history)-maxHistory:], true
+	}
+	return history, false
+}
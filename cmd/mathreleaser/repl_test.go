@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useTempHistory points the REPL history at a temporary directory
+func useTempHistory(t *testing.T) string {
+	dir := t.TempDir()
+	original := replHistoryDir
+	replHistoryDir = func() (string, error) { return filepath.Join(dir, "mathreleaser"), nil }
+	t.Cleanup(func() { replHistoryDir = original })
+	return filepath.Join(dir, "mathreleaser", "history")
+}
+
+// TestREPLSession tests a scripted REPL session
+func TestREPLSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedOut []string
+		expectedErr string
+	}{
+		{"expression", "2*(3+4)^2\n", []string{"98.00"}, ""},
+		{"assignment_and_use", "x = 5\nx * 2\n", []string{"x = 5.00", "10.00"}, ""},
+		{"ans", "3 + 4\nans * 2\n", []string{"7.00", "14.00"}, ""},
+		{"vars", "y = 2\n:vars\n", []string{"ans = 2.00", "y = 2.00", "pi = 3.14"}, ""},
+		{"clear", "z = 1\n:clear\nz\n", []string{"Variables cleared"}, "undefined variable \"z\""},
+		{"recall_last", "1 + 1\n!!\n", []string{"1 + 1\n2.00"}, ""},
+		{"recall_numbered", "6 / 3\n9 - 1\n!1\n:history\n", []string{"    1  6 / 3", "    3  6 / 3"}, ""},
+		{"help", ":help\n", []string{":vars"}, ""},
+		{"quit_stops_reading", ":quit\n1 + 1\n", []string{"> "}, ""},
+		{"parse_error", "2 *\n", nil, "Error parsing expression: column 4"},
+		{"unknown_command", ":bogus\n", nil, "Unknown command: :bogus"},
+		{"bad_recall", "!7\n", nil, "history is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempHistory(t)
+			var out, errOut bytes.Buffer
+
+			newREPL(strings.NewReader(tt.input), &out, &errOut).run()
+
+			for _, want := range tt.expectedOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Expected output to contain %q, got stdout: %s, stderr: %s", want, out.String(), errOut.String())
+				}
+			}
+			if tt.expectedErr != "" && !strings.Contains(errOut.String(), tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, out.String(), errOut.String())
+			}
+			if tt.name == "quit_stops_reading" && strings.Contains(out.String(), "2.00") {
+				t.Errorf("Expected no evaluation after :quit, got stdout: %s", out.String())
+			}
+		})
+	}
+}
+
+// TestREPLHistoryPersists tests that history is saved and reloaded across sessions
+func TestREPLHistoryPersists(t *testing.T) {
+	historyFile := useTempHistory(t)
+	var out, errOut bytes.Buffer
+
+	newREPL(strings.NewReader("1 + 2\nx = 4\n"), &out, &errOut).run()
+
+	data, err := os.ReadFile(historyFile)
+	if err != nil {
+		t.Fatalf("Expected history file to exist: %v", err)
+	}
+	if string(data) != "1 + 2\nx = 4\n" {
+		t.Errorf("Unexpected history file contents: %q", string(data))
+	}
+
+	out.Reset()
+	newREPL(strings.NewReader("!1\n"), &out, &errOut).run()
+	if !strings.Contains(out.String(), "3.00") {
+		t.Errorf("Expected recalled entry from previous session, got stdout: %s, stderr: %s", out.String(), errOut.String())
+	}
+}
+
+// TestREPLHistoryBounded tests that the history file is kept to maxHistory entries
+func TestREPLHistoryBounded(t *testing.T) {
+	historyFile := useTempHistory(t)
+	var previous []string
+	for i := 1; i <= maxHistory+5; i++ {
+		previous = append(previous, fmt.Sprintf("%d + 1", i))
+	}
+	if err := os.MkdirAll(filepath.Dir(historyFile), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(historyFile, []byte(strings.Join(previous, "\n")+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out, errOut bytes.Buffer
+	newREPL(strings.NewReader("2 + 2\n3 + 3\n"), &out, &errOut).run()
+
+	data, err := os.ReadFile(historyFile)
+	if err != nil {
+		t.Fatalf("Expected history file to exist: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != maxHistory {
+		t.Fatalf("Expected %d history entries, got %d", maxHistory, len(lines))
+	}
+	if lines[0] != "8 + 1" || lines[len(lines)-2] != "2 + 2" || lines[len(lines)-1] != "3 + 3" {
+		t.Errorf("Unexpected history entries: first %q, last %q", lines[0], lines[len(lines)-1])
+	}
+}
+
+// TestREPLSubcommand tests that the repl subcommand starts the REPL
+func TestREPLSubcommand(t *testing.T) {
+	useTempHistory(t)
+	resetFlags()
+	outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+	defer teardown()
+
+	originalStdin := os.Stdin
+	rIn, wIn, _ := os.Pipe()
+	os.Stdin = rIn
+	defer func() { os.Stdin = originalStdin }()
+	_, _ = wIn.WriteString("5 * 5\n")
+	wIn.Close()
+
+	os.Args = []string{"mathreleaser", "repl"}
+	mainInternal()
+
+	stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+	if !strings.Contains(stdout, "25.00") {
+		t.Errorf("Expected REPL result '25.00', got stdout: %s, stderr: %s", stdout, stderr)
+	}
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got %d", exitCode)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestScientificOperations tests the logarithmic, exponential, hyperbolic and special functions
+func TestScientificOperations(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"ln", []string{"-op=ln", "1"}, "ln(1) = 0.00", "", exitOK},
+		{"log_base", []string{"-op=log", "1000", "10"}, "log(1000, 10) = 3.00", "", exitOK},
+		{"log10", []string{"-op=log10", "0.01"}, "log10(0.01) = -2.00", "", exitOK},
+		{"log2", []string{"-op=log2", "1024"}, "log2(1024) = 10.00", "", exitOK},
+		{"log_invalid_base", []string{"-op=log", "8", "1"}, "", "Error performing logarithm: logarithm base must be positive and not 1", exitDomain},
+		{"log_negative", []string{"-op=log10", "--", "-10"}, "", "logarithm of negative number", exitDomain},
+		{"exp", []string{"-op=exp", "1"}, "exp(1) = 2.72", "", exitOK},
+		{"expm1", []string{"-op=expm1", "0"}, "expm1(0) = 0.00", "", exitOK},
+		{"log1p", []string{"-op=log1p", "1"}, "log1p(1) = 0.69", "", exitOK},
+		{"sinh", []string{"-op=sinh", "1"}, "sinh(1) = 1.18", "", exitOK},
+		{"cosh", []string{"-op=cosh", "0"}, "cosh(0) = 1.00", "", exitOK},
+		{"tanh", []string{"-op=tanh", "100"}, "tanh(100) = 1.00", "", exitOK},
+		{"asinh", []string{"-op=asinh", "0"}, "asinh(0) = 0.00", "", exitOK},
+		{"acosh_out_of_range", []string{"-op=acosh", "0.5"}, "", "Error performing hyperbolic function: undefined result", exitDomain},
+		{"atanh", []string{"-op=atanh", "0.5"}, "atanh(0.5) = 0.55", "", exitOK},
+		{"cbrt", []string{"-op=cbrt", "--", "-27"}, "cbrt(-27) = -3.00", "", exitOK},
+		{"root", []string{"-op=root", "--", "-32", "5"}, "root(-32, 5) = -2.00", "", exitOK},
+		{"even_root_of_negative", []string{"-op=root", "--", "-16", "4"}, "", "even root of negative number", exitDomain},
+		{"hypot", []string{"-op=hypot", "3", "4"}, "hypot(3, 4) = 5.00", "", exitOK},
+		{"factorial", []string{"-op=factorial", "5"}, "5! = 120.00", "", exitOK},
+		{"factorial_fraction", []string{"-op=factorial", "0.5"}, "0.5! = 0.89", "", exitOK},
+		{"factorial_negative_integer", []string{"-op=factorial", "--", "-2"}, "", "Error performing factorial: undefined result", exitDomain},
+		{"factorial_overflow", []string{"-op=factorial", "200"}, "", "result overflows", exitOverflow},
+		{"gamma", []string{"-op=gamma", "0.5"}, "gamma(0.5) = 1.77", "", exitOK},
+		{"beta", []string{"-op=beta", "2", "3"}, "beta(2, 3) = 0.08", "", exitOK},
+		{"erf", []string{"-op=erf", "1"}, "erf(1) = 0.84", "", exitOK},
+		{"erfc", []string{"-op=erfc", "1"}, "erfc(1) = 0.16", "", exitOK},
+		{"expression", []string{"-e", "ln(e^2) + log(8, 2) + factorial(3)"}, "= 11.00", "", exitOK},
+		{"missing_base", []string{"-op=log", "8"}, "Usage:", "", exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
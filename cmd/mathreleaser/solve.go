@@ -0,0 +1,222 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/numeric"
+)
+
+// solveUsage is the usage text of the solve subcommand.
+var solveUsage = []string{
+	"Usage: mathreleaser solve [-method=brent|bisect] -bracket=<a>,<b> <expression in x>",
+	"       mathreleaser solve -method=newton|secant [-x0=<start> | -bracket=<a>,<b>] <expression in x>",
+}
+
+// minimizeUsage is the usage text of the minimize subcommand.
+var minimizeUsage = []string{
+	"Usage: mathreleaser minimize [-method=golden] -bracket=<a>,<b> <expression in x>",
+	"       mathreleaser minimize -method=nelder-mead -x0=<start> <expression in x>",
+}
+
+// solveResult is the structured form of a root or minimum.
+type solveResult struct {
+	Op         string      `json:"op"`
+	Expression string      `json:"expression"`
+	Method     string      `json:"method"`
+	Result     interface{} `json:"result"`
+	Value      interface{} `json:"value"`
+	Iterations int         `json:"iterations"`
+	Converged  bool        `json:"converged"`
+	Formatted  string      `json:"formatted"`
+}
+
+// iterativeFlags holds the flags shared by solve and minimize.
+type iterativeFlags struct {
+	set                 *flag.FlagSet
+	method, bracket, x0 *string
+	tol                 *float64
+	maxIterations       *int
+}
+
+// newIterativeFlags defines the flags shared by solve and minimize.
+func newIterativeFlags(name, defaultMethod, methods string, defaultTol float64) *iterativeFlags {
+	set := newSubcommandFlags(name)
+	return &iterativeFlags{
+		set:           set,
+		method:        set.String("method", defaultMethod, "method: "+methods),
+		bracket:       set.String("bracket", "", "interval a,b to search"),
+		x0:            set.String("x0", "", "starting point"),
+		tol:           set.Float64("tol", defaultTol, "tolerance on x, relative to |x| or absolute when |x| < 1"),
+		maxIterations: set.Int("maxiter", 500, "maximum number of iterations"),
+	}
+}
+
+// options returns the numeric options selected by the flags.
+func (f *iterativeFlags) options() numeric.Options {
+	return numeric.Options{Tol: *f.tol, MaxIterations: *f.maxIterations}
+}
+
+// parseBracket parses the -bracket flag, reporting an error unless it is
+// two numbers separated by a comma. It returns false on failure.
+func parseBracket(s string) (a, b float64, ok bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		failf(exitUsage, "Invalid -bracket %q: want two numbers such as 0,2", s)
+		return 0, 0, false
+	}
+	if a, ok = parsePoint(strings.TrimSpace(parts[0])); !ok {
+		return 0, 0, false
+	}
+	if b, ok = parsePoint(strings.TrimSpace(parts[1])); !ok {
+		return 0, 0, false
+	}
+	return a, b, true
+}
+
+// runSolve implements "mathreleaser solve".
+func runSolve(args []string) {
+	flags := newIterativeFlags("solve", "brent", "brent, bisect, newton or secant", 1e-12)
+	positional, ok := parseSubcommandFlags(flags.set, args)
+	if !ok {
+		return
+	}
+	if len(positional) != 1 {
+		usage(solveUsage...)
+		return
+	}
+
+	method := *flags.method
+	switch method {
+	case "brent", "bisect", "newton", "secant":
+	default:
+		failf(exitUsage, "Unknown root finding method: %s", method)
+		return
+	}
+	f, ok := parseFunction(positional[0])
+	if !ok {
+		return
+	}
+
+	// Bracketing methods need -bracket; the others start from -x0, or
+	// from the bracket if only that is given
+	var a, b float64
+	switch {
+	case *flags.x0 != "" && (method == "newton" || method == "secant"):
+		if a, ok = parsePoint(*flags.x0); !ok {
+			return
+		}
+		b = a + 1e-4*math.Max(1, math.Abs(a))
+	case *flags.bracket != "":
+		if a, b, ok = parseBracket(*flags.bracket); !ok {
+			return
+		}
+		if method == "newton" {
+			a = (a + b) / 2
+		}
+	default:
+		usage(solveUsage...)
+		return
+	}
+
+	var result numeric.Result
+	var err error
+	switch method {
+	case "brent":
+		result, err = numeric.Brent(f.eval, a, b, flags.options())
+	case "bisect":
+		result, err = numeric.Bisect(f.eval, a, b, flags.options())
+	case "newton":
+		result, err = numeric.Newton(f.eval, nil, a, flags.options())
+	case "secant":
+		result, err = numeric.Secant(f.eval, a, b, flags.options())
+	}
+	if f.failEval() {
+		return
+	}
+	if err != nil {
+		failOp("root finding", err)
+		return
+	}
+
+	r := solveResult{Op: "solve", Expression: f.input, Method: method}
+	printSolveResult(r, result, fmt.Sprintf("x = %s", numberFormat.Format(result.X)))
+}
+
+// runMinimize implements "mathreleaser minimize".
+func runMinimize(args []string) {
+	flags := newIterativeFlags("minimize", "golden", "golden or nelder-mead", 1e-8)
+	positional, ok := parseSubcommandFlags(flags.set, args)
+	if !ok {
+		return
+	}
+	if len(positional) != 1 {
+		usage(minimizeUsage...)
+		return
+	}
+
+	method := *flags.method
+	switch method {
+	case "golden", "nelder-mead":
+	default:
+		failf(exitUsage, "Unknown minimization method: %s", method)
+		return
+	}
+	f, ok := parseFunction(positional[0])
+	if !ok {
+		return
+	}
+
+	var result numeric.Result
+	var err error
+	switch {
+	case method == "golden" && *flags.bracket != "":
+		a, b, ok := parseBracket(*flags.bracket)
+		if !ok {
+			return
+		}
+		result, err = numeric.GoldenSection(f.eval, a, b, flags.options())
+	case method == "nelder-mead" && *flags.x0 != "":
+		x0, ok := parsePoint(*flags.x0)
+		if !ok {
+			return
+		}
+		var v numeric.VectorResult
+		v, err = numeric.NelderMead(func(x []float64) float64 { return f.eval(x[0]) }, []float64{x0}, flags.options())
+		if v.X != nil {
+			result = numeric.Result{X: v.X[0], Value: v.Value, Iterations: v.Iterations, Converged: v.Converged}
+		}
+	default:
+		usage(minimizeUsage...)
+		return
+	}
+	if f.failEval() {
+		return
+	}
+	if err != nil {
+		failOp("minimization", err)
+		return
+	}
+
+	r := solveResult{Op: "minimize", Expression: f.input, Method: method}
+	printSolveResult(r, result, fmt.Sprintf("minimum %s at x = %s", numberFormat.Format(result.Value), numberFormat.Format(result.X)))
+}
+
+// printSolveResult completes r with result and prints it, as
+// "<text> (<method>, <n> iterations)" in text mode.
+func printSolveResult(r solveResult, result numeric.Result, text string) {
+	r.Result = output.Float(result.X)
+	r.Value = output.Float(result.Value)
+	r.Iterations = result.Iterations
+	r.Converged = result.Converged
+	r.Formatted = numberFormat.Format(result.X)
+	if outputFormat == output.Text {
+		fmt.Printf("%s (%s, %d iterations)\n", text, r.Method, r.Iterations)
+		return
+	}
+	encode(os.Stdout, r)
+}
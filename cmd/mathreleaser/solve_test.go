@@ -0,0 +1,161 @@
+package main
+
+import (
+	"testing"
+)
+
+// TestSolve tests the solve subcommand
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{
+			name:        "brent",
+			args:        []string{"solve", "x^2 - 2", "-method=brent", "-bracket=0,2"},
+			expectedOut: []string{"x = 1.41 (brent, "},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "default_method",
+			args:        []string{"solve", "-bracket=0,pi", "cos(x)"},
+			expectedOut: []string{"x = 1.57 (brent, "},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "bisect",
+			args:        []string{"solve", "-method=bisect", "-bracket=0,2", "x^2 - 2"},
+			expectedOut: []string{"x = 1.41 (bisect, 41 iterations)\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "newton",
+			args:        []string{"solve", "-method=newton", "-x0=1", "x^2 - 2"},
+			expectedOut: []string{"x = 1.41 (newton, "},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "newton_from_bracket",
+			args:        []string{"solve", "-method=newton", "-bracket=1,3", "x^3 - 8"},
+			expectedOut: []string{"x = 2.00 (newton, "},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "secant",
+			args:        []string{"solve", "-method=secant", "-x0=1", "x^2 - 2"},
+			expectedOut: []string{"x = 1.41 (secant, "},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "json",
+			args:        []string{"-output=json", "solve", "-bracket=0,4", "x - 3"},
+			expectedOut: []string{`{"op":"solve","expression":"x - 3","method":"brent","result":3,"value":0,"iterations":`, `"converged":true,"formatted":"3.00"}`},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "no_bracket",
+			args:        []string{"solve", "-bracket=3,4", "x^2 - 2"},
+			expectedErr: "Error performing root finding: function has the same sign at both ends of the interval",
+			exitCode:    exitDomain,
+		},
+		{
+			name:        "zero_derivative",
+			args:        []string{"solve", "-method=newton", "-x0=0", "x^2 + 1"},
+			expectedErr: "derivative is zero",
+			exitCode:    exitNoConvergence,
+		},
+		{
+			name:        "iteration_limit",
+			args:        []string{"solve", "-bracket=0,2", "-maxiter=3", "x^2 - 2"},
+			expectedErr: "brent did not converge after 3 iterations",
+			exitCode:    exitNoConvergence,
+		},
+		{
+			name:        "evaluation_error",
+			args:        []string{"solve", "-bracket=-1,1", "sqrt(x)"},
+			expectedErr: "Error evaluating expression at x = -1: column 1: square root of negative number",
+			exitCode:    exitDomain,
+		},
+		{
+			name:        "invalid_bracket",
+			args:        []string{"solve", "-bracket=0", "x"},
+			expectedErr: "Invalid -bracket \"0\"",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "missing_bracket",
+			args:        []string{"solve", "-x0=1", "x"},
+			expectedOut: []string{"Usage: mathreleaser solve"},
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "unknown_method",
+			args:        []string{"solve", "-method=regula-falsi", "-bracket=0,1", "x"},
+			expectedErr: "Unknown root finding method: regula-falsi",
+			exitCode:    exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCalculusTest(t, tt.args, tt.expectedOut, tt.expectedErr, tt.exitCode)
+		})
+	}
+}
+
+// TestMinimize tests the minimize subcommand
+func TestMinimize(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{
+			name:        "golden",
+			args:        []string{"minimize", "-bracket=-5,5", "(x-1)^2 + 3"},
+			expectedOut: []string{"minimum 3.00 at x = 1.00 (golden, "},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "nelder_mead",
+			args:        []string{"minimize", "-method=nelder-mead", "-x0=3", "cos(x)"},
+			expectedOut: []string{"minimum -1.00 at x = 3.14 (nelder-mead, "},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "yaml",
+			args:        []string{"-output=yaml", "minimize", "-bracket=0,4", "(x-2)^2"},
+			expectedOut: []string{"op: minimize\n", "method: golden\n", "converged: true\n", "formatted: \"2.00\"\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "iteration_limit",
+			args:        []string{"minimize", "-bracket=-5,5", "-maxiter=2", "x^2"},
+			expectedErr: "golden did not converge after 2 iterations",
+			exitCode:    exitNoConvergence,
+		},
+		{
+			name:        "missing_start",
+			args:        []string{"minimize", "-method=nelder-mead", "x^2"},
+			expectedOut: []string{"Usage: mathreleaser minimize"},
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "unknown_method",
+			args:        []string{"minimize", "-method=bfgs", "-x0=1", "x^2"},
+			expectedErr: "Unknown minimization method: bfgs",
+			exitCode:    exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCalculusTest(t, tt.args, tt.expectedOut, tt.expectedErr, tt.exitCode)
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/stats"
+)
+
+// statsResult is the structured form of the stats subcommand's summary.
+// Statistics that are undefined for the data, such as the sample variance
+// of a single value, are nil.
+type statsResult struct {
+	Count          int                `json:"count"`
+	Sum            interface{}        `json:"sum"`
+	Mean           interface{}        `json:"mean"`
+	Median         interface{}        `json:"median"`
+	Mode           []interface{}      `json:"mode"`
+	Min            interface{}        `json:"min"`
+	Max            interface{}        `json:"max"`
+	Variance       interface{}        `json:"variance"`
+	SampleVariance interface{}        `json:"sampleVariance"`
+	StdDev         interface{}        `json:"stddev"`
+	SampleStdDev   interface{}        `json:"sampleStddev"`
+	Skewness       interface{}        `json:"skewness"`
+	Kurtosis       interface{}        `json:"kurtosis"`
+	Percentiles    []percentileResult `json:"percentiles,omitempty"`
+	Histogram      []bucketResult     `json:"histogram,omitempty"`
+}
+
+// percentileResult is one requested percentile.
+type percentileResult struct {
+	P     float64     `json:"p"`
+	Value interface{} `json:"value"`
+}
+
+// bucketResult is one histogram bucket.
+type bucketResult struct {
+	Lower interface{} `json:"lower"`
+	Upper interface{} `json:"upper"`
+	Count int         `json:"count"`
+}
+
+// runStats implements "mathreleaser stats". It summarizes the numbers given
+// as arguments, or read from stdin when there are none.
+func runStats(args []string) {
+	flags := newSubcommandFlags("stats")
+	percentiles := flags.String("percentiles", "25,50,75", "Comma-separated percentiles to report, between 0 and 100")
+	methodName := flags.String("method", "linear", "Percentile interpolation: linear, lower, higher, nearest or midpoint")
+	bins := flags.Int("bins", 0, "Number of histogram bins to report (0 for none)")
+	stream := flags.Bool("stream", false, "Read an unbounded stream from stdin in constant memory; percentiles are approximate")
+	every := flags.Int("every", 0, "With -stream, print a rolling summary every N values (0 for only the final summary)")
+	positional, ok := parseSubcommandFlags(flags, args)
+	if !ok {
+		return
+	}
+
+	if *stream {
+		switch {
+		case len(positional) > 0:
+			failf(exitUsage, "-stream reads from stdin and does not accept numbers as arguments")
+		case isSubcommandFlagSet(flags, "bins"), isSubcommandFlagSet(flags, "method"):
+			failf(exitUsage, "-bins and -method cannot be used with -stream")
+		case *every < 0:
+			failf(exitUsage, "-every must not be negative")
+		default:
+			ps, err := parsePercentiles(*percentiles)
+			if err != nil {
+				failf(exitUsage, "%v", err)
+				return
+			}
+			streamStats(os.Stdin, ps, *every)
+		}
+		return
+	}
+
+	method, err := stats.ParseInterpolation(*methodName)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+	ps, err := parsePercentiles(*percentiles)
+	if err != nil {
+		failf(exitUsage, "%v", err)
+		return
+	}
+	if *bins < 0 {
+		failf(exitUsage, "-bins must not be negative")
+		return
+	}
+
+	var data []float64
+	if len(positional) > 0 {
+		data, err = parseNumbers(positional)
+	} else {
+		data, err = readNumbers(os.Stdin)
+	}
+	if err != nil {
+		failf(exitInvalidInput, "Error parsing number: %v", err)
+		return
+	}
+	if len(data) == 0 {
+		failf(exitInvalidInput, "No numbers to summarize")
+		return
+	}
+
+	summary, err := summarize(data, ps, method, *bins)
+	if err != nil {
+		fail(errorResult{Code: exitCodeFor(err), Message: fmt.Sprintf("Error computing statistics: %v", err)})
+		return
+	}
+
+	if outputFormat == output.Text {
+		printStatsText(summary)
+		return
+	}
+	encode(os.Stdout, summary)
+}
+
+// summarize computes every statistic reported by the stats subcommand.
+// Statistics that are undefined for data are left nil.
+func summarize(data []float64, ps []float64, method stats.Interpolation, bins int) (statsResult, error) {
+	r := statsResult{Count: len(data), Sum: output.Float(stats.Sum(data))}
+
+	// optional records a statistic, leaving it nil when it is undefined
+	optional := func(v float64, err error) (interface{}, error) {
+		if errors.Is(err, stats.ErrTooFewValues) || errors.Is(err, stats.ErrZeroVariance) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return output.Float(v), nil
+	}
+
+	for _, stat := range []struct {
+		dst *interface{}
+		fn  func([]float64) (float64, error)
+	}{
+		{&r.Mean, stats.Mean},
+		{&r.Median, stats.Median},
+		{&r.Min, stats.Min},
+		{&r.Max, stats.Max},
+		{&r.Variance, stats.Variance},
+		{&r.SampleVariance, stats.SampleVariance},
+		{&r.StdDev, stats.StdDev},
+		{&r.SampleStdDev, stats.SampleStdDev},
+		{&r.Skewness, stats.Skewness},
+		{&r.Kurtosis, stats.Kurtosis},
+	} {
+		v, err := optional(stat.fn(data))
+		if err != nil {
+			return statsResult{}, err
+		}
+		*stat.dst = v
+	}
+
+	modes, err := stats.Mode(data)
+	if err != nil {
+		return statsResult{}, err
+	}
+	r.Mode = make([]interface{}, len(modes))
+	for i, m := range modes {
+		r.Mode[i] = output.Float(m)
+	}
+
+	if len(ps) > 0 {
+		values, err := stats.Percentiles(data, ps, method)
+		if err != nil {
+			return statsResult{}, err
+		}
+		for i, p := range ps {
+			r.Percentiles = append(r.Percentiles, percentileResult{P: p, Value: output.Float(values[i])})
+		}
+	}
+
+	if bins > 0 {
+		buckets, err := stats.Histogram(data, bins)
+		if err != nil {
+			return statsResult{}, err
+		}
+		for _, b := range buckets {
+			r.Histogram = append(r.Histogram, bucketResult{Lower: output.Float(b.Lower), Upper: output.Float(b.Upper), Count: b.Count})
+		}
+	}
+	return r, nil
+}
+
+// printStatsText prints a summary as an aligned table on stdout.
+func printStatsText(r statsResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "count\t%d\n", r.Count)
+	rows := []struct {
+		name  string
+		value interface{}
+	}{
+		{"sum", r.Sum},
+		{"mean", r.Mean},
+		{"median", r.Median},
+		{"min", r.Min},
+		{"max", r.Max},
+		{"variance", r.Variance},
+		{"sample variance", r.SampleVariance},
+		{"stddev", r.StdDev},
+		{"sample stddev", r.SampleStdDev},
+		{"skewness", r.Skewness},
+		{"kurtosis", r.Kurtosis},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s\t%s\n", row.name, formatStat(row.value))
+	}
+
+	modes := make([]string, len(r.Mode))
+	for i, m := range r.Mode {
+		modes[i] = formatStat(m)
+	}
+	if len(modes) == 0 {
+		modes = []string{"none"}
+	}
+	fmt.Fprintf(w, "mode\t%s\n", strings.Join(modes, ", "))
+
+	for _, p := range r.Percentiles {
+		fmt.Fprintf(w, "p%s\t%s\n", strconv.FormatFloat(p.P, 'f', -1, 64), formatStat(p.Value))
+	}
+	for i, b := range r.Histogram {
+		// Every bucket but the last excludes its upper bound
+		closing := ")"
+		if i == len(r.Histogram)-1 {
+			closing = "]"
+		}
+		bar := strings.Repeat("#", histogramBar(b.Count, r.Count))
+		fmt.Fprintf(w, "[%s, %s%s\t%d\t%s\n", formatStat(b.Lower), formatStat(b.Upper), closing, b.Count, bar)
+	}
+	w.Flush()
+}
+
+// formatStat formats a value of statsResult for text output.
+func formatStat(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "n/a"
+	case float64:
+		return numberFormat.Format(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// histogramBar scales a bucket count to a bar of at most 40 characters.
+func histogramBar(count, total int) int {
+	const width = 40
+	return (count*width + total - 1) / total
+}
+
+// parsePercentiles parses a comma-separated list such as "25,50,75". An
+// empty list requests no percentiles.
+func parsePercentiles(list string) ([]float64, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+	var ps []float64
+	for _, field := range strings.Split(list, ",") {
+		p, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid percentile %q: %v", field, stats.ErrInvalidPercentile)
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+// parseNumbers parses each string as a float64, as the operations do.
+func parseNumbers(fields []string) ([]float64, error) {
+	numbers := make([]float64, 0, len(fields))
+	for _, field := range fields {
+		x, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, x)
+	}
+	return numbers, nil
+}
+
+// readNumbers reads numbers separated by whitespace or commas from r.
+func readNumbers(r io.Reader) ([]float64, error) {
+	var numbers []float64
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		parsed, err := parseNumbers(splitNumbers(scanner.Text()))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		numbers = append(numbers, parsed...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return numbers, nil
+}
+
+// splitNumbers splits a line of input on whitespace and commas.
+func splitNumbers(line string) []string {
+	return strings.FieldsFunc(line, func(c rune) bool {
+		return c == ',' || c == ' ' || c == '\t' || c == '\r'
+	})
+}
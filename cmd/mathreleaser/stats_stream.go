@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/internal/output"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/stats"
+)
+
+// streamSummary is a rolling summary printed by stats -stream. StdDev is the
+// sample standard deviation and is nil until two values have been read.
+type streamSummary struct {
+	Count       int64              `json:"count"`
+	Sum         interface{}        `json:"sum"`
+	Mean        interface{}        `json:"mean"`
+	Min         interface{}        `json:"min"`
+	Max         interface{}        `json:"max"`
+	StdDev      interface{}        `json:"stddev"`
+	Percentiles []percentileResult `json:"percentiles,omitempty"`
+}
+
+// streamStats reads numbers from r until EOF using a stats.Accumulator, so
+// memory use does not grow with the input. A summary is printed every
+// `every` values, if every is positive, and once more at the end.
+func streamStats(r io.Reader, ps []float64, every int) {
+	acc := stats.NewAccumulator()
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		for _, field := range splitNumbers(scanner.Text()) {
+			x, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				failf(exitInvalidInput, "Error parsing number: line %d: %v", line, err)
+				return
+			}
+			if err := acc.Add(x); err != nil {
+				fail(errorResult{Code: exitCodeFor(err), Message: fmt.Sprintf("Error computing statistics: line %d: %v", line, err)})
+				return
+			}
+			if every > 0 && acc.Count()%int64(every) == 0 {
+				printStreamSummary(acc, ps)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		failf(exitError, "Error reading input: %v", err)
+		return
+	}
+
+	switch {
+	case acc.Count() == 0:
+		failf(exitInvalidInput, "No numbers to summarize")
+	case every == 0 || acc.Count()%int64(every) != 0:
+		// The final summary was not already printed as a rolling one
+		printStreamSummary(acc, ps)
+	}
+}
+
+// printStreamSummary prints the current state of acc as a single line of
+// text, a JSON line or a YAML document.
+func printStreamSummary(acc *stats.Accumulator, ps []float64) {
+	s := streamSummary{Count: acc.Count(), Sum: output.Float(acc.Sum())}
+	s.Mean = optionalStat(acc.Mean())
+	s.Min = optionalStat(acc.Min())
+	s.Max = optionalStat(acc.Max())
+	s.StdDev = optionalStat(acc.SampleStdDev())
+	for _, p := range ps {
+		s.Percentiles = append(s.Percentiles, percentileResult{P: p, Value: optionalStat(acc.Percentile(p))})
+	}
+
+	switch outputFormat {
+	case output.Text:
+		fields := []string{
+			fmt.Sprintf("count=%d", s.Count),
+			"sum=" + formatStat(s.Sum),
+			"mean=" + formatStat(s.Mean),
+			"min=" + formatStat(s.Min),
+			"max=" + formatStat(s.Max),
+			"stddev=" + formatStat(s.StdDev),
+		}
+		for _, p := range s.Percentiles {
+			fields = append(fields, fmt.Sprintf("p%s=%s", strconv.FormatFloat(p.P, 'f', -1, 64), formatStat(p.Value)))
+		}
+		fmt.Println(strings.Join(fields, " "))
+	case output.YAML:
+		// Each summary is a separate document in the YAML stream
+		fmt.Println("---")
+		encode(os.Stdout, s)
+	default:
+		encode(os.Stdout, s)
+	}
+}
+
+// optionalStat returns v for use in a summary, or nil if the statistic is
+// undefined.
+func optionalStat(v float64, err error) interface{} {
+	if err != nil {
+		return nil
+	}
+	return output.Float(v)
+}
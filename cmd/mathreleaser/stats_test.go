@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestStats tests the stats subcommand
+func TestStats(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		stdin       string
+		expectedOut []string
+		expectedErr string
+		exitCode    int
+	}{
+		{
+			name:        "args",
+			args:        []string{"stats", "2", "4", "4", "4", "5", "5", "7", "9"},
+			expectedOut: []string{"count            8\n", "mean             5.00\n", "median           4.50\n", "mode             4.00\n", "stddev           2.00\n", "sample variance  4.57\n", "p75              5.50\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "stdin",
+			stdin:       "1, 2\n3\t4 5\n",
+			args:        []string{"stats"},
+			expectedOut: []string{"count            5\n", "sum              15.00\n", "mode             none\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "negative_numbers_and_trailing_flags",
+			args:        []string{"stats", "-1", "-3", "-percentiles", "50", "-method=lower"},
+			expectedOut: []string{"mean             -2.00\n", "p50              -3.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "histogram",
+			args:        []string{"stats", "-bins=2", "0", "1", "2", "3"},
+			expectedOut: []string{"[0.00, 1.50)     2  ####################\n", "[1.50, 3.00]     2  ####################\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "single_value",
+			args:        []string{"stats", "5"},
+			expectedOut: []string{"sample stddev    n/a\n", "skewness         n/a\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "json",
+			args:        []string{"-output=json", "stats", "-percentiles=", "3", "3"},
+			expectedOut: []string{`{"count":2,"sum":6,"mean":3,"median":3,"mode":[3],"min":3,"max":3,"variance":0,"sampleVariance":0,"stddev":0,"sampleStddev":0,"skewness":null,"kurtosis":null}` + "\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "invalid_number",
+			args:        []string{"stats", "1", "x"},
+			expectedErr: "Error parsing number",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "invalid_stdin_number",
+			args:        []string{"stats"},
+			stdin:       "1\n2 y\n",
+			expectedErr: "line 2",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "no_numbers",
+			args:        []string{"stats"},
+			stdin:       "\n",
+			expectedErr: "No numbers to summarize",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "non_finite",
+			args:        []string{"stats", "1", "NaN"},
+			expectedErr: "input is NaN or infinite",
+			exitCode:    exitNonFinite,
+		},
+		{
+			name:        "bad_percentile",
+			args:        []string{"stats", "-percentiles=150", "1"},
+			expectedErr: "invalid percentile",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "bad_method",
+			args:        []string{"stats", "-method=cubic", "1"},
+			expectedErr: "unknown interpolation method",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "stream_final_summary",
+			args:        []string{"stats", "-stream", "-percentiles=50"},
+			stdin:       "1 2 3\n4,5\n",
+			expectedOut: []string{"count=5 sum=15.00 mean=3.00 min=1.00 max=5.00 stddev=1.58 p50=3.00\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "stream_rolling",
+			args:        []string{"stats", "-stream", "-every=2", "-percentiles="},
+			stdin:       "1\n2\n3\n4\n5\n",
+			expectedOut: []string{"count=2 sum=3.00 mean=1.50 min=1.00 max=2.00 stddev=0.71\ncount=4 sum=10.00", "\ncount=5 sum=15.00"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "stream_json",
+			args:        []string{"-output=json", "stats", "-stream", "-percentiles="},
+			stdin:       "7\n",
+			expectedOut: []string{`{"count":1,"sum":7,"mean":7,"min":7,"max":7,"stddev":null}` + "\n"},
+			exitCode:    exitOK,
+		},
+		{
+			name:        "stream_invalid_number",
+			args:        []string{"stats", "-stream"},
+			stdin:       "1\nz\n",
+			expectedErr: "line 2",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "stream_with_args",
+			args:        []string{"stats", "-stream", "1"},
+			expectedErr: "does not accept numbers as arguments",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "stream_with_bins",
+			args:        []string{"stats", "-stream", "-bins=3"},
+			expectedErr: "cannot be used with -stream",
+			exitCode:    exitUsage,
+		},
+		{
+			name:        "stream_empty",
+			args:        []string{"stats", "-stream"},
+			expectedErr: "No numbers to summarize",
+			exitCode:    exitInvalidInput,
+		},
+		{
+			name:        "unknown_flag",
+			args:        []string{"stats", "-bogus", "1"},
+			expectedErr: "flag provided but not defined",
+			exitCode:    exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			rIn, wIn, _ := os.Pipe()
+			originalStdin := os.Stdin
+			os.Stdin = rIn
+			defer func() { os.Stdin = originalStdin }()
+			fmt.Fprint(wIn, tt.stdin)
+			wIn.Close()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			for _, want := range tt.expectedOut {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("Expected %q, got stdout: %s, stderr: %s", want, stdout, stderr)
+				}
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
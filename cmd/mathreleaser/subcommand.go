@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// subcommands maps the name of each subcommand to its implementation, which
+// receives the arguments that follow the name.
+var subcommands = map[string]func(args []string){
+	"batch":      runBatch,
+	"derivative": runDerivative,
+	"finance":    runFinance,
+	"integrate":  runIntegrate,
+	"matrix":     runMatrix,
+	"minimize":   runMinimize,
+	"poly":       runPoly,
+	"solve":      runSolve,
+	"stats":      runStats,
+}
+
+// newSubcommandFlags returns a flag set for the named subcommand that
+// reports errors on stderr instead of exiting.
+func newSubcommandFlags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+	return flags
+}
+
+// parseSubcommandFlags parses the arguments of a subcommand and returns the
+// positional arguments. On failure it exits with exitUsage, or with exitOK
+// for -h, and returns false.
+func parseSubcommandFlags(flags *flag.FlagSet, args []string) ([]string, bool) {
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			osExit(exitUsage)
+		}
+		return nil, false
+	}
+	return positional, true
+}
+
+// isSubcommandFlagSet reports whether the named flag was given to a
+// subcommand.
+func isSubcommandFlagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments. Arguments that parse as numbers, such as -5, or that
+// cannot be flag names, such as the expression -x^2+1, are positional, as is
+// everything after "--".
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for len(args) > 0 {
+		arg := args[0]
+		if arg == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" || isNumber(arg) || !isFlagName(arg) {
+			positional = append(positional, arg)
+			args = args[1:]
+			continue
+		}
+
+		n := flagLength(flags, args)
+		if err := flags.Parse(args[:n]); err != nil {
+			return nil, err
+		}
+		args = args[n:]
+	}
+	return positional, nil
+}
+
+// flagLength returns how many arguments the flag at the start of args
+// occupies: one for -name=value and boolean flags, two for -name value.
+func flagLength(flags *flag.FlagSet, args []string) int {
+	name := strings.TrimLeft(args[0], "-")
+	if strings.Contains(name, "=") || len(args) < 2 {
+		return 1
+	}
+	f := flags.Lookup(name)
+	if f == nil {
+		// Let Parse report the unknown flag
+		return 1
+	}
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return 1
+	}
+	return 2
+}
+
+// isFlagName reports whether arg, such as "-count=2", starts with a flag
+// name made only of letters, digits, '_', '.' and '-'.
+func isFlagName(arg string) bool {
+	name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		isLetter := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && !isDigit && c != '_' && c != '.' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumber reports whether s parses as a float64.
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// TestParseInterspersed tests flag parsing around positional arguments
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		positional  []string
+		count       int
+		verbose     bool
+		expectError bool
+	}{
+		{"flags_first", []string{"-count=2", "a", "b"}, []string{"a", "b"}, 2, false, false},
+		{"flags_last", []string{"a", "b", "-count", "3", "-v"}, []string{"a", "b"}, 3, true, false},
+		{"flags_between", []string{"a", "-v", "b"}, []string{"a", "b"}, 0, true, false},
+		{"negative_numbers", []string{"-1", "-count", "-2", "-3.5e2"}, []string{"-1", "-3.5e2"}, -2, false, false},
+		{"double_dash", []string{"a", "--", "-v", "-count=1"}, []string{"a", "-v", "-count=1"}, 0, false, false},
+		{"single_dash", []string{"-"}, []string{"-"}, 0, false, false},
+		{"expressions", []string{"-x^2+1", "-v", "-(x)"}, []string{"-x^2+1", "-(x)"}, 0, true, false},
+		{"unknown_flag", []string{"-bogus"}, nil, 0, false, true},
+		{"missing_value", []string{"a", "-count"}, nil, 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			count := flags.Int("count", 0, "")
+			verbose := flags.Bool("v", false, "")
+
+			positional, err := parseInterspersed(flags, tt.args)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("parseInterspersed(%v) error = %v, expectError %v", tt.args, err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+			if !reflect.DeepEqual(positional, tt.positional) {
+				t.Errorf("positional = %v, want %v", positional, tt.positional)
+			}
+			if *count != tt.count || *verbose != tt.verbose {
+				t.Errorf("count = %d, v = %v, want %d, %v", *count, *verbose, tt.count, tt.verbose)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestUnits tests expressions with units and -units definition files
+func TestUnits(t *testing.T) {
+	dir := t.TempDir()
+	unitsFile := filepath.Join(dir, "units.txt")
+	if err := os.WriteFile(unitsFile, []byte("# racing\nfurlong furlongs = 220 yd\nfortnight = 14 d\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	badFile := filepath.Join(dir, "bad.txt")
+	if err := os.WriteFile(badFile, []byte("furlong = 220 cubits\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"convert_sum", []string{"-e", "3 km + 200 m in mi"}, "3 km + 200 m in mi = 1.99 mi", "", exitOK},
+		{"sum_in_first_unit", []string{"-e", "3 km + 200 m"}, "3 km + 200 m = 3.20 km", "", exitOK},
+		{"speed", []string{"-e", "10 km / 2 h in mph"}, "10 km / 2 h in mph = 3.11 mph", "", exitOK},
+		{"data_size", []string{"-e", "1 GiB in MB"}, "1 GiB in MB = 1,073.74 MB", "", exitOK},
+		{"units_cancel", []string{"-e", "2 km / 500 m"}, "2 km / 500 m = 4.00", "", exitOK},
+		{"plain_expression", []string{"-e", "2 pi"}, "2 pi = 6.28", "", exitOK},
+		{"dimension_mismatch", []string{"-e", "5 kg + 3 s"}, "", "Error evaluating expression: column 6: dimension mismatch\n  5 kg + 3 s\n       ^", exitDomain},
+		{"conversion_mismatch", []string{"-e", "3 km in kg"}, "", "column 6: dimension mismatch", exitDomain},
+		{"unknown_unit", []string{"-e", "3 cubits in m"}, "", "unknown unit \"cubits\"", exitInvalidInput},
+		{"json", []string{"-output=json", "-e", "60 km/h in m/s"}, `"result":16.666666666666668,"unit":"m/s","formatted":"16.67 m/s"`, "", exitOK},
+		{"json_detail", []string{"-output=json", "-e", "5 kg + 3 s"}, "", `"detail":"add(5 kg, 3 s): dimension mismatch"`, exitDomain},
+		{"units_file", []string{"-units=" + unitsFile, "-e", "2 furlongs / 1 fortnight in m/h"}, "= 1.20 m/h", "", exitOK},
+		{"units_file_missing", []string{"-units=" + filepath.Join(dir, "missing.txt"), "-e", "1 m"}, "", "Error reading units", exitError},
+		{"units_file_invalid", []string{"-units=" + badFile, "-e", "1 m"}, "", "line 1: invalid definition", exitInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestVariadicOperations tests operations taking any number of operands
+func TestVariadicOperations(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		stdin       string
+		expectedOut string
+		expectedErr string
+		exitCode    int
+	}{
+		{"add", []string{"-op=add", "1", "2", "3"}, "", "1 + 2 + 3 = 6.00", "", exitOK},
+		{"add_compensated", []string{"-op=add", "1e100", "1", "-1e100"}, "", "1e100 + 1 + -1e100 = 1.00", "", exitOK},
+		{"multiply", []string{"-op=multiply", "2", "3", "4"}, "", "2 * 3 * 4 = 24.00", "", exitOK},
+		{"min", []string{"-op=min", "--", "3", "-1", "2"}, "", "min(3, -1, 2) = -1.00", "", exitOK},
+		{"max_single", []string{"-op=max", "7"}, "", "max(7) = 7.00", "", exitOK},
+		{"mean", []string{"-op=mean", "1", "2", "3", "4"}, "", "mean(1, 2, 3, 4) = 2.50", "", exitOK},
+		{"mean_large", []string{"-sig=3", "-op=mean", "1e308", "1e308"}, "", "mean(1e308, 1e308) = 100,000,000,000,000,000,000", "", exitOK},
+		{"mean_nan", []string{"-op=mean", "1", "NaN"}, "", "", "Error performing mean: input is NaN or infinite", exitNonFinite},
+		{"gcd", []string{"-op=gcd", "12", "18", "24"}, "", "gcd(12, 18, 24) = 6.00", "", exitOK},
+		{"lcm", []string{"-op=lcm", "4", "6", "10"}, "", "lcm(4, 6, 10) = 60.00", "", exitOK},
+		{"gcd_fraction", []string{"-op=gcd", "1.5", "3"}, "", "", "Error performing greatest common divisor: operand must be an integer", exitDomain},
+		{"json_operands", []string{"-output=json", "-op=add", "1", "2", "3"}, "", `"operands":[1,2,3]`, "", exitOK},
+		{"invalid_third_operand", []string{"-op=add", "1", "2", "x"}, "", "", "Error parsing number 3", exitInvalidInput},
+		{"too_few", []string{"-op=add", "1"}, "", "<number>... (or numbers on stdin)", "", exitUsage},
+		{"stdin", []string{"-op=add"}, "1 2\n3, 4\n", "1 + 2 + 3 + 4 = 10.00", "", exitOK},
+		{"stdin_mean", []string{"-op=mean"}, "2\n4\n", "mean(2, 4) = 3.00", "", exitOK},
+		{"stdin_empty", []string{"-op=max"}, "", "Usage:", "", exitUsage},
+		{"stdin_invalid", []string{"-op=add"}, "1 two\n", "", "Error parsing second number", exitInvalidInput},
+		{"stdin_needs_op", []string{}, "1 2 3\n", "Usage:", "", exitUsage},
+		{"batch", []string{"batch"}, "add 1 2 3\nmax 4 5\n", "1 + 2 + 3 = 6.00\nmax(4, 5) = 5.00", "", exitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			outBuf, errBuf, rOut, wOut, rErr, wErr := setup()
+			defer teardown()
+
+			rIn, wIn, _ := os.Pipe()
+			originalStdin := os.Stdin
+			os.Stdin = rIn
+			defer func() { os.Stdin = originalStdin }()
+			fmt.Fprint(wIn, tt.stdin)
+			wIn.Close()
+
+			os.Args = append([]string{"mathreleaser"}, tt.args...)
+			mainInternal()
+
+			stdout, stderr := getOutput(outBuf, errBuf, rOut, wOut, rErr, wErr)
+
+			if tt.expectedOut != "" && !strings.Contains(stdout, tt.expectedOut) {
+				t.Errorf("Expected %q, got stdout: %s, stderr: %s", tt.expectedOut, stdout, stderr)
+			}
+			if tt.expectedErr != "" && !strings.Contains(stderr, tt.expectedErr) {
+				t.Errorf("Expected error %q, got stdout: %s, stderr: %s", tt.expectedErr, stdout, stderr)
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, exitCode)
+			}
+		})
+	}
+}
@@ -124,6 +124,18 @@ func TestIntegration(t *testing.T) {
 			expectedOut:   "random(20, 10) = ", // The output shows the original args
 			expectSuccess: true,
 		},
+		{
+			name:          "Expression",
+			args:          []string{"-e", "2*(3+4)^2"},
+			expectedOut:   "2*(3+4)^2 = 98.00",
+			expectSuccess: true,
+		},
+		{
+			name:          "Expression with syntax error",
+			args:          []string{"-e", "2*(3+4"},
+			expectedErr:   "Error: Error parsing expression: column 7",
+			expectSuccess: false,
+		},
 		{
 			name:          "Invalid operation",
 			args:          []string{"-op=invalid", "5", "3"},
@@ -133,7 +145,7 @@ func TestIntegration(t *testing.T) {
 		{
 			name:          "Missing arguments for add",
 			args:          []string{"-op=add", "5"},
-			expectedOut:   "Usage: mathreleaser -op=[add|subtract|multiply|divide|power|random] <number1> <number2>",
+			expectedOut:   "Usage: mathreleaser -op=[add|multiply|min|max|mean|gcd|lcm] <number>... (or numbers on stdin)",
 			expectSuccess: false,
 		},
 		{
@@ -145,7 +157,7 @@ func TestIntegration(t *testing.T) {
 		{
 			name:          "Missing arguments for sqrt",
 			args:          []string{"-op=sqrt"},
-			expectedOut:   "Usage: mathreleaser -op=[sqrt|sin|cos|tan] <number>",
+			expectedOut:   "Usage: mathreleaser [-angle=rad|deg|grad|turn] -op=[sqrt|cbrt|sin|cos|tan|asin|acos|atan|sinh|cosh|tanh|asinh|acosh|atanh|ln|log10|log2|log1p|exp|expm1|gamma|factorial|erf|erfc] <number>",
 			expectSuccess: false,
 		},
 		{
@@ -0,0 +1,314 @@
+package helpers
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Notation selects how a Formatter writes numbers.
+type Notation int
+
+// Notations accepted by Formatter.
+const (
+	// NotationFixed writes 12345.678 as "12,345.68".
+	NotationFixed Notation = iota
+	// NotationScientific writes 12345.678 as "1.23e4", with one digit
+	// before the decimal point.
+	NotationScientific
+	// NotationEngineering writes 12345.678 as "12.35e3", with an exponent
+	// that is a multiple of three.
+	NotationEngineering
+	// NotationSI writes 12345.678 as "12.35k", replacing the exponent of
+	// engineering notation with an SI prefix. Exponents beyond the prefixes
+	// (10^-30 to 10^30) are written as in engineering notation.
+	NotationSI
+)
+
+// notationNames maps each Notation to its name.
+var notationNames = []string{"fixed", "scientific", "engineering", "si"}
+
+// String returns the name of the notation.
+func (n Notation) String() string {
+	if n < 0 || int(n) >= len(notationNames) {
+		return fmt.Sprintf("Notation(%d)", int(n))
+	}
+	return notationNames[n]
+}
+
+// NotationNames returns the names accepted by ParseNotation.
+func NotationNames() []string {
+	return append([]string(nil), notationNames...)
+}
+
+// ParseNotation converts a notation name (fixed, scientific, engineering or
+// si) into a Notation.
+func ParseNotation(name string) (Notation, error) {
+	for i, n := range notationNames {
+		if n == name {
+			return Notation(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown notation %q (want fixed, scientific, engineering or si)", name)
+}
+
+// siPrefixes holds the SI prefixes from 10^-30 to 10^30, in steps of 10^3.
+var siPrefixes = []string{"q", "r", "y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y", "R", "Q"}
+
+// Locale holds the separators a Formatter writes numbers with.
+type Locale struct {
+	// Name is the language tag of the locale, e.g. "de-DE".
+	Name string
+	// Group separates groups of digits in the integer part.
+	Group string
+	// Decimal separates the integer part from the fraction.
+	Decimal string
+	// Primary is the size of the group nearest the decimal separator, and
+	// Secondary the size of the others, so en-IN writes 12,34,567.
+	Primary, Secondary int
+}
+
+// locales holds the locales accepted by ParseLocale. The first is the
+// default.
+var locales = []Locale{
+	{Name: "en-US", Group: ",", Decimal: ".", Primary: 3, Secondary: 3},
+	{Name: "de-DE", Group: ".", Decimal: ",", Primary: 3, Secondary: 3},
+	{Name: "fr-FR", Group: "\u202f", Decimal: ",", Primary: 3, Secondary: 3},
+	{Name: "en-IN", Group: ",", Decimal: ".", Primary: 3, Secondary: 2},
+}
+
+// LocaleNames returns the names accepted by ParseLocale.
+func LocaleNames() []string {
+	names := make([]string, len(locales))
+	for i, l := range locales {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// ParseLocale returns the locale with the given name, such as "de-DE".
+func ParseLocale(name string) (Locale, error) {
+	for _, l := range locales {
+		if l.Name == name {
+			return l, nil
+		}
+	}
+	return Locale{}, fmt.Errorf("unknown locale %q (want %s)", name, strings.Join(LocaleNames(), ", "))
+}
+
+// group inserts the group separator into a string of digits.
+func (l Locale) group(digits string) string {
+	if l.Group == "" || len(digits) <= l.Primary {
+		return digits
+	}
+	var groups []string
+	end, size := len(digits), l.Primary
+	for end > size {
+		groups = append(groups, digits[end-size:end])
+		end -= size
+		size = l.Secondary
+	}
+	groups = append(groups, digits[:end])
+	for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
+		groups[i], groups[j] = groups[j], groups[i]
+	}
+	return strings.Join(groups, l.Group)
+}
+
+// MaxPlaces is the largest Precision or Significant a Formatter honours;
+// larger values are treated as MaxPlaces. It is more digits than a float64
+// or calculator.Number at calculator.MaxPrecision has.
+const MaxPlaces = 10000
+
+// Formatter formats numbers for display. The zero value writes numbers in
+// fixed notation with no decimal places and the separators of en-US.
+//
+// Values are rounded half to even, as the strconv and fmt packages do, and
+// a value that rounds to zero is written without a sign. NaN and infinities
+// are written as "NaN", "+Inf" and "-Inf" in every notation.
+type Formatter struct {
+	// Precision is the number of decimal places: after the decimal point in
+	// fixed notation, and in the mantissa in the other notations.
+	Precision int
+	// Significant, if positive, rounds to this many significant figures
+	// instead of Precision decimal places.
+	Significant int
+	// Notation selects fixed, scientific, engineering or SI notation.
+	Notation Notation
+	// Locale holds the separators. The zero Locale is en-US.
+	Locale Locale
+}
+
+// DefaultFormatter returns the formatter of FormatNumber: two decimal places
+// in fixed notation with the separators of en-US.
+func DefaultFormatter() Formatter {
+	return Formatter{Precision: 2}
+}
+
+// Format formats a float64.
+func (f Formatter) Format(x float64) string {
+	if math.IsNaN(x) {
+		return "NaN"
+	}
+	return f.FormatFloat(new(big.Float).SetFloat64(x))
+}
+
+// FormatFloat formats a big.Float exactly as Format formats a float64, so
+// arbitrary-precision results are rounded from all of their digits.
+func (f Formatter) FormatFloat(x *big.Float) string {
+	f = f.limited()
+	if x.IsInf() {
+		if x.Signbit() {
+			return "-Inf"
+		}
+		return "+Inf"
+	}
+	abs := new(big.Float).Abs(x)
+
+	var integerPart, fraction, suffix string
+	switch {
+	case f.Notation == NotationFixed && f.Significant <= 0:
+		integerPart, fraction, _ = strings.Cut(abs.Text('f', f.Precision), ".")
+	case f.Notation == NotationFixed:
+		digits, exp := significantDigits(abs, f.Significant)
+		integerPart, fraction = placePoint(digits, exp+1)
+	case f.Notation == NotationScientific:
+		digits, exp := significantDigits(abs, f.figures(1))
+		integerPart, fraction = placePoint(digits, 1)
+		suffix = "e" + strconv.Itoa(exp)
+	default:
+		digits, exp := significantDigits(abs, f.figures(1))
+		eng := floorDiv(exp, 3) * 3
+		if f.Significant <= 0 {
+			// The number of digits depends on the exponent, which
+			// rounding can carry to the next power of ten.
+			for e := exp; ; e = exp {
+				eng = floorDiv(e, 3) * 3
+				if digits, exp = significantDigits(abs, f.figures(e-eng+1)); exp == e {
+					break
+				}
+			}
+		}
+		integerPart, fraction = placePoint(digits, exp-eng+1)
+		suffix = "e" + strconv.Itoa(eng)
+		if i := eng/3 + len(siPrefixes)/2; f.Notation == NotationSI && i >= 0 && i < len(siPrefixes) {
+			suffix = siPrefixes[i]
+		}
+	}
+
+	locale := f.Locale
+	if locale.Name == "" {
+		locale = locales[0]
+	}
+	s := locale.group(integerPart)
+	if fraction != "" {
+		s += locale.Decimal + fraction
+	}
+	if x.Signbit() && strings.Trim(integerPart+fraction, "0") != "" {
+		s = "-" + s
+	}
+	return s + suffix
+}
+
+// limited returns f with Precision and Significant reduced to MaxPlaces.
+func (f Formatter) limited() Formatter {
+	if f.Precision > MaxPlaces {
+		f.Precision = MaxPlaces
+	}
+	if f.Significant > MaxPlaces {
+		f.Significant = MaxPlaces
+	}
+	return f
+}
+
+// figures returns the number of significant figures to round to when
+// integerDigits digits come before the decimal point.
+func (f Formatter) figures(integerDigits int) int {
+	if f.Significant > 0 {
+		return f.Significant
+	}
+	return integerDigits + f.Precision
+}
+
+// FormatComplex formats a complex number, e.g. "3.00 + 4.00i". A part that
+// rounds to zero is omitted, so real results print like Format and purely
+// imaginary ones like "2.00i".
+func (f Formatter) FormatComplex(c complex128) string {
+	re := f.Format(real(c))
+	im := f.Format(imag(c))
+
+	switch {
+	case isFormattedZero(im):
+		return re
+	case isFormattedZero(re):
+		return im + "i"
+	case strings.HasPrefix(im, "-"):
+		return re + " - " + im[1:] + "i"
+	default:
+		return re + " + " + im + "i"
+	}
+}
+
+// FormatDecimal formats a plain decimal string, such as "-1234.5" produced
+// by big.Float.Text, with the separators of the locale and at least
+// Precision decimal places. It never rounds, and ignores Significant and
+// Notation. Infinities and NaN, such as "+Inf", are returned unchanged.
+func (f Formatter) FormatDecimal(s string) string {
+	if strings.HasSuffix(s, "Inf") || s == "NaN" {
+		return s
+	}
+	f = f.limited()
+	locale := f.Locale
+	if locale.Name == "" {
+		locale = locales[0]
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	integerPart, fraction, _ := strings.Cut(s, ".")
+	for len(fraction) < f.Precision {
+		fraction += "0"
+	}
+	s = locale.group(integerPart)
+	if fraction != "" {
+		s += locale.Decimal + fraction
+	}
+	return sign + s
+}
+
+// significantDigits rounds x, which must be finite and not negative, to n
+// significant figures. It returns the digits and the decimal exponent of
+// the first, so 1234.5 to 3 figures is "123" and 3.
+func significantDigits(x *big.Float, n int) (string, int) {
+	if n < 1 {
+		n = 1
+	}
+	mantissa, exp, _ := strings.Cut(x.Text('e', n-1), "e")
+	e, _ := strconv.Atoi(exp)
+	return strings.Replace(mantissa, ".", "", 1), e
+}
+
+// placePoint splits digits into an integer part and a fraction after the
+// first n digits, padding with zeros where n lies outside them.
+func placePoint(digits string, n int) (string, string) {
+	switch {
+	case n <= 0:
+		return "0", strings.Repeat("0", -n) + digits
+	case n >= len(digits):
+		return digits + strings.Repeat("0", n-len(digits)), ""
+	default:
+		return digits[:n], digits[n:]
+	}
+}
+
+// floorDiv returns a/b rounded towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,169 @@
+package helpers
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestFormatterFormat(t *testing.T) {
+	deDE, _ := ParseLocale("de-DE")
+	frFR, _ := ParseLocale("fr-FR")
+	enIN, _ := ParseLocale("en-IN")
+
+	tests := []struct {
+		name      string
+		formatter Formatter
+		input     float64
+		expected  string
+	}{
+		{"default", DefaultFormatter(), 1234567.891, "1,234,567.89"},
+		{"zero_value", Formatter{}, 1234.5, "1,234"},
+		{"precision", Formatter{Precision: 4}, math.Pi, "3.1416"},
+		{"round_half_even", Formatter{Precision: 2}, 0.125, "0.12"},
+		{"negative_zero", Formatter{Precision: 2}, math.Copysign(0, -1), "0.00"},
+		{"significant", Formatter{Significant: 3}, 1234.5, "1,230"},
+		{"significant_small", Formatter{Significant: 2}, 0.000123456, "0.00012"},
+		{"significant_trailing_zeros", Formatter{Significant: 3}, 1.5, "1.50"},
+		{"scientific", Formatter{Precision: 2, Notation: NotationScientific}, 12345.678, "1.23e4"},
+		{"scientific_small", Formatter{Precision: 3, Notation: NotationScientific}, -0.00012345, "-1.234e-4"},
+		{"scientific_zero", Formatter{Precision: 2, Notation: NotationScientific}, 0, "0.00e0"},
+		{"scientific_significant", Formatter{Significant: 2, Notation: NotationScientific}, 98765, "9.9e4"},
+		{"scientific_carry", Formatter{Precision: 1, Notation: NotationScientific}, 9.96, "1.0e1"},
+		{"engineering", Formatter{Precision: 2, Notation: NotationEngineering}, 12345.678, "12.35e3"},
+		{"engineering_small", Formatter{Precision: 1, Notation: NotationEngineering}, 0.00047, "470.0e-6"},
+		{"engineering_carry", Formatter{Precision: 2, Notation: NotationEngineering}, 999.996, "1.00e3"},
+		{"engineering_significant", Formatter{Significant: 2, Notation: NotationEngineering}, 123456, "120e3"},
+		{"si", Formatter{Precision: 2, Notation: NotationSI}, 12345.678, "12.35k"},
+		{"si_micro", Formatter{Precision: 1, Notation: NotationSI}, -0.0000047, "-4.7µ"},
+		{"si_units", Formatter{Precision: 2, Notation: NotationSI}, 42, "42.00"},
+		{"si_out_of_range", Formatter{Precision: 1, Notation: NotationSI}, 1e33, "1.0e33"},
+		{"de_DE", Formatter{Precision: 2, Locale: deDE}, -1234567.891, "-1.234.567,89"},
+		{"fr_FR", Formatter{Precision: 2, Locale: frFR}, 1234567.891, "1\u202f234\u202f567,89"},
+		{"en_IN", Formatter{Precision: 2, Locale: enIN}, 123456789.5, "12,34,56,789.50"},
+		{"en_IN_thousands", Formatter{Locale: enIN}, 1234, "1,234"},
+		{"de_DE_scientific", Formatter{Precision: 2, Notation: NotationScientific, Locale: deDE}, 1234, "1,23e3"},
+		{"nan", Formatter{Precision: 2, Notation: NotationSI}, math.NaN(), "NaN"},
+		{"infinity", Formatter{Precision: 2, Notation: NotationScientific}, math.Inf(1), "+Inf"},
+		{"negative_infinity", Formatter{Significant: 3}, math.Inf(-1), "-Inf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.Format(tt.input); got != tt.expected {
+				t.Errorf("%+v.Format(%v) = %q, want %q", tt.formatter, tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatterFormatFloat(t *testing.T) {
+	third := new(big.Float).SetPrec(200).Quo(big.NewFloat(1).SetPrec(200), big.NewFloat(3))
+	f := Formatter{Precision: 30}
+	if got, want := f.FormatFloat(third), "0.333333333333333333333333333333"; got != want {
+		t.Errorf("FormatFloat(1/3) = %q, want %q", got, want)
+	}
+}
+
+func TestFormatterMaxPlaces(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter Formatter
+		format    func(Formatter) string
+	}{
+		{"precision", Formatter{Precision: 2000000000}, func(f Formatter) string { return f.Format(0.5) }},
+		{"significant", Formatter{Significant: 2000000000}, func(f Formatter) string { return f.Format(0.5) }},
+		{"decimal", Formatter{Precision: 2000000000}, func(f Formatter) string { return f.FormatDecimal("0.5") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, fraction, _ := strings.Cut(tt.format(tt.formatter), ".")
+			if len(fraction) != MaxPlaces {
+				t.Errorf("%+v wrote %d decimal places, want %d", tt.formatter, len(fraction), MaxPlaces)
+			}
+		})
+	}
+}
+
+func TestFormatterFormatComplex(t *testing.T) {
+	deDE, _ := ParseLocale("de-DE")
+
+	tests := []struct {
+		name      string
+		formatter Formatter
+		input     complex128
+		expected  string
+	}{
+		{"scientific", Formatter{Precision: 1, Notation: NotationScientific}, complex(1500, -0.25), "1.5e3 - 2.5e-1i"},
+		{"scientific_zero_part", Formatter{Precision: 1, Notation: NotationScientific}, complex(0, 2), "2.0e0i"},
+		{"de_DE", Formatter{Precision: 2, Locale: deDE}, complex(3, 4), "3,00 + 4,00i"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.FormatComplex(tt.input); got != tt.expected {
+				t.Errorf("%+v.FormatComplex(%v) = %q, want %q", tt.formatter, tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatterFormatDecimal(t *testing.T) {
+	deDE, _ := ParseLocale("de-DE")
+	enIN, _ := ParseLocale("en-IN")
+
+	tests := []struct {
+		name      string
+		formatter Formatter
+		input     string
+		expected  string
+	}{
+		{"no_rounding", Formatter{Precision: 2}, "0.333333333333", "0.333333333333"},
+		{"pads", Formatter{Precision: 3}, "-1234.5", "-1,234.500"},
+		{"no_places", Formatter{}, "42", "42"},
+		{"de_DE", Formatter{Precision: 2, Locale: deDE}, "1234567.25", "1.234.567,25"},
+		{"en_IN", Formatter{Locale: enIN}, "10000000", "1,00,00,000"},
+		{"infinity", Formatter{Precision: 2}, "+Inf", "+Inf"},
+		{"negative_infinity", Formatter{Precision: 2, Locale: deDE}, "-Inf", "-Inf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.FormatDecimal(tt.input); got != tt.expected {
+				t.Errorf("%+v.FormatDecimal(%q) = %q, want %q", tt.formatter, tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseNotation(t *testing.T) {
+	for _, name := range NotationNames() {
+		n, err := ParseNotation(name)
+		if err != nil {
+			t.Fatalf("ParseNotation(%q) unexpected error: %v", name, err)
+		}
+		if n.String() != name {
+			t.Errorf("ParseNotation(%q).String() = %q", name, n.String())
+		}
+	}
+	if _, err := ParseNotation("roman"); err == nil {
+		t.Errorf("ParseNotation(%q) expected error", "roman")
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	for _, name := range LocaleNames() {
+		l, err := ParseLocale(name)
+		if err != nil {
+			t.Fatalf("ParseLocale(%q) unexpected error: %v", name, err)
+		}
+		if l.Name != name {
+			t.Errorf("ParseLocale(%q).Name = %q", name, l.Name)
+		}
+	}
+	if _, err := ParseLocale("xx-XX"); err == nil {
+		t.Errorf("ParseLocale(%q) expected error", "xx-XX")
+	}
+}
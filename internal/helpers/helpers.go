@@ -3,15 +3,185 @@ package helpers
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-// FormatNumber formats a number with comma separators for thousands.
+// FormatNumber formats a number with comma separators for thousands and two
+// decimal places, as DefaultFormatter does.
 func FormatNumber(n float64) string {
-	parts := strings.Split(fmt.Sprintf("%.2f", n), ".")
-	integerPart := parts[0]
-	decimalPart := parts[1]
+	return DefaultFormatter().Format(n)
+}
+
+// FormatComplex formats a complex number using FormatNumber for both parts,
+// e.g. "3.00 + 4.00i". A part that rounds to zero is omitted, so real
+// results print like FormatNumber and purely imaginary ones like "2.00i".
+func FormatComplex(c complex128) string {
+	return DefaultFormatter().FormatComplex(c)
+}
+
+// isFormattedZero reports whether a formatted number represents zero: it has
+// no digits other than zeros before any exponent.
+func isFormattedZero(s string) bool {
+	mantissa, _, _ := strings.Cut(s, "e")
+	return strings.Contains(mantissa, "0") && !strings.ContainsAny(mantissa, "123456789")
+}
+
+// FormatDecimal formats a plain decimal string, such as "-1234.5" produced by
+// big.Float.Text, with comma separators for thousands and at least two
+// decimal places. Unlike FormatNumber it never rounds, so it is suitable for
+// arbitrary-precision results.
+func FormatDecimal(s string) string {
+	return DefaultFormatter().FormatDecimal(s)
+}
+
+// FormatCurrency formats an amount of money with its currency symbol and
+// comma separators for thousands, rounded to minorUnits decimal places with
+// halves rounded away from zero, e.g. "$1,234.57", "-€0.50" or "¥1,235". A
+// symbol ending in a letter, such as "CHF", is separated from the amount by
+// a space.
+func FormatCurrency(amount *big.Rat, symbol string, minorUnits int) string {
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+	integerPart, decimalPart, _ := strings.Cut(new(big.Rat).Abs(amount).FloatString(minorUnits), ".")
+	formatted := groupThousands(integerPart)
+	if decimalPart != "" {
+		formatted += "." + decimalPart
+	}
+	if r, _ := utf8.DecodeLastRuneInString(symbol); unicode.IsLetter(r) {
+		symbol += " "
+	}
+	if isFormattedZero(formatted) {
+		sign = ""
+	}
+	return sign + symbol + formatted
+}
+
+// FractionFormat selects how FormatFraction presents a fraction.
+type FractionFormat int
+
+// Fraction formats accepted by FormatFraction.
+const (
+	// FractionImproper renders 7/3 as "7/3".
+	FractionImproper FractionFormat = iota
+	// FractionMixed renders 7/3 as "2 1/3".
+	FractionMixed
+	// FractionDecimal renders 7/3 as "2.(3)", marking any repeating digits
+	// with parentheses so that no rounding takes place.
+	FractionDecimal
+)
+
+// maxDecimalDigits is the number of digits FormatFraction will expand in
+// FractionDecimal format while looking for a repeating block.
+const maxDecimalDigits = 100
+
+// ParseFractionFormat converts a format name (improper, mixed or decimal)
+// into a FractionFormat.
+func ParseFractionFormat(name string) (FractionFormat, error) {
+	switch name {
+	case "improper":
+		return FractionImproper, nil
+	case "mixed":
+		return FractionMixed, nil
+	case "decimal":
+		return FractionDecimal, nil
+	default:
+		return 0, fmt.Errorf("unknown fraction format %q (want improper, mixed or decimal)", name)
+	}
+}
+
+// FormatFraction formats an exact fraction with comma separators for
+// thousands, as FormatNumber does for floating-point values.
+func FormatFraction(r *big.Rat, format FractionFormat) string {
+	switch format {
+	case FractionMixed:
+		if r.IsInt() {
+			return groupThousands(r.Num().String())
+		}
+		num := new(big.Int).Abs(r.Num())
+		whole, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+		fraction := groupThousands(rem.String()) + "/" + groupThousands(r.Denom().String())
+		if whole.Sign() == 0 {
+			if r.Sign() < 0 {
+				return "-" + fraction
+			}
+			return fraction
+		}
+		sign := ""
+		if r.Sign() < 0 {
+			sign = "-"
+		}
+		return sign + groupThousands(whole.String()) + " " + fraction
+	case FractionDecimal:
+		return formatRepeatingDecimal(r)
+	default:
+		if r.IsInt() {
+			return groupThousands(r.Num().String())
+		}
+		return groupThousands(r.Num().String()) + "/" + groupThousands(r.Denom().String())
+	}
+}
+
+// formatRepeatingDecimal expands r by long division, enclosing the repeating
+// block in parentheses. Expansions that have not started repeating within
+// maxDecimalDigits digits are cut off and end in "...".
+func formatRepeatingDecimal(r *big.Rat) string {
+	sign := ""
+	if r.Sign() < 0 {
+		sign = "-"
+	}
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+	whole, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	// Long division, remembering where each remainder was first seen so the
+	// start of the repeating block can be found.
+	seen := make(map[string]int)
+	ten := big.NewInt(10)
+	var digits []byte
+	repeatAt := -1
+	for rem.Sign() != 0 {
+		key := rem.String()
+		if pos, ok := seen[key]; ok {
+			repeatAt = pos
+			break
+		}
+		if len(digits) >= maxDecimalDigits {
+			break
+		}
+		seen[key] = len(digits)
+		rem.Mul(rem, ten)
+		digit := new(big.Int)
+		digit.QuoRem(rem, den, rem)
+		digits = append(digits, byte('0'+digit.Int64()))
+	}
+
+	decimals := string(digits)
+	switch {
+	case repeatAt >= 0:
+		decimals = decimals[:repeatAt] + "(" + decimals[repeatAt:] + ")"
+	case rem.Sign() != 0:
+		decimals += "..."
+	}
+	for len(decimals) < 2 {
+		decimals += "0"
+	}
+
+	return sign + groupThousands(whole.String()) + "." + decimals
+}
+
+// groupThousands inserts comma separators into a string of digits with an
+// optional leading sign.
+func groupThousands(integerPart string) string {
+	sign := ""
+	if strings.HasPrefix(integerPart, "-") || strings.HasPrefix(integerPart, "+") {
+		sign, integerPart = integerPart[:1], integerPart[1:]
+	}
 
 	var result []byte
 	for i, c := range integerPart {
@@ -21,7 +191,7 @@ func FormatNumber(n float64) string {
 		result = append(result, byte(c))
 	}
 
-	return string(result) + "." + decimalPart
+	return sign + string(result)
 }
 
 // Variable that holds the exit function, allowing it to be mocked in tests
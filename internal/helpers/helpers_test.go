@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"math"
+	"math/big"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -30,6 +32,11 @@ func TestFormatNumber(t *testing.T) {
 		{"large_number", 1234567890.12, "1,234,567,890.12"},
 		{"integer", 42, "42.00"},
 		{"small_decimal", 0.42, "0.42"},
+		{"negative_below_one", -0.5, "-0.50"},
+		{"negative_rounds_to_zero", -0.001, "0.00"},
+		{"not_a_number", math.NaN(), "NaN"},
+		{"positive_infinity", math.Inf(1), "+Inf"},
+		{"negative_infinity", math.Inf(-1), "-Inf"},
 	}
 
 	for _, tt := range tests {
@@ -193,3 +200,129 @@ func TestPrintError(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatDecimal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"integer", "42", "42.00"},
+		{"one_decimal", "0.3", "0.30"},
+		{"many_decimals", "0.333333333333333333333", "0.333333333333333333333"},
+		{"thousands", "1234567.5", "1,234,567.50"},
+		{"negative_hundreds", "-123.45", "-123.45"},
+		{"negative_thousands", "-1234", "-1,234.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDecimal(tt.input); got != tt.expected {
+				t.Errorf("FormatDecimal(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatFraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		num, den int64
+		format   FractionFormat
+		expected string
+	}{
+		{"improper", 7, 3, FractionImproper, "7/3"},
+		{"improper_integer", 6, 3, FractionImproper, "2"},
+		{"improper_grouped", 1234567, 2, FractionImproper, "1,234,567/2"},
+		{"mixed", 7, 3, FractionMixed, "2 1/3"},
+		{"mixed_negative", -7, 3, FractionMixed, "-2 1/3"},
+		{"mixed_proper", -1, 2, FractionMixed, "-1/2"},
+		{"mixed_integer", 4000, 1, FractionMixed, "4,000"},
+		{"decimal_terminating", 3, 10, FractionDecimal, "0.30"},
+		{"decimal_repeating", 1, 3, FractionDecimal, "0.(3)"},
+		{"decimal_partly_repeating", -7, 6, FractionDecimal, "-1.1(6)"},
+		{"decimal_long_repetend", 1, 7, FractionDecimal, "0.(142857)"},
+		{"decimal_grouped", 123456789, 4, FractionDecimal, "30,864,197.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatFraction(big.NewRat(tt.num, tt.den), tt.format)
+			if got != tt.expected {
+				t.Errorf("FormatFraction(%d/%d) = %v, want %v", tt.num, tt.den, got, tt.expected)
+			}
+		})
+	}
+
+	// 1/9973 has a 9972 digit repetend, so the expansion is cut off
+	got := FormatFraction(big.NewRat(1, 9973), FractionDecimal)
+	if len(got) != len("0.")+maxDecimalDigits+len("...") {
+		t.Errorf("FormatFraction(1/9973) = %v, want a truncated expansion", got)
+	}
+}
+
+func TestParseFractionFormat(t *testing.T) {
+	for name, want := range map[string]FractionFormat{"improper": FractionImproper, "mixed": FractionMixed, "decimal": FractionDecimal} {
+		if got, err := ParseFractionFormat(name); err != nil || got != want {
+			t.Errorf("ParseFractionFormat(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseFractionFormat("roman"); err == nil {
+		t.Errorf("ParseFractionFormat(%q) expected error", "roman")
+	}
+}
+
+func TestFormatComplex(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    complex128
+		expected string
+	}{
+		{"real_only", complex(8, 0), "8.00"},
+		{"imaginary_only", complex(0, 2), "2.00i"},
+		{"negative_imaginary", complex(0, -2), "-2.00i"},
+		{"both_parts", complex(3, 4), "3.00 + 4.00i"},
+		{"negative_imaginary_part", complex(1234.5, -0.25), "1,234.50 - 0.25i"},
+		{"imaginary_rounds_to_zero", complex(-1, 1e-17), "-1.00"},
+		{"zero", 0, "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatComplex(tt.input); got != tt.expected {
+				t.Errorf("FormatComplex(%v) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     string
+		symbol     string
+		minorUnits int
+		expected   string
+	}{
+		{"dollars", "1234.567", "$", 2, "$1,234.57"},
+		{"round_half_away", "0.125", "€", 2, "€0.13"},
+		{"negative_below_one", "-0.5", "€", 2, "-€0.50"},
+		{"negative_zero", "-0.001", "£", 2, "£0.00"},
+		{"no_minor_units", "1234.5", "¥", 0, "¥1,235"},
+		{"three_minor_units", "1.2345", "KWD", 3, "KWD 1.235"},
+		{"letter_symbol", "-1000000", "CHF", 2, "-CHF 1,000,000.00"},
+		{"fraction", "1/3", "$", 2, "$0.33"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, ok := new(big.Rat).SetString(tt.amount)
+			if !ok {
+				t.Fatalf("invalid amount %q", tt.amount)
+			}
+			if got := FormatCurrency(amount, tt.symbol, tt.minorUnits); got != tt.expected {
+				t.Errorf("FormatCurrency(%s, %q, %d) = %q, want %q", tt.amount, tt.symbol, tt.minorUnits, got, tt.expected)
+			}
+		})
+	}
+}
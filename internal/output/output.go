@@ -0,0 +1,303 @@
+// Package output encodes command results in machine-readable formats.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Format is an output format selected with the -output flag.
+type Format string
+
+// Supported output formats.
+const (
+	Text Format = "text"
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// ParseFormat converts a format name into a Format.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(name); f {
+	case Text, JSON, YAML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (want text, json or yaml)", name)
+	}
+}
+
+// Encode writes v to w as a single JSON line or a YAML document. Field names
+// and omitempty handling come from the json struct tags of v in both
+// formats. Text is not a structured format and is rejected.
+func Encode(w io.Writer, format Format, v interface{}) error {
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return enc.Encode(v)
+	case YAML:
+		var b strings.Builder
+		writeYAML(&b, reflect.ValueOf(v), 0, false)
+		_, err := io.WriteString(w, b.String())
+		return err
+	default:
+		return fmt.Errorf("format %q is not a structured format", format)
+	}
+}
+
+// Float returns a value that encodes as a number when f is finite and as
+// the string "NaN", "+Inf" or "-Inf" otherwise, since neither JSON nor
+// the encoders here accept non-finite numbers.
+func Float(f float64) interface{} {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return f
+}
+
+// writeYAML appends the YAML encoding of v at the given indentation level.
+// Scalars are written inline; the caller has already written any key. When
+// inline is set the first line of a composite follows a "- " the caller has
+// already written, so it is not indented again.
+func writeYAML(b *strings.Builder, v reflect.Value, indent int, inline bool) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			b.WriteString("null\n")
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := structFields(v)
+		if len(fields) == 0 {
+			b.WriteString("{}\n")
+			return
+		}
+		for i, f := range fields {
+			if i > 0 || !inline {
+				b.WriteString(strings.Repeat("  ", indent))
+			}
+			writeKeyValue(b, f.name, f.value, indent)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		if len(keys) == 0 {
+			b.WriteString("{}\n")
+			return
+		}
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = fmt.Sprint(k.Interface())
+		}
+		sortStrings(names, keys)
+		for i, k := range keys {
+			if i > 0 || !inline {
+				b.WriteString(strings.Repeat("  ", indent))
+			}
+			writeKeyValue(b, names[i], v.MapIndex(k), indent)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			b.WriteString("[]\n")
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 || !inline {
+				b.WriteString(strings.Repeat("  ", indent))
+			}
+			b.WriteString("- ")
+			elem := v.Index(i)
+			if isComposite(elem) {
+				// Nested mappings start on the same line as the dash.
+				writeYAML(b, elem, indent+1, true)
+			} else {
+				writeScalar(b, elem)
+			}
+		}
+	default:
+		writeScalar(b, v)
+	}
+}
+
+// writeKeyValue writes "key: value", placing composite values on the
+// following lines.
+func writeKeyValue(b *strings.Builder, key string, value reflect.Value, indent int) {
+	b.WriteString(yamlString(key))
+	b.WriteString(":")
+	if isComposite(value) && !isEmptyComposite(value) {
+		b.WriteString("\n")
+		writeYAML(b, value, indent+1, false)
+		return
+	}
+	b.WriteString(" ")
+	writeYAML(b, value, indent+1, true)
+}
+
+// writeScalar writes a single scalar value followed by a newline.
+func writeScalar(b *strings.Builder, v reflect.Value) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			b.WriteString("null\n")
+			return
+		}
+		v = v.Elem()
+	}
+
+	if n, ok := v.Interface().(json.Number); ok {
+		b.WriteString(n.String())
+		b.WriteString("\n")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		b.WriteString(yamlString(v.String()))
+	case reflect.Bool:
+		b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		b.WriteString(yamlFloat(v.Float()))
+	default:
+		b.WriteString(yamlString(fmt.Sprint(v.Interface())))
+	}
+	b.WriteString("\n")
+}
+
+// yamlFloat formats a float the way YAML 1.2 spells it.
+func yamlFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return ".nan"
+	case math.IsInf(f, 1):
+		return ".inf"
+	case math.IsInf(f, -1):
+		return "-.inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
+
+// yamlString quotes a string unless it is a plain word that YAML would
+// read back as the same string.
+func yamlString(s string) string {
+	if s == "" || !isPlain(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// isPlain reports whether s can be written unquoted without being read back
+// as a number, boolean, null or YAML syntax.
+func isPlain(s string) bool {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return false
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return false
+	}
+	for i, c := range s {
+		isLetter := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+		isDigit := c >= '0' && c <= '9'
+		if isLetter || c == '_' || (i > 0 && (isDigit || c == '-' || c == '.' || c == '/')) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// yamlField is a struct field selected for encoding.
+type yamlField struct {
+	name  string
+	value reflect.Value
+}
+
+// structFields returns the exported fields of v named by their json tags,
+// skipping fields tagged "-" and empty fields tagged omitempty.
+func structFields(v reflect.Value) []yamlField {
+	var fields []yamlField
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		name := sf.Name
+		omitEmpty := false
+		if tag, ok := sf.Tag.Lookup("json"); ok {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, opt := range parts[1:] {
+				if opt == "omitempty" {
+					omitEmpty = true
+				}
+			}
+		}
+		fv := v.Field(i)
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+		fields = append(fields, yamlField{name: name, value: fv})
+	}
+	return fields
+}
+
+// isComposite reports whether v encodes as a YAML mapping or sequence.
+func isComposite(v reflect.Value) bool {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	if _, ok := v.Interface().(json.Number); ok {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// isEmptyComposite reports whether v is a composite that encodes inline as
+// [] or {}.
+func isEmptyComposite(v reflect.Value) bool {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return v.Len() == 0
+	case reflect.Struct:
+		return len(structFields(v)) == 0
+	}
+	return false
+}
+
+// sortStrings sorts names and reorders keys to match, so maps are written
+// in a stable order.
+func sortStrings(names []string, keys []reflect.Value) {
+	for i := 1; i < len(names); i++ {
+		for j := i; j > 0 && names[j] < names[j-1]; j-- {
+			names[j], names[j-1] = names[j-1], names[j]
+			keys[j], keys[j-1] = keys[j-1], keys[j]
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+type sample struct {
+	Op       string        `json:"op"`
+	Operands []interface{} `json:"operands,omitempty"`
+	Note     string        `json:"note,omitempty"`
+	Hidden   string        `json:"-"`
+	Nested   *sample       `json:"nested,omitempty"`
+	Items    []sample      `json:"items,omitempty"`
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Format
+		wantErr  bool
+	}{
+		{"text", Text, false},
+		{"json", JSON, false},
+		{"yaml", YAML, false},
+		{"xml", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := ParseFormat(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if format != tt.expected {
+				t.Errorf("ParseFormat(%q) = %q, want %q", tt.name, format, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   Format
+		value    interface{}
+		expected string
+	}{
+		{
+			name:     "json",
+			format:   JSON,
+			value:    sample{Op: "add", Operands: []interface{}{5.0, 3.0}, Hidden: "x"},
+			expected: `{"op":"add","operands":[5,3]}` + "\n",
+		},
+		{
+			name:     "json_no_html_escaping",
+			format:   JSON,
+			value:    sample{Op: "<a>"},
+			expected: `{"op":"<a>"}` + "\n",
+		},
+		{
+			name:     "yaml_scalars",
+			format:   YAML,
+			value:    sample{Op: "add", Operands: []interface{}{5.0, "1/3", json.Number("0.25")}, Note: "8.00"},
+			expected: "op: add\noperands:\n  - 5\n  - \"1/3\"\n  - 0.25\nnote: \"8.00\"\n",
+		},
+		{
+			name:     "yaml_nested",
+			format:   YAML,
+			value:    sample{Op: "a", Nested: &sample{Op: "b"}, Items: []sample{{Op: "c", Note: "d"}}},
+			expected: "op: a\nnested:\n  op: b\nitems:\n  - op: c\n    note: d\n",
+		},
+		{
+			name:     "yaml_quoting",
+			format:   YAML,
+			value:    map[string]interface{}{"b": "true", "a": "", "c": "x: y", "d": nil},
+			expected: "a: \"\"\nb: \"true\"\nc: \"x: y\"\nd: null\n",
+		},
+		{
+			name:     "yaml_special_floats",
+			format:   YAML,
+			value:    []float64{math.Inf(1), math.Inf(-1), math.NaN(), 1.5},
+			expected: "- .inf\n- -.inf\n- .nan\n- 1.5\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, tt.format, tt.value); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Encode() =\n%s\nwant\n%s", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestEncodeText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, Text, sample{}); err == nil {
+		t.Error("Encode() with Text should return an error")
+	}
+}
+
+func TestFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		expected interface{}
+	}{
+		{"finite", 2.5, 2.5},
+		{"positive_infinity", math.Inf(1), "+Inf"},
+		{"negative_infinity", math.Inf(-1), "-Inf"},
+		{"nan", math.NaN(), "NaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Float(tt.value); got != tt.expected {
+				t.Errorf("Float(%v) = %v, want %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// AngleUnit is a unit for measuring angles. The trigonometric methods of an
+// AngleUnit take and return angles in that unit. Outside radians, angles
+// that are whole multiples of 30° or 45° give exact results, so
+// Degrees.Sin(180) is 0 rather than 1.2e-16 and Degrees.Tan(90) is an
+// error rather than 1.6e16.
+type AngleUnit int
+
+const (
+	// Radians measure a full turn as 2π.
+	Radians AngleUnit = iota
+	// Degrees measure a full turn as 360.
+	Degrees
+	// Gradians measure a full turn as 400.
+	Gradians
+	// Turns measure a full turn as 1.
+	Turns
+)
+
+// angleUnitNames maps each AngleUnit to its name.
+var angleUnitNames = []string{"rad", "deg", "grad", "turn"}
+
+// halfTurns holds the size of half a turn in each AngleUnit.
+var halfTurns = []float64{math.Pi, 180, 200, 0.5}
+
+// String returns the short name of the unit, e.g. "deg".
+func (u AngleUnit) String() string {
+	if u < 0 || int(u) >= len(angleUnitNames) {
+		return fmt.Sprintf("AngleUnit(%d)", int(u))
+	}
+	return angleUnitNames[u]
+}
+
+// ParseAngleUnit converts a unit name (rad, deg, grad or turn) into an
+// AngleUnit.
+func ParseAngleUnit(name string) (AngleUnit, error) {
+	for i, n := range angleUnitNames {
+		if n == name {
+			return AngleUnit(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown angle unit %q (want %s)", name, strings.Join(angleUnitNames, ", "))
+}
+
+// ToRadians converts an angle in u to radians.
+func (u AngleUnit) ToRadians(a float64) float64 {
+	if u == Radians {
+		return a
+	}
+	return a * math.Pi / halfTurns[u]
+}
+
+// FromRadians converts an angle in radians to u.
+func (u AngleUnit) FromRadians(a float64) float64 {
+	if u == Radians {
+		return a
+	}
+	return a * halfTurns[u] / math.Pi
+}
+
+// exactSines holds sin(15°·k) for k = 0..6 where it is 0, 1/2, √2/2, √3/2
+// or 1, and NaN for 15° and 75°, which have no simple form.
+var exactSines = [7]float64{0, math.NaN(), 0.5, math.Sqrt2 / 2, math.Sqrt(3) / 2, math.NaN(), 1}
+
+// reduce returns a in [0, full turn) and, if the result is a whole multiple
+// of 15°, that multiple. Radians are never reduced, since no float64 is an
+// exact multiple of π.
+func (u AngleUnit) reduce(a float64) (r float64, k int, exact bool) {
+	if u == Radians {
+		return a, 0, false
+	}
+	full := 2 * halfTurns[u]
+	r = math.Mod(a, full)
+	if r < 0 {
+		r += full
+	}
+	m := r * 24 / full
+	if m != math.Trunc(m) {
+		return r, 0, false
+	}
+	return r, int(m) % 24, true
+}
+
+// exactSin returns sin(15°·k) if it has a simple exact value.
+func exactSin(k int) (float64, bool) {
+	sign := 1.0
+	if k >= 12 {
+		k -= 12
+		sign = -1
+	}
+	if k > 6 {
+		k = 12 - k
+	}
+	s := exactSines[k]
+	if math.IsNaN(s) {
+		return 0, false
+	}
+	// Adding zero turns -0 into 0
+	return sign*s + 0, true
+}
+
+// Sin returns the sine of an angle in u.
+func (u AngleUnit) Sin(a float64) float64 {
+	r, k, exact := u.reduce(a)
+	if exact {
+		if s, ok := exactSin(k); ok {
+			return s
+		}
+	}
+	return math.Sin(u.ToRadians(r))
+}
+
+// Cos returns the cosine of an angle in u.
+func (u AngleUnit) Cos(a float64) float64 {
+	r, k, exact := u.reduce(a)
+	if exact {
+		// cos(x) = sin(x + 90°)
+		if c, ok := exactSin((k + 6) % 24); ok {
+			return c
+		}
+	}
+	return math.Cos(u.ToRadians(r))
+}
+
+// Tan returns the tangent of an angle in u.
+// Returns an error at odd multiples of a quarter turn, where the tangent is
+// undefined, or if the angle is NaN or infinite. In radians the check
+// allows for rounding, as for Tan.
+func (u AngleUnit) Tan(a float64) (float64, error) {
+	if u == Radians {
+		return Tan(a)
+	}
+	if !isFinite(a) {
+		return 0, domainError("tan", ErrNonFinite, a)
+	}
+	r, k, exact := u.reduce(a)
+	if exact {
+		s, sinOK := exactSin(k)
+		c, cosOK := exactSin((k + 6) % 24)
+		if sinOK && cosOK {
+			if c == 0 {
+				return 0, domainError("tan", ErrUndefined, a)
+			}
+			if math.Abs(s) == math.Abs(c) {
+				// ±1 exactly, which s/c may miss by rounding
+				return math.Copysign(1, s*c), nil
+			}
+			return s/c + 0, nil
+		}
+	}
+	return math.Tan(u.ToRadians(r)), nil
+}
+
+// exactAngle returns the angle in u corresponding to k·15°.
+func (u AngleUnit) exactAngle(k int) float64 {
+	return float64(k) * halfTurns[u] / 12
+}
+
+// Asin returns the arcsine of x in u, between -90° and 90°.
+// Returns an error if x is outside [-1, 1] or is NaN.
+func (u AngleUnit) Asin(x float64) (float64, error) {
+	if math.IsNaN(x) {
+		return 0, domainError("asin", ErrNonFinite, x)
+	}
+	if x < -1 || x > 1 {
+		return 0, domainError("asin", ErrUndefined, x)
+	}
+	if u != Radians {
+		for k, s := range exactSines {
+			if math.Abs(x) == s {
+				return math.Copysign(u.exactAngle(k), x) + 0, nil
+			}
+		}
+	}
+	return u.FromRadians(math.Asin(x)), nil
+}
+
+// Acos returns the arccosine of x in u, between 0° and 180°.
+// Returns an error if x is outside [-1, 1] or is NaN.
+func (u AngleUnit) Acos(x float64) (float64, error) {
+	if math.IsNaN(x) {
+		return 0, domainError("acos", ErrNonFinite, x)
+	}
+	if x < -1 || x > 1 {
+		return 0, domainError("acos", ErrUndefined, x)
+	}
+	if u != Radians {
+		// acos(x) = 90° - asin(x)
+		for k, s := range exactSines {
+			if math.Abs(x) == s {
+				if x < 0 {
+					return u.exactAngle(6 + k), nil
+				}
+				return u.exactAngle(6 - k), nil
+			}
+		}
+	}
+	return u.FromRadians(math.Acos(x)), nil
+}
+
+// Atan returns the arctangent of x in u, between -90° and 90°.
+func (u AngleUnit) Atan(x float64) float64 {
+	if u != Radians {
+		for k := 0; k < 6; k++ {
+			if math.IsNaN(exactSines[k]) {
+				continue
+			}
+			if t, _ := u.Tan(u.exactAngle(k)); math.Abs(x) == t {
+				return math.Copysign(u.exactAngle(k), x) + 0
+			}
+		}
+		if math.IsInf(x, 0) {
+			return math.Copysign(u.exactAngle(6), x)
+		}
+	}
+	return u.FromRadians(math.Atan(x))
+}
+
+// Atan2 returns the angle in u of the point (x, y) from the positive x axis,
+// between -180° and 180°, following the conventions of math.Atan2.
+func (u AngleUnit) Atan2(y, x float64) float64 {
+	if u != Radians && isFinite(x, y) && (x != 0 || y != 0) {
+		switch {
+		case y == 0 && x > 0:
+			return math.Copysign(0, y)
+		case y == 0:
+			return math.Copysign(u.exactAngle(12), y)
+		case x == 0:
+			return math.Copysign(u.exactAngle(6), y)
+		case math.Abs(x) == math.Abs(y) && x > 0:
+			return math.Copysign(u.exactAngle(3), y)
+		case math.Abs(x) == math.Abs(y):
+			return math.Copysign(u.exactAngle(9), y)
+		}
+	}
+	return u.FromRadians(math.Atan2(y, x))
+}
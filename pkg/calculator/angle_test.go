@@ -0,0 +1,231 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseAngleUnit(t *testing.T) {
+	for _, u := range []AngleUnit{Radians, Degrees, Gradians, Turns} {
+		got, err := ParseAngleUnit(u.String())
+		if err != nil || got != u {
+			t.Errorf("ParseAngleUnit(%q) = %v, %v, want %v", u.String(), got, err, u)
+		}
+	}
+	if _, err := ParseAngleUnit("degrees"); err == nil {
+		t.Errorf("ParseAngleUnit(%q) expected error", "degrees")
+	}
+}
+
+func TestAngleConversion(t *testing.T) {
+	tests := []struct {
+		unit  AngleUnit
+		angle float64
+	}{
+		{Radians, math.Pi},
+		{Degrees, 180},
+		{Gradians, 200},
+		{Turns, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit.String(), func(t *testing.T) {
+			if got := tt.unit.ToRadians(tt.angle); math.Abs(got-math.Pi) > 1e-15 {
+				t.Errorf("ToRadians(%v) = %v, want π", tt.angle, got)
+			}
+			if got := tt.unit.FromRadians(math.Pi); math.Abs(got-tt.angle) > 1e-12 {
+				t.Errorf("FromRadians(π) = %v, want %v", got, tt.angle)
+			}
+		})
+	}
+}
+
+func TestAngleSinCos(t *testing.T) {
+	tests := []struct {
+		name     string
+		unit     AngleUnit
+		angle    float64
+		sin, cos float64
+	}{
+		{"zero", Degrees, 0, 0, 1},
+		{"30", Degrees, 30, 0.5, math.Sqrt(3) / 2},
+		{"45", Degrees, 45, math.Sqrt2 / 2, math.Sqrt2 / 2},
+		{"90", Degrees, 90, 1, 0},
+		{"180", Degrees, 180, 0, -1},
+		{"270", Degrees, 270, -1, 0},
+		{"negative", Degrees, -30, -0.5, math.Sqrt(3) / 2},
+		{"many_turns", Degrees, 360*1000 + 150, 0.5, -math.Sqrt(3) / 2},
+		{"gradians", Gradians, 100, 1, 0},
+		{"turns", Turns, 0.5, 0, -1},
+		{"turns_twelfth", Turns, 1.0 / 12, 0.5, math.Sqrt(3) / 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Special angles must be exact, not merely close
+			if got := tt.unit.Sin(tt.angle); got != tt.sin {
+				t.Errorf("%v.Sin(%v) = %v, want %v", tt.unit, tt.angle, got, tt.sin)
+			}
+			if got := tt.unit.Cos(tt.angle); got != tt.cos {
+				t.Errorf("%v.Cos(%v) = %v, want %v", tt.unit, tt.angle, got, tt.cos)
+			}
+		})
+	}
+}
+
+func TestAngleSinCosGeneral(t *testing.T) {
+	tests := []struct {
+		unit  AngleUnit
+		angle float64
+	}{
+		{Radians, 1},
+		{Degrees, 57.29577951308232},
+		{Gradians, 63.66197723675813},
+		{Turns, 0.15915494309189535},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit.String(), func(t *testing.T) {
+			if got := tt.unit.Sin(tt.angle); math.Abs(got-math.Sin(1)) > 1e-12 {
+				t.Errorf("Sin(%v) = %v, want %v", tt.angle, got, math.Sin(1))
+			}
+			if got := tt.unit.Cos(tt.angle); math.Abs(got-math.Cos(1)) > 1e-12 {
+				t.Errorf("Cos(%v) = %v, want %v", tt.angle, got, math.Cos(1))
+			}
+		})
+	}
+}
+
+func TestAngleTan(t *testing.T) {
+	tests := []struct {
+		name        string
+		unit        AngleUnit
+		angle       float64
+		expected    float64
+		expectError error
+	}{
+		{"zero", Degrees, 0, 0, nil},
+		{"45", Degrees, 45, 1, nil},
+		{"135", Degrees, 135, -1, nil},
+		{"60", Degrees, 60, math.Sqrt(3), nil},
+		{"180", Degrees, 180, 0, nil},
+		{"general", Degrees, 10, math.Tan(math.Pi / 18), nil},
+		{"90", Degrees, 90, 0, ErrUndefined},
+		{"-270", Degrees, -270, 0, ErrUndefined},
+		{"large_odd_multiple", Degrees, 90 + 180*1001, 0, ErrUndefined},
+		{"near_90", Degrees, 89.9999, 572957.7951308232, nil},
+		{"gradians", Gradians, 300, 0, ErrUndefined},
+		{"turns", Turns, 0.25, 0, ErrUndefined},
+		{"radians", Radians, math.Pi / 2, 0, ErrUndefined},
+		{"infinite", Degrees, math.Inf(1), 0, ErrNonFinite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.unit.Tan(tt.angle)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("%v.Tan(%v) error = %v, want %v", tt.unit, tt.angle, err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && math.Abs(got-tt.expected) > 1e-9*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("%v.Tan(%v) = %v, want %v", tt.unit, tt.angle, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAngleInverse(t *testing.T) {
+	asin := func(u AngleUnit, x float64) (float64, error) { return u.Asin(x) }
+	acos := func(u AngleUnit, x float64) (float64, error) { return u.Acos(x) }
+	atan := func(u AngleUnit, x float64) (float64, error) { return u.Atan(x), nil }
+
+	tests := []struct {
+		name        string
+		fn          func(AngleUnit, float64) (float64, error)
+		unit        AngleUnit
+		x           float64
+		expected    float64
+		expectError error
+	}{
+		{"asin_half", asin, Degrees, 0.5, 30, nil},
+		{"asin_negative", asin, Degrees, -math.Sqrt(3) / 2, -60, nil},
+		{"asin_one", asin, Gradians, 1, 100, nil},
+		{"asin_radians", asin, Radians, 1, math.Pi / 2, nil},
+		{"asin_general", asin, Degrees, 0.3, 17.457603123722095, nil},
+		{"asin_out_of_range", asin, Degrees, 1.5, 0, ErrUndefined},
+		{"asin_nan", asin, Degrees, math.NaN(), 0, ErrNonFinite},
+		{"acos_half", acos, Degrees, 0.5, 60, nil},
+		{"acos_negative", acos, Degrees, -math.Sqrt2 / 2, 135, nil},
+		{"acos_minus_one", acos, Turns, -1, 0.5, nil},
+		{"acos_out_of_range", acos, Degrees, -2, 0, ErrUndefined},
+		{"atan_one", atan, Degrees, 1, 45, nil},
+		{"atan_sqrt3", atan, Degrees, -math.Sqrt(3), -60, nil},
+		{"atan_infinity", atan, Degrees, math.Inf(1), 90, nil},
+		{"atan_general", atan, Degrees, 0.5, 26.56505117707799, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.unit, tt.x)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("error = %v, want %v", err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && math.Abs(got-tt.expected) > 1e-12*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAngleInverseIsExact(t *testing.T) {
+	// Inverting the exact values of special angles must give the angle back
+	for k := 0; k <= 12; k++ {
+		angle := float64(k) * 15
+		if k%2 != 0 && k%3 != 0 {
+			continue
+		}
+		if got, _ := Degrees.Acos(Degrees.Cos(angle)); got != angle {
+			t.Errorf("Acos(Cos(%v)) = %v", angle, got)
+		}
+		if k <= 6 {
+			if got, _ := Degrees.Asin(Degrees.Sin(angle)); got != angle {
+				t.Errorf("Asin(Sin(%v)) = %v", angle, got)
+			}
+		}
+		if k < 6 {
+			tan, _ := Degrees.Tan(angle)
+			if got := Degrees.Atan(tan); got != angle {
+				t.Errorf("Atan(Tan(%v)) = %v", angle, got)
+			}
+		}
+	}
+}
+
+func TestAngleAtan2(t *testing.T) {
+	tests := []struct {
+		name     string
+		y, x     float64
+		expected float64
+	}{
+		{"positive_x", 0, 5, 0},
+		{"negative_x", 0, -5, 180},
+		{"positive_y", 2, 0, 90},
+		{"negative_y", -2, 0, -90},
+		{"first_diagonal", 3, 3, 45},
+		{"third_diagonal", -3, -3, -135},
+		{"second_diagonal", 3, -3, 135},
+		{"general", 1, 2, 26.56505117707799},
+		{"origin", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Degrees.Atan2(tt.y, tt.x)
+			if math.Abs(got-tt.expected) > 1e-12 {
+				t.Errorf("Degrees.Atan2(%v, %v) = %v, want %v", tt.y, tt.x, got, tt.expected)
+			}
+		})
+	}
+}
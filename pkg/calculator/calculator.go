@@ -3,11 +3,29 @@ package calculator
 
 import (
 	"crypto/rand"
-	"errors"
 	"math"
 	"math/big"
 )
 
+// tanPoleTolerance is how close cos(a) may come to zero before Tan reports
+// the tangent as undefined. It absorbs the rounding in float64
+// approximations of π/2 + nπ.
+const tanPoleTolerance = 1e-12
+
+// tanPoleMaxWindow caps the pole window of large arguments, whose float64
+// spacing is so coarse that a window of a few ulps would cover most of the
+// period and make every argument a pole.
+const tanPoleMaxWindow = 1e-6
+
+// nearTanPole reports whether c, the cosine of an argument of magnitude
+// mag, is close enough to zero for the argument to be taken as π/2 + nπ.
+// The window is tanPoleTolerance or two ulps of mag, whichever is larger,
+// and at most tanPoleMaxWindow.
+func nearTanPole(c, mag float64) bool {
+	ulp := math.Nextafter(mag, math.Inf(1)) - mag
+	return math.Abs(c) <= math.Min(math.Max(tanPoleTolerance, 2*ulp), tanPoleMaxWindow)
+}
+
 // Add returns the sum of two numbers.
 func Add(a, b float64) float64 {
 	return a + b
@@ -23,25 +41,65 @@ func Multiply(a, b float64) float64 {
 	return a * b
 }
 
+// AddChecked returns the sum of two numbers, like Add.
+// Returns an error if the result overflows or an input is NaN or infinite.
+func AddChecked(a, b float64) (float64, error) {
+	return checkFinite("add", a+b, a, b)
+}
+
+// SubtractChecked returns the difference between two numbers, like Subtract.
+// Returns an error if the result overflows or an input is NaN or infinite.
+func SubtractChecked(a, b float64) (float64, error) {
+	return checkFinite("subtract", a-b, a, b)
+}
+
+// MultiplyChecked returns the product of two numbers, like Multiply.
+// Returns an error if the result overflows or an input is NaN or infinite.
+func MultiplyChecked(a, b float64) (float64, error) {
+	return checkFinite("multiply", a*b, a, b)
+}
+
 // Divide returns the quotient of two numbers.
-// Returns an error if the divisor is zero.
+// Returns an error if the divisor is zero or an input is NaN or infinite.
 func Divide(a, b float64) (float64, error) {
+	if !isFinite(a, b) {
+		return 0, domainError("divide", ErrNonFinite, a, b)
+	}
 	if b == 0 {
-		return 0, errors.New("division by zero")
+		return 0, domainError("divide", ErrDivisionByZero, a, b)
 	}
 	return a / b, nil
 }
 
 // Power returns the result of raising a to the power of b.
-func Power(a, b float64) float64 {
-	return math.Pow(a, b)
+// Returns an error if a is zero and b is negative, if a is negative and b
+// is not an integer, if the result overflows, or if an input is NaN or
+// infinite.
+func Power(a, b float64) (float64, error) {
+	if !isFinite(a, b) {
+		return 0, domainError("power", ErrNonFinite, a, b)
+	}
+	if a == 0 && b < 0 {
+		return 0, domainError("power", ErrDivisionByZero, a, b)
+	}
+	if a < 0 && b != math.Trunc(b) {
+		return 0, domainError("power", ErrNegativeFractionalPower, a, b)
+	}
+	result := math.Pow(a, b)
+	if math.IsInf(result, 0) {
+		return 0, domainError("power", ErrOverflow, a, b)
+	}
+	return result, nil
 }
 
 // SquareRoot returns the square root of a number.
-// Returns an error if the number is negative.
+// Returns an error if the number is negative, NaN or infinite.
 func SquareRoot(a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError("sqrt", ErrNonFinite, a)
+	}
 	if a < 0 {
-		return 0, errors.New("square root of negative number")
+		return 0, domainError("sqrt", ErrNegativeSquareRoot, a)
 	}
 	return math.Sqrt(a), nil
 }
@@ -57,10 +115,16 @@ func Cos(a float64) float64 {
 }
 
 // Tan returns the tangent of an angle (in radians).
-// Note: Returns ±Inf when the angle is close to ±π/2 + nπ
-// (where the tangent is undefined).
-func Tan(a float64) float64 {
-	return math.Tan(a)
+// Returns an error when the angle is within rounding distance of π/2 + nπ,
+// where the tangent is undefined, or if the angle is NaN or infinite.
+func Tan(a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError("tan", ErrNonFinite, a)
+	}
+	if nearTanPole(math.Cos(a), math.Abs(a)) {
+		return 0, domainError("tan", ErrUndefined, a)
+	}
+	return math.Tan(a), nil
 }
 
 // Random returns a random number between min and max.
@@ -88,3 +152,25 @@ func Random(min, max float64) float64 {
 	// Scale to our desired range and shift
 	return min + f*range_size
 }
+
+// checkFinite returns the result of op applied to operands, or an error if
+// an operand is NaN or infinite or the result is not finite.
+func checkFinite(op string, result float64, operands ...float64) (float64, error) {
+	if !isFinite(operands...) {
+		return 0, domainError(op, ErrNonFinite, floatOperands(operands)...)
+	}
+	if !isFinite(result) {
+		return 0, domainError(op, ErrOverflow, floatOperands(operands)...)
+	}
+	return result, nil
+}
+
+// isFinite reports whether none of the values is NaN or infinite.
+func isFinite(values ...float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
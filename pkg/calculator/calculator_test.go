@@ -1,6 +1,8 @@
 package calculator
 
 import (
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -67,6 +69,94 @@ func TestMultiply(t *testing.T) {
 	}
 }
 
+func TestAddChecked(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        float64
+		expected    float64
+		expectError bool
+	}{
+		{"positive", 2, 3, 5, false},
+		{"negative", -2, -3, -5, false},
+		{"mixed", -2, 3, 1, false},
+		{"zero", 0, 0, 0, false},
+		{"nan", math.NaN(), 1, 0, true},
+		{"infinite", 1, math.Inf(1), 0, true},
+		{"overflow", math.MaxFloat64, math.MaxFloat64, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddChecked(tt.a, tt.b)
+			if (err != nil) != tt.expectError {
+				t.Errorf("AddChecked(%v, %v) error = %v, expectError %v", tt.a, tt.b, err, tt.expectError)
+				return
+			}
+			if !tt.expectError && got != tt.expected {
+				t.Errorf("AddChecked(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubtractChecked(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        float64
+		expected    float64
+		expectError bool
+	}{
+		{"positive", 5, 3, 2, false},
+		{"negative", -5, -3, -2, false},
+		{"mixed", -5, 3, -8, false},
+		{"zero", 0, 0, 0, false},
+		{"nan", 1, math.NaN(), 0, true},
+		{"overflow", -math.MaxFloat64, math.MaxFloat64, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SubtractChecked(tt.a, tt.b)
+			if (err != nil) != tt.expectError {
+				t.Errorf("SubtractChecked(%v, %v) error = %v, expectError %v", tt.a, tt.b, err, tt.expectError)
+				return
+			}
+			if !tt.expectError && got != tt.expected {
+				t.Errorf("SubtractChecked(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMultiplyChecked(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        float64
+		expected    float64
+		expectError bool
+	}{
+		{"positive", 2, 3, 6, false},
+		{"negative", -2, -3, 6, false},
+		{"mixed", -2, 3, -6, false},
+		{"zero", 0, 5, 0, false},
+		{"infinite_times_zero", math.Inf(1), 0, 0, true},
+		{"overflow", 1e308, 10, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MultiplyChecked(tt.a, tt.b)
+			if (err != nil) != tt.expectError {
+				t.Errorf("MultiplyChecked(%v, %v) error = %v, expectError %v", tt.a, tt.b, err, tt.expectError)
+				return
+			}
+			if !tt.expectError && got != tt.expected {
+				t.Errorf("MultiplyChecked(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestDivide(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -79,6 +169,8 @@ func TestDivide(t *testing.T) {
 		{"mixed", -6, 3, -2, false},
 		{"zero_dividend", 0, 5, 0, false},
 		{"zero_divisor", 5, 0, 0, true},
+		{"nan_dividend", math.NaN(), 1, 0, true},
+		{"infinite_divisor", 1, math.Inf(-1), 0, true},
 	}
 
 	for _, tt := range tests {
@@ -97,22 +189,33 @@ func TestDivide(t *testing.T) {
 
 func TestPower(t *testing.T) {
 	tests := []struct {
-		name     string
-		a, b     float64
-		expected float64
+		name        string
+		a, b        float64
+		expected    float64
+		expectError error
 	}{
-		{"positive_base_positive_exponent", 2, 3, 8},
-		{"positive_base_negative_exponent", 2, -1, 0.5},
-		{"negative_base_even_exponent", -2, 2, 4},
-		{"negative_base_odd_exponent", -2, 3, -8},
-		{"zero_base", 0, 5, 0},
-		{"any_base_zero_exponent", 5, 0, 1},
-		{"one_base_any_exponent", 1, 99, 1},
+		{"positive_base_positive_exponent", 2, 3, 8, nil},
+		{"positive_base_negative_exponent", 2, -1, 0.5, nil},
+		{"negative_base_even_exponent", -2, 2, 4, nil},
+		{"negative_base_odd_exponent", -2, 3, -8, nil},
+		{"zero_base", 0, 5, 0, nil},
+		{"any_base_zero_exponent", 5, 0, 1, nil},
+		{"one_base_any_exponent", 1, 99, 1, nil},
+		{"zero_base_negative_exponent", 0, -1, 0, ErrDivisionByZero},
+		{"negative_base_fractional_exponent", -8, 0.5, 0, ErrNegativeFractionalPower},
+		{"overflow", 10, 400, 0, ErrOverflow},
+		{"nan_base", math.NaN(), 2, 0, ErrNonFinite},
+		{"infinite_exponent", 2, math.Inf(1), 0, ErrNonFinite},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := Power(tt.a, tt.b); got != tt.expected {
+			got, err := Power(tt.a, tt.b)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("Power(%v, %v) error = %v, want %v", tt.a, tt.b, err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && got != tt.expected {
 				t.Errorf("Power(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.expected)
 			}
 		})
@@ -131,6 +234,7 @@ func TestSquareRoot(t *testing.T) {
 		{"negative", -4, 0, true},
 		{"perfect_square", 16, 4, false},
 		{"non_perfect_square", 2, 1.4142135623730951, false},
+		{"infinite", math.Inf(1), 0, true},
 	}
 
 	for _, tt := range tests {
@@ -199,23 +303,36 @@ func TestCos(t *testing.T) {
 
 func TestTan(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    float64
-		expected float64
+		name        string
+		input       float64
+		expected    float64
+		expectError error
 	}{
-		{"zero", 0, 0},
-		{"pi/4", 0.7853981633974483, 1},
-		{"pi/3", 1.0471975511965976, 1.7320508075688767}, // √3
-		{"pi", 3.141592653589793, 0},
-		{"-pi/4", -0.7853981633974483, -1},
+		{"zero", 0, 0, nil},
+		{"pi/4", 0.7853981633974483, 1, nil},
+		{"pi/3", 1.0471975511965976, 1.7320508075688767, nil}, // √3
+		{"pi", 3.141592653589793, 0, nil},
+		{"-pi/4", -0.7853981633974483, -1, nil},
+		{"pi/2", math.Pi / 2, 0, ErrUndefined},
+		{"-3pi/2", -3 * math.Pi / 2, 0, ErrUndefined},
+		{"large_odd_multiple_of_pi/2", 1001 * math.Pi / 2, 0, ErrUndefined},
+		{"near_but_not_at_pi/2", 1.57, 1255.7655915007897, nil},
+		{"large_finite", 2e12, -3.827621974984473, nil},
+		{"large_negative_finite", -2e12, 3.827621974984473, nil},
+		{"huge_finite", 1e300, 1.4214488238747243, nil},
+		{"nan", math.NaN(), 0, ErrNonFinite},
 	}
 
 	const epsilon = 1e-9
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := Tan(tt.input)
-			if diff := got - tt.expected; diff < -epsilon || diff > epsilon {
+			got, err := Tan(tt.input)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("Tan(%v) error = %v, want %v", tt.input, err, tt.expectError)
+				return
+			}
+			if diff := got - tt.expected; tt.expectError == nil && (diff < -epsilon || diff > epsilon) {
 				t.Errorf("Tan(%v) = %v, want %v", tt.input, got, tt.expected)
 			}
 		})
@@ -0,0 +1,193 @@
+package calculator
+
+import (
+	"fmt"
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+// ParseComplex parses a complex literal such as "3+4i", "-2.5i", "i" or "7".
+// A bare "i" coefficient is read as 1, so "3-i" is 3-1i.
+func ParseComplex(s string) (complex128, error) {
+	literal := strings.TrimSpace(s)
+	if strings.HasSuffix(literal, "i") {
+		// strconv requires an explicit coefficient before the imaginary unit
+		body := strings.TrimSuffix(literal, "i")
+		if body == "" || strings.HasSuffix(body, "+") || strings.HasSuffix(body, "-") {
+			literal = body + "1i"
+		}
+	}
+
+	c, err := strconv.ParseComplex(literal, 128)
+	if err != nil {
+		return 0, fmt.Errorf("invalid complex number %q", s)
+	}
+	return c, nil
+}
+
+// AddComplex returns the sum of two complex numbers.
+// Returns an error if the result overflows or an input is NaN or infinite.
+func AddComplex(a, b complex128) (complex128, error) {
+	return checkFiniteComplex("add", a+b, a, b)
+}
+
+// SubtractComplex returns the difference between two complex numbers.
+// Returns an error if the result overflows or an input is NaN or infinite.
+func SubtractComplex(a, b complex128) (complex128, error) {
+	return checkFiniteComplex("subtract", a-b, a, b)
+}
+
+// MultiplyComplex returns the product of two complex numbers.
+// Returns an error if the result overflows or an input is NaN or infinite.
+func MultiplyComplex(a, b complex128) (complex128, error) {
+	return checkFiniteComplex("multiply", a*b, a, b)
+}
+
+// DivideComplex returns the quotient of two complex numbers.
+// Returns an error if the divisor is zero, the result overflows or an input
+// is NaN or infinite.
+func DivideComplex(a, b complex128) (complex128, error) {
+	if !isFiniteComplex(a, b) {
+		return 0, domainError("divide", ErrNonFinite, a, b)
+	}
+	if b == 0 {
+		return 0, domainError("divide", ErrDivisionByZero, a, b)
+	}
+	return checkFiniteComplex("divide", a/b, a, b)
+}
+
+// PowerComplex returns a raised to the power of b, using the principal
+// branch of the logarithm.
+// Returns an error if a is zero and the real part of b is negative, the
+// result overflows or an input is NaN or infinite.
+func PowerComplex(a, b complex128) (complex128, error) {
+	if !isFiniteComplex(a, b) {
+		return 0, domainError("power", ErrNonFinite, a, b)
+	}
+	if a == 0 && real(b) < 0 {
+		return 0, domainError("power", ErrDivisionByZero, a, b)
+	}
+	return checkFiniteComplex("power", cmplx.Pow(a, b), a, b)
+}
+
+// SquareRootComplex returns the principal square root of a number. Unlike
+// SquareRoot it accepts negative input, so SquareRootComplex(-4) is 2i.
+// Returns an error if the input is NaN or infinite.
+func SquareRootComplex(a complex128) (complex128, error) {
+	return checkFiniteComplex("sqrt", cmplx.Sqrt(a), a)
+}
+
+// ExpComplex returns e raised to the power of a.
+// Returns an error if the result overflows or the input is NaN or infinite.
+func ExpComplex(a complex128) (complex128, error) {
+	return checkFiniteComplex("exp", cmplx.Exp(a), a)
+}
+
+// LogComplex returns the principal natural logarithm of a.
+// Returns an error if a is zero, NaN or infinite.
+func LogComplex(a complex128) (complex128, error) {
+	if !isFiniteComplex(a) {
+		return 0, domainError("log", ErrNonFinite, a)
+	}
+	if a == 0 {
+		return 0, domainError("log", ErrLogOfZero, a)
+	}
+	return cmplx.Log(a), nil
+}
+
+// LogBaseComplex returns the principal logarithm of a in the given base.
+// Returns an error if a is zero, the base is 0 or 1, or an input is NaN or
+// infinite.
+func LogBaseComplex(a, base complex128) (complex128, error) {
+	if !isFiniteComplex(a, base) {
+		return 0, domainError("log", ErrNonFinite, a, base)
+	}
+	if base == 0 || base == 1 {
+		return 0, domainError("log", ErrInvalidBase, a, base)
+	}
+	if a == 0 {
+		return 0, domainError("log", ErrLogOfZero, a, base)
+	}
+	return cmplx.Log(a) / cmplx.Log(base), nil
+}
+
+// SinComplex returns the sine of a.
+// Returns an error if the result overflows or the input is NaN or infinite.
+func SinComplex(a complex128) (complex128, error) {
+	return checkFiniteComplex("sin", cmplx.Sin(a), a)
+}
+
+// CosComplex returns the cosine of a.
+// Returns an error if the result overflows or the input is NaN or infinite.
+func CosComplex(a complex128) (complex128, error) {
+	return checkFiniteComplex("cos", cmplx.Cos(a), a)
+}
+
+// TanComplex returns the tangent of a.
+// Returns an error when a is within rounding distance of π/2 + nπ, where
+// the tangent is undefined as for Tan, or if a is NaN or infinite.
+func TanComplex(a complex128) (complex128, error) {
+	if !isFiniteComplex(a) {
+		return 0, domainError("tan", ErrNonFinite, a)
+	}
+	if nearTanPole(cmplx.Abs(cmplx.Cos(a)), cmplx.Abs(a)) {
+		return 0, domainError("tan", ErrUndefined, a)
+	}
+	return checkFiniteComplex("tan", cmplx.Tan(a), a)
+}
+
+// AbsComplex returns the modulus |a|.
+// Returns an error if a part of a is NaN or infinite or the modulus
+// overflows.
+func AbsComplex(a complex128) (float64, error) {
+	if !isFiniteComplex(a) {
+		return 0, domainError("abs", ErrNonFinite, a)
+	}
+	result := cmplx.Abs(a)
+	if !isFinite(result) {
+		return 0, domainError("abs", ErrOverflow, a)
+	}
+	return result, nil
+}
+
+// ArgComplex returns the argument (phase) of a in radians, in the range
+// [-π, π].
+// Returns an error if a part of a is NaN or infinite.
+func ArgComplex(a complex128) (float64, error) {
+	if !isFiniteComplex(a) {
+		return 0, domainError("arg", ErrNonFinite, a)
+	}
+	return cmplx.Phase(a), nil
+}
+
+// ConjComplex returns the complex conjugate of a.
+func ConjComplex(a complex128) complex128 {
+	return cmplx.Conj(a)
+}
+
+// checkFiniteComplex returns the result of op applied to operands, or an
+// error if an operand is NaN or infinite or the result is not finite.
+func checkFiniteComplex(op string, result complex128, operands ...complex128) (complex128, error) {
+	values := make([]interface{}, len(operands))
+	for i, v := range operands {
+		values[i] = v
+	}
+	if !isFiniteComplex(operands...) {
+		return 0, domainError(op, ErrNonFinite, values...)
+	}
+	if !isFiniteComplex(result) {
+		return 0, domainError(op, ErrOverflow, values...)
+	}
+	return result, nil
+}
+
+// isFiniteComplex reports whether no part of the values is NaN or infinite.
+func isFiniteComplex(values ...complex128) bool {
+	for _, v := range values {
+		if !isFinite(real(v), imag(v)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// complexClose reports whether two complex numbers agree to within 1e-12
+func complexClose(a, b complex128) bool {
+	return cmplx.Abs(a-b) < 1e-12
+}
+
+func TestParseComplex(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    complex128
+		expectError bool
+	}{
+		{"3+4i", complex(3, 4), false},
+		{"3-4.5i", complex(3, -4.5), false},
+		{"-2i", complex(0, -2), false},
+		{"i", complex(0, 1), false},
+		{"-i", complex(0, -1), false},
+		{"3+i", complex(3, 1), false},
+		{"7", complex(7, 0), false},
+		{"1e3+2e-1i", complex(1000, 0.2), false},
+		{"abc", 0, true},
+		{"3+4j", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseComplex(tt.input)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ParseComplex(%q) error = %v, expectError %v", tt.input, err, tt.expectError)
+			}
+			if !tt.expectError && got != tt.expected {
+				t.Errorf("ParseComplex(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComplexArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func() (complex128, error)
+		expected complex128
+	}{
+		{"add", func() (complex128, error) { return AddComplex(complex(1, 2), complex(3, -1)) }, complex(4, 1)},
+		{"subtract", func() (complex128, error) { return SubtractComplex(complex(1, 2), complex(3, -1)) }, complex(-2, 3)},
+		{"multiply_conjugates", func() (complex128, error) { return MultiplyComplex(complex(3, 4), complex(3, -4)) }, complex(25, 0)},
+		{"power_i_squared", func() (complex128, error) { return PowerComplex(complex(0, 1), 2) }, complex(-1, 0)},
+		{"sqrt_negative", func() (complex128, error) { return SquareRootComplex(-4) }, complex(0, 2)},
+		{"euler_identity", func() (complex128, error) { return ExpComplex(complex(0, math.Pi)) }, complex(-1, 0)},
+		{"sin_real", func() (complex128, error) { return SinComplex(complex(math.Pi/2, 0)) }, complex(1, 0)},
+		{"cos_imaginary", func() (complex128, error) { return CosComplex(complex(0, 1)) }, complex(math.Cosh(1), 0)},
+		{"tan_zero", func() (complex128, error) { return TanComplex(0) }, 0},
+		{"tan_off_axis", func() (complex128, error) { return TanComplex(complex(math.Pi/2, 1)) }, complex(0, 1/math.Tanh(1))},
+		{"tan_large_real", func() (complex128, error) { return TanComplex(2e12) }, complex(math.Tan(2e12), 0)},
+		{"conj", func() (complex128, error) { return ConjComplex(complex(3, 4)), nil }, complex(3, -4)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if err != nil {
+				t.Fatalf("%s unexpected error: %v", tt.name, err)
+			}
+			if !complexClose(got, tt.expected) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComplexErrors(t *testing.T) {
+	if _, err := DivideComplex(1, 0); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("DivideComplex(1, 0) error = %v, want %v", err, ErrDivisionByZero)
+	}
+	if got, err := DivideComplex(1, complex(0, 1)); err != nil || !complexClose(got, complex(0, -1)) {
+		t.Errorf("DivideComplex(1, i) = %v, %v, want -i", got, err)
+	}
+	if _, err := LogComplex(0); !errors.Is(err, ErrLogOfZero) {
+		t.Errorf("LogComplex(0) error = %v, want %v", err, ErrLogOfZero)
+	}
+	if got, err := LogComplex(-1); err != nil || !complexClose(got, complex(0, math.Pi)) {
+		t.Errorf("LogComplex(-1) = %v, %v, want πi", got, err)
+	}
+	if got, err := LogBaseComplex(-8, 2); err != nil || !complexClose(got, complex(3, math.Pi/math.Ln2)) {
+		t.Errorf("LogBaseComplex(-8, 2) = %v, %v, want 3+(π/ln 2)i", got, err)
+	}
+
+	tests := []struct {
+		name string
+		fn   func() (complex128, error)
+		want error
+	}{
+		{"add_nan", func() (complex128, error) { return AddComplex(complex(math.NaN(), 0), 1) }, ErrNonFinite},
+		{"multiply_overflow", func() (complex128, error) { return MultiplyComplex(complex(1e308, 0), 10) }, ErrOverflow},
+		{"divide_infinite", func() (complex128, error) { return DivideComplex(1, complex(0, math.Inf(1))) }, ErrNonFinite},
+		{"power_zero_negative", func() (complex128, error) { return PowerComplex(0, -1) }, ErrDivisionByZero},
+		{"exp_overflow", func() (complex128, error) { return ExpComplex(1000) }, ErrOverflow},
+		{"log_base_one", func() (complex128, error) { return LogBaseComplex(2, 1) }, ErrInvalidBase},
+		{"log_base_zero_argument", func() (complex128, error) { return LogBaseComplex(0, 2) }, ErrLogOfZero},
+		{"tan_pole", func() (complex128, error) { return TanComplex(complex(math.Pi/2, 0)) }, ErrUndefined},
+		{"tan_negative_pole", func() (complex128, error) { return TanComplex(complex(-3*math.Pi/2, 0)) }, ErrUndefined},
+	}
+	for _, tt := range tests {
+		if _, err := tt.fn(); !errors.Is(err, tt.want) {
+			t.Errorf("%s error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestComplexAbsArg(t *testing.T) {
+	tests := []struct {
+		name        string
+		fn          func(complex128) (float64, error)
+		a           complex128
+		expected    float64
+		expectError error
+	}{
+		{"abs", AbsComplex, complex(3, 4), 5, nil},
+		{"abs_overflow", AbsComplex, complex(1.5e308, 1.5e308), 0, ErrOverflow},
+		{"abs_nan", AbsComplex, complex(math.NaN(), 0), 0, ErrNonFinite},
+		{"abs_infinite_imaginary", AbsComplex, complex(1, math.Inf(-1)), 0, ErrNonFinite},
+		{"arg", ArgComplex, complex(0, 1), math.Pi / 2, nil},
+		{"arg_negative_real", ArgComplex, -1, math.Pi, nil},
+		{"arg_infinite", ArgComplex, complex(math.Inf(1), 1), 0, ErrNonFinite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.a)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("error = %v, want %v", err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && math.Abs(got-tt.expected) > 1e-12 {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Package currency converts amounts of money between currencies with exact
+// rational arithmetic, at exchange rates supplied by a RateProvider. Rates
+// can come from a local JSON or CSV rate table (FileProvider) or be added in
+// code (MemoryProvider), and each is stamped with the date it applies from,
+// so conversions are reproducible offline.
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Currency describes how amounts of a currency are written.
+type Currency struct {
+	// Code is the ISO 4217 code, e.g. "EUR".
+	Code string
+	// Symbol is written before amounts, e.g. "€".
+	Symbol string
+	// MinorUnits is the number of decimal places amounts are rounded to,
+	// e.g. 2 for cents or 0 for yen.
+	MinorUnits int
+}
+
+// currencies lists the currencies Lookup knows the symbol and minor units
+// of.
+var currencies = []Currency{
+	{"AUD", "A$", 2},
+	{"BHD", "BHD", 3},
+	{"BRL", "R$", 2},
+	{"CAD", "CA$", 2},
+	{"CHF", "CHF", 2},
+	{"CNY", "CN¥", 2},
+	{"CZK", "CZK", 2},
+	{"DKK", "DKK", 2},
+	{"EUR", "€", 2},
+	{"GBP", "£", 2},
+	{"HKD", "HK$", 2},
+	{"INR", "₹", 2},
+	{"ISK", "ISK", 0},
+	{"JPY", "¥", 0},
+	{"KRW", "₩", 0},
+	{"KWD", "KWD", 3},
+	{"MXN", "MX$", 2},
+	{"NOK", "NOK", 2},
+	{"NZD", "NZ$", 2},
+	{"PLN", "PLN", 2},
+	{"SEK", "SEK", 2},
+	{"SGD", "SGD", 2},
+	{"USD", "$", 2},
+	{"ZAR", "ZAR", 2},
+}
+
+// Lookup returns the currency with the given code, which may be in any
+// case. Currencies that are not listed are written with their code as the
+// symbol and two minor units.
+// Returns an error if code is not three letters.
+func Lookup(code string) (Currency, error) {
+	code = strings.ToUpper(code)
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return Currency{}, fmt.Errorf("%w: %q", ErrInvalidCode, code)
+	}
+	for _, c := range currencies {
+		if c.Code == code {
+			return c, nil
+		}
+	}
+	return Currency{Code: code, Symbol: code, MinorUnits: 2}, nil
+}
+
+// Round returns amount rounded to the minor units of c, with halves rounded
+// away from zero.
+func (c Currency) Round(amount calculator.Rational) calculator.Rational {
+	rounded, _ := new(big.Rat).SetString(amount.Rat().FloatString(c.MinorUnits))
+	return calculator.NewRationalFromRat(rounded)
+}
+
+// Rate is an exchange rate quoted on a date.
+type Rate struct {
+	// From and To are the codes of the currencies exchanged.
+	From, To string
+	// Date is the day the rate applies from.
+	Date time.Time
+	// Value is the amount of To that one unit of From buys.
+	Value calculator.Rational
+}
+
+// RateProvider supplies exchange rates.
+type RateProvider interface {
+	// Rate returns the rate from one currency to another that applies on
+	// date, which is the latest quoted on or before it, or the latest of
+	// all if date is zero.
+	// Returns an error wrapping ErrNoRate if there is none.
+	Rate(from, to string, date time.Time) (Rate, error)
+}
+
+// Convert converts amount from one currency to another at the rate p
+// supplies for date, and rounds the result to the minor units of the target
+// currency. It returns the converted amount and the rate used.
+// Returns an error if a code is invalid, there is no rate or p fails.
+func Convert(p RateProvider, amount calculator.Rational, from, to string, date time.Time) (calculator.Rational, Rate, error) {
+	source, err := Lookup(from)
+	if err != nil {
+		return calculator.Rational{}, Rate{}, conversionError(ErrInvalidCode, amount, from, to)
+	}
+	target, err := Lookup(to)
+	if err != nil {
+		return calculator.Rational{}, Rate{}, conversionError(ErrInvalidCode, amount, from, to)
+	}
+	rate, err := p.Rate(source.Code, target.Code, date)
+	if errors.Is(err, ErrNoRate) {
+		return calculator.Rational{}, Rate{}, conversionError(ErrNoRate, amount, source.Code, target.Code)
+	}
+	if err != nil {
+		return calculator.Rational{}, Rate{}, err
+	}
+	return target.Round(calculator.MultiplyRational(amount, rate.Value)), rate, nil
+}
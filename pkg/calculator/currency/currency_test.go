@@ -0,0 +1,138 @@
+package currency
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// mustRational parses a fraction or decimal or fails the test
+func mustRational(t *testing.T, s string) calculator.Rational {
+	t.Helper()
+	r, err := calculator.ParseRational(s)
+	if err != nil {
+		t.Fatalf("ParseRational(%q) unexpected error: %v", s, err)
+	}
+	return r
+}
+
+// mustDate parses a date in DateFormat or fails the test
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse(DateFormat, s)
+	if err != nil {
+		t.Fatalf("time.Parse(%q) unexpected error: %v", s, err)
+	}
+	return d
+}
+
+// testProvider returns a provider with EUR rates on two dates
+func testProvider(t *testing.T) *MemoryProvider {
+	t.Helper()
+	p := NewMemoryProvider()
+	quotes := []struct{ date, from, to, value string }{
+		{"2024-04-01", "EUR", "USD", "1.08"},
+		{"2024-05-01", "EUR", "USD", "1.0712"},
+		{"2024-05-01", "EUR", "GBP", "0.8554"},
+		{"2024-05-01", "EUR", "JPY", "166.45"},
+	}
+	for _, q := range quotes {
+		if err := p.Add(Rate{From: q.from, To: q.to, Date: mustDate(t, q.date), Value: mustRational(t, q.value)}); err != nil {
+			t.Fatalf("Add(%v) unexpected error: %v", q, err)
+		}
+	}
+	return p
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		code       string
+		symbol     string
+		minorUnits int
+		err        error
+	}{
+		{"USD", "$", 2, nil},
+		{"eur", "€", 2, nil},
+		{"JPY", "¥", 0, nil},
+		{"KWD", "KWD", 3, nil},
+		{"XYZ", "XYZ", 2, nil},
+		{"US$", "", 0, ErrInvalidCode},
+		{"EURO", "", 0, ErrInvalidCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			c, err := Lookup(tt.code)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Lookup(%q) error = %v, want %v", tt.code, err, tt.err)
+			}
+			if err == nil && (c.Symbol != tt.symbol || c.MinorUnits != tt.minorUnits) {
+				t.Errorf("Lookup(%q) = %+v, want symbol %q and %d minor units", tt.code, c, tt.symbol, tt.minorUnits)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		from, to string
+		date     string
+		expected string
+		rate     string
+		rateDate string
+		err      error
+	}{
+		{"direct", "100", "EUR", "USD", "", "107.12", "1339/1250", "2024-05-01", nil},
+		{"inverse", "107.12", "USD", "EUR", "", "100", "1250/1339", "2024-05-01", nil},
+		{"cross", "100", "USD", "GBP", "", "79.85", "329/412", "2024-05-01", nil},
+		{"no_minor_units", "10", "EUR", "JPY", "", "1665", "3329/20", "2024-05-01", nil},
+		{"same_currency", "12.345", "USD", "USD", "", "12.35", "1", "", nil},
+		{"earlier_date", "100", "EUR", "USD", "2024-04-15", "108", "27/25", "2024-04-01", nil},
+		{"cross_falls_back", "100", "EUR", "GBP", "2024-04-15", "", "", "", ErrNoRate},
+		{"before_first_date", "100", "EUR", "USD", "2024-01-01", "", "", "", ErrNoRate},
+		{"unknown_pair", "100", "EUR", "CHF", "", "", "", "", ErrNoRate},
+		{"invalid_code", "100", "EUR", "EURO", "", "", "", "", ErrInvalidCode},
+	}
+
+	p := testProvider(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var date time.Time
+			if tt.date != "" {
+				date = mustDate(t, tt.date)
+			}
+			got, rate, err := Convert(p, mustRational(t, tt.amount), tt.from, tt.to, date)
+			if tt.err != nil {
+				var domainErr *calculator.DomainError
+				if !errors.Is(err, tt.err) || !errors.As(err, &domainErr) {
+					t.Errorf("Convert(%s %s to %s) error = %v, want %v", tt.amount, tt.from, tt.to, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Convert(%s %s to %s) unexpected error: %v", tt.amount, tt.from, tt.to, err)
+			}
+			if got.String() != mustRational(t, tt.expected).String() {
+				t.Errorf("Convert(%s %s to %s) = %s, want %s", tt.amount, tt.from, tt.to, got, tt.expected)
+			}
+			if rate.Value.String() != tt.rate {
+				t.Errorf("Convert(%s %s to %s) rate = %s, want %s", tt.amount, tt.from, tt.to, rate.Value, tt.rate)
+			}
+			if tt.rateDate != "" && rate.Date.Format(DateFormat) != tt.rateDate {
+				t.Errorf("Convert(%s %s to %s) rate date = %s, want %s", tt.amount, tt.from, tt.to, rate.Date.Format(DateFormat), tt.rateDate)
+			}
+		})
+	}
+}
+
+func TestAddInvalidRate(t *testing.T) {
+	p := NewMemoryProvider()
+	err := p.Add(Rate{From: "EUR", To: "USD", Value: mustRational(t, "-1")})
+	if !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("Add(negative rate) error = %v, want %v", err, ErrInvalidRate)
+	}
+}
@@ -0,0 +1,29 @@
+package currency
+
+import (
+	"errors"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Sentinel errors returned by this package. Conversion errors are wrapped in
+// a *calculator.DomainError whose operands are the amount and the currency
+// codes, e.g. "currency(100, USD, XYZ): no exchange rate".
+var (
+	// ErrInvalidCode is returned for a currency code that is not three
+	// letters, such as "US$".
+	ErrInvalidCode = errors.New("currency code must be three letters")
+
+	// ErrNoRate is returned when a provider has no rate, direct, inverse
+	// or through a common currency, for a pair of currencies on or before
+	// the requested date.
+	ErrNoRate = errors.New("no exchange rate")
+
+	// ErrInvalidRate is returned when adding a rate that is not positive.
+	ErrInvalidRate = errors.New("exchange rate must be positive")
+)
+
+// conversionError builds a *calculator.DomainError for a conversion.
+func conversionError(reason error, amount calculator.Rational, from, to string) error {
+	return &calculator.DomainError{Op: "currency", Operands: []interface{}{amount, from, to}, Reason: reason}
+}
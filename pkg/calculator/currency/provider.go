@@ -0,0 +1,275 @@
+package currency
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// DateFormat is the layout of dates in rate tables, e.g. "2024-05-01".
+const DateFormat = "2006-01-02"
+
+// MemoryProvider is a RateProvider holding rates added in code or read from
+// rate tables. A pair of currencies can be converted on a date if a rate
+// between them, in either direction, or rates from both to a common
+// currency were quoted on that date.
+type MemoryProvider struct {
+	// quotes maps each date to the rates quoted on it, by source and
+	// target currency.
+	quotes map[time.Time]map[string]map[string]calculator.Rational
+}
+
+// NewMemoryProvider returns a provider with no rates.
+func NewMemoryProvider() *MemoryProvider {
+	return &MemoryProvider{quotes: make(map[time.Time]map[string]map[string]calculator.Rational)}
+}
+
+// Add records a rate, replacing any quoted for the same pair on the same
+// date. Only the day of r.Date is kept.
+// Returns an error if a code is invalid or the rate is not positive.
+func (p *MemoryProvider) Add(r Rate) error {
+	from, err := Lookup(r.From)
+	if err != nil {
+		return err
+	}
+	to, err := Lookup(r.To)
+	if err != nil {
+		return err
+	}
+	if r.Value.Rat().Sign() <= 0 {
+		return fmt.Errorf("%w: %s to %s is %s", ErrInvalidRate, from.Code, to.Code, r.Value)
+	}
+
+	date := day(r.Date)
+	if p.quotes[date] == nil {
+		p.quotes[date] = make(map[string]map[string]calculator.Rational)
+	}
+	if p.quotes[date][from.Code] == nil {
+		p.quotes[date][from.Code] = make(map[string]calculator.Rational)
+	}
+	p.quotes[date][from.Code][to.Code] = r.Value
+	return nil
+}
+
+// Rate implements RateProvider.
+func (p *MemoryProvider) Rate(from, to string, date time.Time) (Rate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return Rate{From: from, To: to, Date: day(date), Value: calculator.NewRational(1, 1)}, nil
+	}
+	dates := p.Dates()
+	for i := len(dates) - 1; i >= 0; i-- {
+		if !date.IsZero() && dates[i].After(day(date)) {
+			continue
+		}
+		if value, ok := p.resolve(dates[i], from, to); ok {
+			return Rate{From: from, To: to, Date: dates[i], Value: value}, nil
+		}
+	}
+	if date.IsZero() {
+		return Rate{}, fmt.Errorf("%w from %s to %s", ErrNoRate, from, to)
+	}
+	return Rate{}, fmt.Errorf("%w from %s to %s on or before %s", ErrNoRate, from, to, date.Format(DateFormat))
+}
+
+// Dates returns the dates rates are quoted on, oldest first.
+func (p *MemoryProvider) Dates() []time.Time {
+	dates := make([]time.Time, 0, len(p.quotes))
+	for d := range p.quotes {
+		dates = append(dates, d)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+// resolve returns the rate from one currency to another on date: quoted
+// directly, as the inverse of the opposite quote, or through the first
+// currency, in alphabetical order, that both can be converted to.
+func (p *MemoryProvider) resolve(date time.Time, from, to string) (calculator.Rational, bool) {
+	if value, ok := p.direct(date, from, to); ok {
+		return value, true
+	}
+	for _, via := range p.currencies(date) {
+		if via == from || via == to {
+			continue
+		}
+		first, ok := p.direct(date, from, via)
+		if !ok {
+			continue
+		}
+		if second, ok := p.direct(date, via, to); ok {
+			return calculator.MultiplyRational(first, second), true
+		}
+	}
+	return calculator.Rational{}, false
+}
+
+// direct returns the rate quoted from one currency to another on date, or
+// the inverse of the rate quoted the other way.
+func (p *MemoryProvider) direct(date time.Time, from, to string) (calculator.Rational, bool) {
+	if value, ok := p.quotes[date][from][to]; ok {
+		return value, true
+	}
+	if value, ok := p.quotes[date][to][from]; ok {
+		inverse, err := calculator.DivideRational(calculator.NewRational(1, 1), value)
+		return inverse, err == nil
+	}
+	return calculator.Rational{}, false
+}
+
+// currencies returns the codes of the currencies quoted on date, sorted.
+func (p *MemoryProvider) currencies(date time.Time) []string {
+	seen := make(map[string]bool)
+	for from, rates := range p.quotes[date] {
+		seen[from] = true
+		for to := range rates {
+			seen[to] = true
+		}
+	}
+	codes := make([]string, 0, len(seen))
+	for code := range seen {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// jsonTable is a rate table in JSON: the rates of one base currency on one
+// date. Rates may be JSON numbers or strings, and are read exactly.
+type jsonTable struct {
+	Date  string                 `json:"date"`
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// ReadJSON adds the rates of a JSON rate table: an object with the date,
+// the base currency and the amount of each other currency one unit of the
+// base buys, or an array of such objects.
+//
+//	{"date": "2024-05-01", "base": "EUR", "rates": {"USD": "1.0712", "JPY": 166.45}}
+//
+// Returns an error if the table is malformed or holds an invalid rate.
+func (p *MemoryProvider) ReadJSON(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var tables []jsonTable
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(data, &tables)
+	} else {
+		tables = make([]jsonTable, 1)
+		err = json.Unmarshal(data, &tables[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, table := range tables {
+		date, err := time.Parse(DateFormat, table.Date)
+		if err != nil {
+			return fmt.Errorf("table %d: invalid date %q (want YYYY-MM-DD)", i+1, table.Date)
+		}
+		for code, value := range table.Rates {
+			if err := p.addQuote(date, table.Base, code, value.String()); err != nil {
+				return fmt.Errorf("table %d: %w", i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ReadCSV adds the rates of a CSV rate table, with one rate per record of
+// the form date,from,to,rate. A header record starting with "date" and
+// lines starting with "#" are skipped.
+//
+//	date,from,to,rate
+//	2024-05-01,EUR,USD,1.0712
+//
+// Returns an error naming the line of the first invalid record.
+func (p *MemoryProvider) ReadCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if first && strings.EqualFold(record[0], "date") {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		date, err := time.Parse(DateFormat, record[0])
+		if err != nil {
+			return fmt.Errorf("line %d: invalid date %q (want YYYY-MM-DD)", line, record[0])
+		}
+		if err := p.addQuote(date, record[1], record[2], record[3]); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+// addQuote adds a rate read from a rate table, keeping its decimal text
+// exact.
+func (p *MemoryProvider) addQuote(date time.Time, from, to, value string) error {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return fmt.Errorf("invalid rate %q from %s to %s", value, from, to)
+	}
+	return p.Add(Rate{From: from, To: to, Date: date, Value: calculator.NewRationalFromRat(rate)})
+}
+
+// FileProvider is a RateProvider reading a rate table from a local JSON or
+// CSV file, in the formats of MemoryProvider.ReadJSON and ReadCSV.
+type FileProvider struct {
+	*MemoryProvider
+	// Path is the file the rates were read from.
+	Path string
+}
+
+// NewFileProvider reads the rate table at path, in JSON if its name ends in
+// ".json" and in CSV if it ends in ".csv".
+// Returns an error if the file cannot be read or is invalid.
+func NewFileProvider(path string) (*FileProvider, error) {
+	p := &FileProvider{MemoryProvider: NewMemoryProvider(), Path: path}
+	read := p.ReadCSV
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+	case ".json":
+		read = p.ReadJSON
+	default:
+		return nil, fmt.Errorf("unsupported rate table format %q (want .json or .csv)", ext)
+	}
+
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := read(f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// day returns the start of the day of t, in UTC.
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
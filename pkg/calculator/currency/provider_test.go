@@ -0,0 +1,62 @@
+package currency
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadRateTables(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		from, to    string
+		expected    string
+		expectError string
+	}{
+		{"json_object", "rates.json", `{"date": "2024-05-01", "base": "EUR", "rates": {"USD": "1.0712", "JPY": 166.45}}`, "JPY", "USD", "10712/1664500", ""},
+		{"json_array", "rates.json", `[{"date": "2024-04-01", "base": "EUR", "rates": {"USD": 1.08}}, {"date": "2024-05-01", "base": "EUR", "rates": {"USD": 1.0712}}]`, "EUR", "USD", "1.0712", ""},
+		{"csv", "rates.csv", "date,from,to,rate\n# ECB reference rates\n2024-05-01,EUR,USD,1.0712\n2024-05-01, usd, cad, 1.3671\n", "EUR", "CAD", "1.46443752", ""},
+		{"csv_without_header", "rates.csv", "2024-05-01,GBP,USD,1.25\n", "USD", "GBP", "0.8", ""},
+		{"json_bad_date", "rates.json", `{"date": "May 1", "base": "EUR", "rates": {"USD": 1.07}}`, "", "", "", "table 1: invalid date"},
+		{"json_bad_rate", "rates.json", `{"date": "2024-05-01", "base": "EUR", "rates": {"USD": "abc"}}`, "", "", "", "invalid"},
+		{"csv_bad_rate", "rates.csv", "2024-05-01,EUR,USD,1.07\n2024-05-01,EUR,GBP,0\n", "", "", "", "line 2: exchange rate must be positive"},
+		{"csv_bad_code", "rates.csv", "2024-05-01,EUR,US$,1.07\n", "", "", "", "line 1: currency code must be three letters"},
+		{"csv_wrong_fields", "rates.csv", "2024-05-01,EUR,USD\n", "", "", "", "wrong number of fields"},
+		{"unsupported_format", "rates.txt", "", "", "", "", "unsupported rate table format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			p, err := NewFileProvider(path)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Errorf("NewFileProvider() error = %v, want %q", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFileProvider() unexpected error: %v", err)
+			}
+			rate, err := p.Rate(tt.from, tt.to, mustDate(t, "2024-06-01"))
+			if err != nil {
+				t.Fatalf("Rate(%s, %s) unexpected error: %v", tt.from, tt.to, err)
+			}
+			if rate.Value.String() != mustRational(t, tt.expected).String() {
+				t.Errorf("Rate(%s, %s) = %s, want %s", tt.from, tt.to, rate.Value, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFileProviderMissing(t *testing.T) {
+	if _, err := NewFileProvider(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("NewFileProvider(missing file) expected error")
+	}
+}
@@ -0,0 +1,355 @@
+package calculator
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalExponent bounds the exponent ParseDecimal accepts, so input
+// such as "1e999999999" cannot exhaust memory.
+const maxDecimalExponent = 100000
+
+// MaxDecimalScale is the largest scale Decimal results are rounded to. Larger
+// scales are rejected with ErrResultTooLarge, since their coefficients would
+// need as many digits.
+const MaxDecimalScale = maxDecimalExponent
+
+// RoundingMode selects how a Decimal is rounded to fewer decimal places.
+type RoundingMode int
+
+const (
+	// HalfUp rounds to the nearest value, with halves rounded away from
+	// zero: 2.5 becomes 3 and -2.5 becomes -3.
+	HalfUp RoundingMode = iota
+	// HalfEven rounds to the nearest value, with halves rounded to the even
+	// neighbour: 2.5 becomes 2 and 3.5 becomes 4. It is also known as
+	// banker's rounding.
+	HalfEven
+	// Down rounds towards zero, truncating the dropped digits.
+	Down
+	// Up rounds away from zero.
+	Up
+	// Ceiling rounds towards positive infinity.
+	Ceiling
+	// Floor rounds towards negative infinity.
+	Floor
+)
+
+// roundingModeNames maps each RoundingMode to its name.
+var roundingModeNames = []string{"half-up", "half-even", "down", "up", "ceiling", "floor"}
+
+// String returns the name of the mode.
+func (m RoundingMode) String() string {
+	if m < 0 || int(m) >= len(roundingModeNames) {
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+	return roundingModeNames[m]
+}
+
+// RoundingModeNames returns the names ParseRoundingMode accepts.
+func RoundingModeNames() []string {
+	return append([]string(nil), roundingModeNames...)
+}
+
+// ParseRoundingMode converts a mode name such as "half-even" into a
+// RoundingMode.
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	for i, n := range roundingModeNames {
+		if n == name {
+			return RoundingMode(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown rounding mode %q (want %s)", name, strings.Join(roundingModeNames, ", "))
+}
+
+// Decimal is a fixed-point decimal number: an integer coefficient and a
+// scale, the number of digits after the decimal point, so 1.50 has
+// coefficient 150 and scale 2. Addition, subtraction and multiplication are
+// exact; division and square roots round to a requested scale with an
+// explicit RoundingMode. Operations never modify their operands. The zero
+// value is 0.
+type Decimal struct {
+	coef  *big.Int
+	scale int
+}
+
+// NewDecimal returns coef×10^-scale, e.g. NewDecimal(150, 2) is 1.50.
+// A negative scale is treated as 0.
+func NewDecimal(coef int64, scale int) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	return Decimal{coef: big.NewInt(coef), scale: scale}
+}
+
+// ParseDecimal parses a decimal such as "0.1", "-12.50" or "1.5e-3". The
+// scale is the number of digits written after the point, adjusted by the
+// exponent, so "12.50" keeps its two places and "1.5e3" is 1500.
+func ParseDecimal(s string) (Decimal, error) {
+	text, exp := s, 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		e, err := strconv.Atoi(text[i+1:])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal number %q", s)
+		}
+		text, exp = text[:i], e
+	}
+
+	sign := ""
+	if text != "" && (text[0] == '-' || text[0] == '+') {
+		sign, text = text[:1], text[1:]
+	}
+	whole, frac, _ := strings.Cut(text, ".")
+	digits := whole + frac
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal number %q", s)
+	}
+
+	coef, _ := new(big.Int).SetString(sign+digits, 10)
+	scale := len(frac) - exp
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: scale}, nil
+}
+
+// coefficient returns the coefficient of d, treating the nil coefficient
+// of the zero value as 0.
+func (d Decimal) coefficient() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.coefficient().Sign()
+}
+
+// Rat returns d as an exact fraction.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.coefficient(), pow10(d.scale))
+}
+
+// Float64 returns the float64 value nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// String returns d in plain notation with exactly Scale digits after the
+// point, e.g. "0.30" or "-1500".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.coefficient()).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Round returns d rounded to scale digits after the point with mode. A
+// scale above that of d appends zeros, so Round never loses digits it does
+// not have to.
+// Returns an error if scale is negative or above MaxDecimalScale.
+func (d Decimal) Round(scale int, mode RoundingMode) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, domainError("round", err, d, scale)
+	}
+	return d.rescale(scale, mode), nil
+}
+
+// rescale returns d at scale >= 0, rounding with mode if digits are lost.
+func (d Decimal) rescale(scale int, mode RoundingMode) Decimal {
+	coef := d.coefficient()
+	if scale >= d.scale {
+		return Decimal{coef: new(big.Int).Mul(coef, pow10(scale-d.scale)), scale: scale}
+	}
+	return Decimal{coef: roundQuotient(coef, pow10(d.scale-scale), mode), scale: scale}
+}
+
+// Reduce returns d with trailing zeros after the point removed, e.g. 2.50
+// becomes 2.5 and 3.00 becomes 3.
+func (d Decimal) Reduce() Decimal {
+	coef, scale := new(big.Int).Set(d.coefficient()), d.scale
+	ten, r := big.NewInt(10), new(big.Int)
+	for scale > 0 && coef.Sign() != 0 {
+		q, m := new(big.Int).QuoRem(coef, ten, r)
+		if m.Sign() != 0 {
+			break
+		}
+		coef, scale = q, scale-1
+	}
+	if coef.Sign() == 0 {
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// AddDecimal returns a + b exactly, at the larger of their scales.
+func AddDecimal(a, b Decimal) Decimal {
+	a, b = align(a, b)
+	return Decimal{coef: new(big.Int).Add(a.coef, b.coef), scale: a.scale}
+}
+
+// SubtractDecimal returns a - b exactly, at the larger of their scales.
+func SubtractDecimal(a, b Decimal) Decimal {
+	a, b = align(a, b)
+	return Decimal{coef: new(big.Int).Sub(a.coef, b.coef), scale: a.scale}
+}
+
+// MultiplyDecimal returns a × b exactly, at the sum of their scales.
+func MultiplyDecimal(a, b Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(a.coefficient(), b.coefficient()), scale: a.scale + b.scale}
+}
+
+// DivideDecimal returns a ÷ b rounded to scale digits after the point with
+// mode.
+// Returns an error if b is zero or scale is negative or above
+// MaxDecimalScale.
+func DivideDecimal(a, b Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if b.Sign() == 0 {
+		return Decimal{}, domainError("divide", ErrDivisionByZero, a, b)
+	}
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, domainError("divide", err, a, b)
+	}
+	return quotient(a, b, scale, mode), nil
+}
+
+// quotient returns a ÷ b, for b not zero, rounded to scale >= 0.
+func quotient(a, b Decimal, scale int, mode RoundingMode) Decimal {
+	// a/b = (ca×10^-sa)/(cb×10^-sb), so the coefficient at scale is
+	// ca×10^(sb+scale-sa)/cb.
+	num, den := new(big.Int).Set(a.coefficient()), new(big.Int).Set(b.coefficient())
+	if shift := b.scale + scale - a.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{coef: roundQuotient(num, den, mode), scale: scale}
+}
+
+// PowerDecimal returns a raised to the integer power n. Non-negative powers
+// are exact; negative powers are 1 ÷ a^-n rounded to scale digits after the
+// point with mode.
+// Returns an error if a is zero and n negative, scale is negative or above
+// MaxDecimalScale, or the result would be too large.
+func PowerDecimal(a Decimal, n int, scale int, mode RoundingMode) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, domainError("power", err, a, n)
+	}
+	if n < 0 && a.Sign() == 0 {
+		return Decimal{}, domainError("power", ErrDivisionByZero, a, n)
+	}
+	e := n
+	if e < 0 {
+		e = -e
+	}
+	bits := a.coefficient().BitLen()
+	if bits > 1 && e > maxExactPowerBits/bits || a.scale > 0 && e > maxDecimalExponent/a.scale {
+		return Decimal{}, domainError("power", ErrResultTooLarge, a, n)
+	}
+
+	p := Decimal{coef: new(big.Int).Exp(a.coefficient(), big.NewInt(int64(e)), nil), scale: a.scale * e}
+	if n >= 0 {
+		return p, nil
+	}
+	return quotient(NewDecimal(1, 0), p, scale, mode), nil
+}
+
+// SquareRootDecimal returns the square root of a rounded to scale digits
+// after the point with mode.
+// Returns an error if a is negative, or scale is negative or above
+// MaxDecimalScale.
+func SquareRootDecimal(a Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if a.Sign() < 0 {
+		return Decimal{}, domainError("sqrt", ErrNegativeSquareRoot, a)
+	}
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, domainError("sqrt", err, a)
+	}
+
+	// Take the root at a scale with at least one extra digit, where the
+	// radicand is an integer, then round the truncated root to scale. A
+	// sticky bit records an inexact root so the final rounding sees that
+	// the true root lies strictly between two extra-digit values.
+	work := scale + 1
+	if least := (a.scale + 1) / 2; work < least {
+		work = least
+	}
+	radicand := new(big.Int).Mul(a.coefficient(), pow10(2*work-a.scale))
+	root := new(big.Int).Sqrt(radicand)
+	num := new(big.Int).Lsh(root, 1)
+	if new(big.Int).Mul(root, root).Cmp(radicand) != 0 {
+		num.Add(num, big.NewInt(1))
+	}
+	den := new(big.Int).Lsh(pow10(work-scale), 1)
+	return Decimal{coef: roundQuotient(num, den, mode), scale: scale}, nil
+}
+
+// checkScale returns the error for a scale results cannot be rounded to, or
+// nil.
+func checkScale(scale int) error {
+	switch {
+	case scale < 0:
+		return ErrNegativeScale
+	case scale > MaxDecimalScale:
+		return ErrResultTooLarge
+	}
+	return nil
+}
+
+// align returns a and b at the larger of their scales.
+func align(a, b Decimal) (Decimal, Decimal) {
+	if a.scale < b.scale {
+		return a.rescale(b.scale, Down), b.rescale(b.scale, Down)
+	}
+	return a.rescale(a.scale, Down), b.rescale(a.scale, Down)
+}
+
+// roundQuotient returns num ÷ den, for den not zero, rounded to an integer
+// with mode.
+func roundQuotient(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// sign is that of the exact quotient; q was truncated towards zero.
+	sign := num.Sign() * den.Sign()
+	away := false
+	switch mode {
+	case Up:
+		away = true
+	case Ceiling:
+		away = sign > 0
+	case Floor:
+		away = sign < 0
+	case HalfUp, HalfEven:
+		half := new(big.Int).Lsh(new(big.Int).Abs(r), 1).CmpAbs(den)
+		away = half > 0 || half == 0 && (mode == HalfUp || q.Bit(0) == 1)
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q
+}
+
+// pow10 returns 10^n for n >= 0.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,260 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+// mustParseDecimal parses a decimal or fails the test
+func mustParseDecimal(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := ParseDecimal(s)
+	if err != nil {
+		t.Fatalf("ParseDecimal(%q) unexpected error: %v", s, err)
+	}
+	return d
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		scale    int
+		wantErr  bool
+	}{
+		{"0.1", "0.1", 1, false},
+		{"12.50", "12.50", 2, false},
+		{"-3", "-3", 0, false},
+		{"+7.25", "7.25", 2, false},
+		{".5", "0.5", 1, false},
+		{"1.", "1", 0, false},
+		{"1.5e3", "1500", 0, false},
+		{"1.5e-3", "0.0015", 4, false},
+		{"-0.000", "0.000", 3, false},
+		{"", "", 0, true},
+		{"-", "", 0, true},
+		{"1.2.3", "", 0, true},
+		{"1e", "", 0, true},
+		{"0x10", "", 0, true},
+		{"NaN", "", 0, true},
+		{"1e999999999", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseDecimal(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDecimal(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if d.String() != tt.expected || d.Scale() != tt.scale {
+				t.Errorf("ParseDecimal(%q) = %s (scale %d), want %s (scale %d)", tt.input, d, d.Scale(), tt.expected, tt.scale)
+			}
+		})
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b Decimal) Decimal
+		a, b     string
+		expected string
+	}{
+		{"add_tenths", AddDecimal, "0.1", "0.2", "0.3"},
+		{"add_scales", AddDecimal, "1.5", "2.25", "3.75"},
+		{"add_keeps_scale", AddDecimal, "0.10", "0.20", "0.30"},
+		{"subtract", SubtractDecimal, "1", "0.01", "0.99"},
+		{"subtract_negative", SubtractDecimal, "0.1", "0.3", "-0.2"},
+		{"multiply", MultiplyDecimal, "19.99", "0.0825", "1.649175"},
+		{"multiply_negative", MultiplyDecimal, "-1.5", "2", "-3.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.op(mustParseDecimal(t, tt.a), mustParseDecimal(t, tt.b))
+			if got.String() != tt.expected {
+				t.Errorf("%s(%s, %s) = %s, want %s", tt.name, tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	// Each row rounds the value to scale with every mode, in the order
+	// half-up, half-even, down, up, ceiling, floor.
+	tests := []struct {
+		value    string
+		scale    int
+		expected [6]string
+	}{
+		{"2.5", 0, [6]string{"3", "2", "2", "3", "3", "2"}},
+		{"3.5", 0, [6]string{"4", "4", "3", "4", "4", "3"}},
+		{"-2.5", 0, [6]string{"-3", "-2", "-2", "-3", "-2", "-3"}},
+		{"2.675", 2, [6]string{"2.68", "2.68", "2.67", "2.68", "2.68", "2.67"}},
+		{"2.665", 2, [6]string{"2.67", "2.66", "2.66", "2.67", "2.67", "2.66"}},
+		{"1.649175", 2, [6]string{"1.65", "1.65", "1.64", "1.65", "1.65", "1.64"}},
+		{"-1.641", 2, [6]string{"-1.64", "-1.64", "-1.64", "-1.65", "-1.64", "-1.65"}},
+		{"0.004", 2, [6]string{"0.00", "0.00", "0.00", "0.01", "0.01", "0.00"}},
+		{"1.5", 3, [6]string{"1.500", "1.500", "1.500", "1.500", "1.500", "1.500"}},
+	}
+
+	modes := []RoundingMode{HalfUp, HalfEven, Down, Up, Ceiling, Floor}
+	for _, tt := range tests {
+		for i, mode := range modes {
+			got, err := mustParseDecimal(t, tt.value).Round(tt.scale, mode)
+			if err != nil {
+				t.Fatalf("Round(%s, %d, %v) unexpected error: %v", tt.value, tt.scale, mode, err)
+			}
+			if got.String() != tt.expected[i] {
+				t.Errorf("Round(%s, %d, %v) = %s, want %s", tt.value, tt.scale, mode, got, tt.expected[i])
+			}
+		}
+	}
+
+	if _, err := NewDecimal(1, 0).Round(-1, HalfUp); !errors.Is(err, ErrNegativeScale) {
+		t.Errorf("Round(-1) error = %v, want %v", err, ErrNegativeScale)
+	}
+	if _, err := NewDecimal(1, 0).Round(MaxDecimalScale+1, HalfUp); !errors.Is(err, ErrResultTooLarge) {
+		t.Errorf("Round(MaxDecimalScale+1) error = %v, want %v", err, ErrResultTooLarge)
+	}
+}
+
+func TestDivideDecimal(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		scale    int
+		mode     RoundingMode
+		expected string
+		err      error
+	}{
+		{"1", "3", 4, HalfEven, "0.3333", nil},
+		{"2", "3", 4, HalfEven, "0.6667", nil},
+		{"2", "3", 4, Down, "0.6666", nil},
+		{"-2", "3", 2, Floor, "-0.67", nil},
+		{"-2", "3", 2, Ceiling, "-0.66", nil},
+		{"10", "4", 0, HalfEven, "2", nil},
+		{"10", "4", 0, HalfUp, "3", nil},
+		{"1.00", "0.25", 2, HalfEven, "4.00", nil},
+		{"0.125", "1", 1, HalfEven, "0.1", nil},
+		{"1", "0", 2, HalfEven, "", ErrDivisionByZero},
+		{"1", "3", -1, HalfEven, "", ErrNegativeScale},
+		{"1", "3", 100000000000, HalfEven, "", ErrResultTooLarge},
+	}
+
+	for _, tt := range tests {
+		got, err := DivideDecimal(mustParseDecimal(t, tt.a), mustParseDecimal(t, tt.b), tt.scale, tt.mode)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("DivideDecimal(%s, %s, %d, %v) error = %v, want %v", tt.a, tt.b, tt.scale, tt.mode, err, tt.err)
+			continue
+		}
+		if err == nil && got.String() != tt.expected {
+			t.Errorf("DivideDecimal(%s, %s, %d, %v) = %s, want %s", tt.a, tt.b, tt.scale, tt.mode, got, tt.expected)
+		}
+	}
+}
+
+func TestPowerDecimal(t *testing.T) {
+	tests := []struct {
+		a        string
+		n        int
+		expected string
+		err      error
+	}{
+		{"1.1", 2, "1.21", nil},
+		{"-0.5", 3, "-0.125", nil},
+		{"7", 0, "1", nil},
+		{"2", -2, "0.2500", nil},
+		{"3", -1, "0.3333", nil},
+		{"0", -1, "", ErrDivisionByZero},
+		{"1.5", 1 << 30, "", ErrResultTooLarge},
+	}
+
+	for _, tt := range tests {
+		got, err := PowerDecimal(mustParseDecimal(t, tt.a), tt.n, 4, HalfEven)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("PowerDecimal(%s, %d) error = %v, want %v", tt.a, tt.n, err, tt.err)
+			continue
+		}
+		if err == nil && got.String() != tt.expected {
+			t.Errorf("PowerDecimal(%s, %d) = %s, want %s", tt.a, tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestSquareRootDecimal(t *testing.T) {
+	tests := []struct {
+		a        string
+		scale    int
+		mode     RoundingMode
+		expected string
+		err      error
+	}{
+		{"2", 10, HalfEven, "1.4142135624", nil},
+		{"2", 10, Down, "1.4142135623", nil},
+		{"0.25", 3, HalfEven, "0.500", nil},
+		{"0.0004", 2, HalfEven, "0.02", nil},
+		{"1e-5", 4, HalfEven, "0.0032", nil},
+		{"152.2756", 2, HalfEven, "12.34", nil},
+		// √0.00015625 is exactly 0.0125, a tie, while √0.00015625001 is
+		// just above it even though its first extra digit is also 5.
+		{"0.00015625", 3, HalfEven, "0.012", nil},
+		{"0.00015625001", 3, HalfEven, "0.013", nil},
+		{"2", 100000000000, HalfEven, "", ErrResultTooLarge},
+		{"0.000025", 3, HalfEven, "0.005", nil},
+		{"0.000025", 3, Down, "0.005", nil},
+		{"0.0000249", 3, HalfUp, "0.005", nil},
+		{"0.0000249", 3, Down, "0.004", nil},
+		{"-1", 2, HalfEven, "", ErrNegativeSquareRoot},
+	}
+
+	for _, tt := range tests {
+		got, err := SquareRootDecimal(mustParseDecimal(t, tt.a), tt.scale, tt.mode)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("SquareRootDecimal(%s) error = %v, want %v", tt.a, err, tt.err)
+			continue
+		}
+		if err == nil && got.String() != tt.expected {
+			t.Errorf("SquareRootDecimal(%s, %d, %v) = %s, want %s", tt.a, tt.scale, tt.mode, got, tt.expected)
+		}
+	}
+}
+
+func TestDecimalReduce(t *testing.T) {
+	tests := map[string]string{
+		"2.50":  "2.5",
+		"3.00":  "3",
+		"0.000": "0",
+		"-1.10": "-1.1",
+		"100":   "100",
+	}
+	for input, expected := range tests {
+		if got := mustParseDecimal(t, input).Reduce(); got.String() != expected {
+			t.Errorf("Reduce(%s) = %s, want %s", input, got, expected)
+		}
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	for _, m := range []RoundingMode{HalfUp, HalfEven, Down, Up, Ceiling, Floor} {
+		got, err := ParseRoundingMode(m.String())
+		if err != nil || got != m {
+			t.Errorf("ParseRoundingMode(%q) = %v, %v, want %v", m.String(), got, err, m)
+		}
+	}
+	if _, err := ParseRoundingMode("banker"); err == nil {
+		t.Error("ParseRoundingMode(\"banker\") expected an error")
+	}
+}
+
+func TestDecimalZeroValue(t *testing.T) {
+	var zero Decimal
+	if zero.String() != "0" || zero.Sign() != 0 {
+		t.Errorf("zero Decimal = %s (sign %d), want 0", zero, zero.Sign())
+	}
+	if got := AddDecimal(zero, NewDecimal(150, 2)); got.String() != "1.50" {
+		t.Errorf("0 + 1.50 = %s, want 1.50", got)
+	}
+}
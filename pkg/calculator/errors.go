@@ -0,0 +1,126 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors describing why a calculation failed. Every failing
+// function in this package returns a *DomainError whose Reason is one of
+// these, so callers can test for them with errors.Is.
+var (
+	// ErrDivisionByZero is returned when dividing by zero, including
+	// raising zero to a negative power.
+	ErrDivisionByZero = errors.New("division by zero")
+
+	// ErrNegativeSquareRoot is returned for the real square root of a
+	// negative number.
+	ErrNegativeSquareRoot = errors.New("square root of negative number")
+
+	// ErrNegativeFractionalPower is returned when raising a negative number
+	// to a non-integer power, which has no real result.
+	ErrNegativeFractionalPower = errors.New("negative base with fractional exponent")
+
+	// ErrLogOfZero is returned for the logarithm of zero.
+	ErrLogOfZero = errors.New("logarithm of zero")
+
+	// ErrLogOfNegative is returned for the real logarithm of a negative
+	// number.
+	ErrLogOfNegative = errors.New("logarithm of negative number")
+
+	// ErrInvalidBase is returned for a logarithm whose base is not
+	// positive or is 1.
+	ErrInvalidBase = errors.New("logarithm base must be positive and not 1")
+
+	// ErrNegativeEvenRoot is returned for an even root, such as the fourth
+	// root, of a negative number.
+	ErrNegativeEvenRoot = errors.New("even root of negative number")
+
+	// ErrUndefined is returned where a function has no real value, such as
+	// the tangent at an odd multiple of π/2 or the arcsine of 2.
+	ErrUndefined = errors.New("undefined result")
+
+	// ErrNoOperands is returned by variadic functions, such as Min, given
+	// no values.
+	ErrNoOperands = errors.New("no operands")
+
+	// ErrNotInteger is returned when an operation that is only defined on
+	// integers, such as GCD, is given a fraction.
+	ErrNotInteger = errors.New("operand must be an integer")
+
+	// ErrNoInverse is returned for the modular inverse of a number that
+	// has a common factor with the modulus.
+	ErrNoInverse = errors.New("no modular inverse")
+
+	// ErrFactorizationFailed is returned when Factorize cannot split a
+	// large composite number within its iteration limit.
+	ErrFactorizationFailed = errors.New("factorization did not finish")
+
+	// ErrOutOfRange is returned when an operand of a Word operation does
+	// not fit in the word, such as 256 or -1 in a uint8.
+	ErrOutOfRange = errors.New("operand out of range for word size")
+
+	// ErrOverflow is returned when finite inputs produce a result too large
+	// to represent.
+	ErrOverflow = errors.New("result overflows")
+
+	// ErrNonFinite is returned when an input is NaN or infinite.
+	ErrNonFinite = errors.New("input is NaN or infinite")
+
+	// ErrNonIntegerExponent is returned when an exact power is requested
+	// with an exponent that is not an integer, since the result would not
+	// be rational.
+	ErrNonIntegerExponent = errors.New("exponent must be an integer for exact arithmetic")
+
+	// ErrResultTooLarge is returned when an exact result would need an
+	// unreasonable amount of memory.
+	ErrResultTooLarge = errors.New("result too large to compute exactly")
+
+	// ErrPrecisionTooLarge is returned when a Number is requested with a
+	// mantissa of more than MaxPrecision bits.
+	ErrPrecisionTooLarge = errors.New("precision too large")
+
+	// ErrNegativeScale is returned when a Decimal result is requested with
+	// a negative number of decimal places.
+	ErrNegativeScale = errors.New("scale must not be negative")
+)
+
+// DomainError describes a calculation that failed because its operands are
+// outside the domain of the operation.
+type DomainError struct {
+	// Op is the name of the operation, e.g. "divide".
+	Op string
+	// Operands holds the operands as passed to the operation: float64,
+	// Number, Rational, Decimal, Integer or complex128 values.
+	Operands []interface{}
+	// Reason is one of the sentinel errors of this package.
+	Reason error
+}
+
+// Error returns the reason for the failure. The message deliberately
+// matches the sentinel so existing output stays stable; use Detail for a
+// message that includes the operation and operands.
+func (e *DomainError) Error() string {
+	return e.Reason.Error()
+}
+
+// Unwrap returns the sentinel reason, allowing errors.Is checks.
+func (e *DomainError) Unwrap() error {
+	return e.Reason
+}
+
+// Detail returns the error with the operation and operands, for example
+// "divide(10, 0): division by zero".
+func (e *DomainError) Detail() string {
+	operands := make([]string, len(e.Operands))
+	for i, o := range e.Operands {
+		operands[i] = fmt.Sprint(o)
+	}
+	return fmt.Sprintf("%s(%s): %v", e.Op, strings.Join(operands, ", "), e.Reason)
+}
+
+// domainError builds a *DomainError for op.
+func domainError(op string, reason error, operands ...interface{}) error {
+	return &DomainError{Op: op, Operands: operands, Reason: reason}
+}
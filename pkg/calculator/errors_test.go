@@ -0,0 +1,50 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDomainError(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func() error
+		op       string
+		operands int
+		reason   error
+		detail   string
+	}{
+		{"divide", func() error { _, err := Divide(10, 0); return err }, "divide", 2, ErrDivisionByZero, "divide(10, 0): division by zero"},
+		{"sqrt", func() error { _, err := SquareRoot(-4); return err }, "sqrt", 1, ErrNegativeSquareRoot, "sqrt(-4): square root of negative number"},
+		{"tan", func() error { _, err := Tan(1.5707963267948966); return err }, "tan", 1, ErrUndefined, "tan(1.5707963267948966): undefined result"},
+		{"power_number", func() error {
+			_, err := PowerNumber(NewNumber(0, 64), NewNumber(-1, 64))
+			return err
+		}, "power", 2, ErrDivisionByZero, "power(0, -1): division by zero"},
+		{"divide_rational", func() error { _, err := DivideRational(NewRational(1, 3), NewRational(0, 1)); return err }, "divide", 2, ErrDivisionByZero, "divide(1/3, 0): division by zero"},
+		{"log_complex", func() error { _, err := LogComplex(0); return err }, "log", 1, ErrLogOfZero, "log((0+0i)): logarithm of zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var domainErr *DomainError
+			if !errors.As(err, &domainErr) {
+				t.Fatalf("error = %v (%T), want *DomainError", err, err)
+			}
+			if !errors.Is(err, tt.reason) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.reason)
+			}
+			if domainErr.Op != tt.op || len(domainErr.Operands) != tt.operands {
+				t.Errorf("DomainError = {Op: %q, Operands: %v}, want op %q with %d operands", domainErr.Op, domainErr.Operands, tt.op, tt.operands)
+			}
+			if err.Error() != tt.reason.Error() {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.reason.Error())
+			}
+			if got := domainErr.Detail(); got != tt.detail {
+				t.Errorf("Detail() = %q, want %q", got, tt.detail)
+			}
+		})
+	}
+}
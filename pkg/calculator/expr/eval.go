@@ -0,0 +1,228 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// ErrUnitConversion is returned when evaluating a conversion such as
+// "3 km in mi" without units.
+var ErrUnitConversion = errors.New("unit conversions need a unit-aware evaluator")
+
+// Func is a function that can be called from an expression.
+type Func struct {
+	// Arity is the number of arguments the function accepts, or -1 if it
+	// accepts any number of arguments.
+	Arity int
+	// Call computes the function result.
+	Call func(args []float64) (float64, error)
+}
+
+// Env holds the variables and functions visible to an expression.
+type Env struct {
+	Vars  map[string]float64
+	Funcs map[string]Func
+}
+
+// EvalError reports a failure while evaluating an expression, together with
+// the column of the node that failed. The underlying error can be retrieved
+// with errors.Unwrap.
+type EvalError struct {
+	Col int
+	Err error
+}
+
+// Error implements the error interface.
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("column %d: %v", e.Col, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *EvalError) Unwrap() error {
+	return e.Err
+}
+
+// NewEnv returns an environment populated with the constants pi and e and
+// the operations of the calculator package, with angles in radians.
+func NewEnv() *Env {
+	return NewAngleEnv(calculator.Radians)
+}
+
+// NewAngleEnv is like NewEnv, but the trigonometric functions take and
+// return angles in the given unit.
+func NewAngleEnv(unit calculator.AngleUnit) *Env {
+	return NewRegistryEnv(calculator.NewDefaultRegistry(unit))
+}
+
+// NewRegistryEnv returns an environment populated with the constants pi and
+// e and a function for each operation of the registry, callable by its name
+// or any alias, e.g. sqrt(16) or pow(2, 3).
+func NewRegistryEnv(registry *calculator.Registry) *Env {
+	env := &Env{
+		Vars: map[string]float64{
+			"pi": math.Pi,
+			"e":  math.E,
+		},
+		Funcs: make(map[string]Func),
+	}
+	for _, op := range registry.Operations() {
+		fn := Func{Arity: op.Arity, Call: op.Func}
+		if op.Variadic {
+			fn = variadic(op)
+		}
+		env.Funcs[op.Name] = fn
+		for _, alias := range op.Aliases {
+			env.Funcs[alias] = fn
+		}
+	}
+	return env
+}
+
+// variadic returns a Func for a variadic operation, which checks the
+// minimum number of arguments itself.
+func variadic(op calculator.Operation) Func {
+	return Func{Arity: -1, Call: func(args []float64) (float64, error) {
+		if !op.Accepts(len(args)) {
+			return 0, fmt.Errorf("%s expects at least %d argument(s), got %d", op.Name, op.Arity, len(args))
+		}
+		return op.Func(args)
+	}}
+}
+
+// Eval parses and evaluates an expression in a fresh default environment.
+func Eval(input string) (float64, error) {
+	node, err := Parse(input)
+	if err != nil {
+		return 0, err
+	}
+	return node.Eval(NewEnv())
+}
+
+// Eval returns the literal value.
+func (n *NumberLit) Eval(env *Env) (float64, error) {
+	return n.Value, nil
+}
+
+// Eval looks up the variable in env.
+func (n *VarRef) Eval(env *Env) (float64, error) {
+	v, ok := env.Vars[n.Name]
+	if !ok {
+		return 0, &EvalError{Col: n.Col, Err: fmt.Errorf("undefined variable %q", n.Name)}
+	}
+	return v, nil
+}
+
+// Eval applies the prefix operator to the evaluated operand.
+func (n *UnaryExpr) Eval(env *Env) (float64, error) {
+	v, err := n.Operand.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	if n.Op == "-" {
+		result, err := calculator.SubtractChecked(0, v)
+		if err != nil {
+			return 0, &EvalError{Col: n.Col, Err: err}
+		}
+		return result, nil
+	}
+	return v, nil
+}
+
+// Eval evaluates both operands and dispatches to the calculator package.
+func (n *BinaryExpr) Eval(env *Env) (float64, error) {
+	a, err := n.Left.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	b, err := n.Right.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.Op {
+	case "+":
+		result, err := calculator.AddChecked(a, b)
+		if err != nil {
+			return 0, &EvalError{Col: n.Col, Err: err}
+		}
+		return result, nil
+	case "-":
+		result, err := calculator.SubtractChecked(a, b)
+		if err != nil {
+			return 0, &EvalError{Col: n.Col, Err: err}
+		}
+		return result, nil
+	case "*":
+		result, err := calculator.MultiplyChecked(a, b)
+		if err != nil {
+			return 0, &EvalError{Col: n.Col, Err: err}
+		}
+		return result, nil
+	case "/":
+		result, err := calculator.Divide(a, b)
+		if err != nil {
+			return 0, &EvalError{Col: n.Col, Err: err}
+		}
+		return result, nil
+	case "^":
+		result, err := calculator.Power(a, b)
+		if err != nil {
+			return 0, &EvalError{Col: n.Col, Err: err}
+		}
+		return result, nil
+	default:
+		return 0, &EvalError{Col: n.Col, Err: fmt.Errorf("unknown operator %q", n.Op)}
+	}
+}
+
+// Eval evaluates the value and stores it in env under the variable name.
+// Function names cannot be assigned to.
+func (n *AssignExpr) Eval(env *Env) (float64, error) {
+	if _, ok := env.Funcs[n.Name]; ok {
+		return 0, &EvalError{Col: n.Col, Err: fmt.Errorf("cannot assign to function %q", n.Name)}
+	}
+	v, err := n.Value.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	if env.Vars == nil {
+		env.Vars = make(map[string]float64)
+	}
+	env.Vars[n.Name] = v
+	return v, nil
+}
+
+// Eval reports an error, since converting between units needs a unit-aware
+// evaluator such as that of the units package.
+func (n *ConvertExpr) Eval(env *Env) (float64, error) {
+	return 0, &EvalError{Col: n.Col, Err: ErrUnitConversion}
+}
+
+// Eval evaluates the arguments and calls the named function from env.
+func (n *CallExpr) Eval(env *Env) (float64, error) {
+	fn, ok := env.Funcs[n.Name]
+	if !ok {
+		return 0, &EvalError{Col: n.Col, Err: fmt.Errorf("undefined function %q", n.Name)}
+	}
+	if fn.Arity >= 0 && len(n.Args) != fn.Arity {
+		return 0, &EvalError{Col: n.Col, Err: fmt.Errorf("%s expects %d argument(s), got %d", n.Name, fn.Arity, len(n.Args))}
+	}
+
+	args := make([]float64, len(n.Args))
+	for i, arg := range n.Args {
+		v, err := arg.Eval(env)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+
+	result, err := fn.Call(args)
+	if err != nil {
+		return 0, &EvalError{Col: n.Col, Err: err}
+	}
+	return result, nil
+}
@@ -0,0 +1,266 @@
+package expr
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Token
+	}{
+		{"number", "42", []Token{{Number, "42", 1}, {EOF, "", 3}}},
+		{"decimal_and_exponent", "1.5e-3", []Token{{Number, "1.5e-3", 1}, {EOF, "", 7}}},
+		{"operators", "1+2", []Token{{Number, "1", 1}, {Operator, "+", 2}, {Number, "2", 3}, {EOF, "", 4}}},
+		{"call", "sqrt(x)", []Token{{Ident, "sqrt", 1}, {LParen, "(", 5}, {Ident, "x", 6}, {RParen, ")", 7}, {EOF, "", 8}}},
+		{"whitespace", " 2 ,3 ", []Token{{Number, "2", 2}, {Comma, ",", 4}, {Number, "3", 5}, {EOF, "", 7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Tokenize(tt.input)
+			if err != nil {
+				t.Fatalf("Tokenize(%q) unexpected error: %v", tt.input, err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Tokenize(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("Tokenize(%q)[%d] = %v, want %v", tt.input, i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"precedence", "1 + 2 * 3", "(1 + (2 * 3))"},
+		{"left_assoc", "8 - 4 - 2", "((8 - 4) - 2)"},
+		{"right_assoc_power", "2 ^ 3 ^ 2", "(2 ^ (3 ^ 2))"},
+		{"parentheses", "2*(3+4)^2", "(2 * ((3 + 4) ^ 2))"},
+		{"unary_minus_below_power", "-2^2", "(-(2 ^ 2))"},
+		{"unary_minus_in_exponent", "2^-1", "(2 ^ (-1))"},
+		{"unary_minus_times", "-2*3", "((-2) * 3)"},
+		{"call_with_args", "pow(2, 1+1)", "pow(2, (1 + 1))"},
+		{"implicit_multiplication", "6 m / 2 s^2", "((6 * m) / (2 * (s ^ 2)))"},
+		{"implicit_before_call", "2 * sqrt(4)", "(2 * sqrt(4))"},
+		{"conversion", "3 km + 200 m in mi", "(((3 * km) + (200 * m)) in mi)"},
+		{"conversion_of_number", "2 + 1 in e", "((2 + 1) in e)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if got := node.String(); got != tt.expected {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		col   int
+	}{
+		{"empty", "", 1},
+		{"dangling_operator", "1 +", 4},
+		{"unclosed_paren", "(1 + 2", 7},
+		{"extra_paren", "1 + 2)", 6},
+		{"bad_character", "2 $ 3", 3},
+		{"missing_operand", "2 * * 3", 5},
+		{"bad_argument_list", "pow(2 3)", 7},
+		{"number_before_call", "2 sqrt(4)", 3},
+		{"missing_unit", "3 km in", 8},
+		{"lonely_dot", "1 + .", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) error = %v, want *SyntaxError", tt.input, err)
+			}
+			if syntaxErr.Col != tt.col {
+				t.Errorf("Parse(%q) error column = %d, want %d (%v)", tt.input, syntaxErr.Col, tt.col, err)
+			}
+		})
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected float64
+	}{
+		{"example", "2*(3+4)^2", 98},
+		{"division", "10 / 4", 2.5},
+		{"unary_minus", "-3 + 5", 2},
+		{"double_negation", "--3", 3},
+		{"right_assoc_power", "2^3^2", 512},
+		{"sqrt", "sqrt(16) + 1", 5},
+		{"trig", "sin(0) + cos(0)", 1},
+		{"constant", "pi", math.Pi},
+		{"nested_calls", "sqrt(pow(3, 2) + 16)", 5},
+		{"inverse_trig", "4 * atan2(1, 1) + asin(1) - acos(0) + atan(0)", math.Pi},
+		{"variadic", "add(1, 2, 3) + max(4, 9, 2) * min(1, 0.5) - mean(2, 4)", 6 + 4.5 - 3},
+		{"scientific", "ln(exp(2)) + log(1000, 10) + log2(8) + root(27, 3) + factorial(4) + hypot(3, 4)", 2 + 3 + 3 + 3 + 24 + 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Eval(tt.input)
+			if err != nil {
+				t.Fatalf("Eval(%q) unexpected error: %v", tt.input, err)
+			}
+			if math.Abs(got-tt.expected) > 1e-12 {
+				t.Errorf("Eval(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		col   int
+	}{
+		{"division_by_zero", "1 / (2 - 2)", 3},
+		{"negative_sqrt", "1 + sqrt(-4)", 5},
+		{"undefined_variable", "x + 1", 1},
+		{"undefined_function", "foo(1)", 1},
+		{"wrong_arity", "sqrt(1, 2)", 1},
+		{"asin_out_of_range", "1 + asin(2)", 5},
+		{"log_of_negative", "2 * ln(-1)", 5},
+		{"variadic_too_few", "1 + add(1)", 5},
+		{"conversion_without_units", "2 + 1 in e", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Eval(tt.input)
+			var evalErr *EvalError
+			if !errors.As(err, &evalErr) {
+				t.Fatalf("Eval(%q) error = %v, want *EvalError", tt.input, err)
+			}
+			if evalErr.Col != tt.col {
+				t.Errorf("Eval(%q) error column = %d, want %d", tt.input, evalErr.Col, tt.col)
+			}
+		})
+	}
+}
+
+func TestAssign(t *testing.T) {
+	env := NewEnv()
+
+	for _, input := range []string{"x = 5", "y = x * 2 + 1"} {
+		node, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", input, err)
+		}
+		if _, ok := node.(*AssignExpr); !ok {
+			t.Fatalf("Parse(%q) = %T, want *AssignExpr", input, node)
+		}
+		if _, err := node.Eval(env); err != nil {
+			t.Fatalf("Eval(%q) unexpected error: %v", input, err)
+		}
+	}
+
+	if env.Vars["x"] != 5 || env.Vars["y"] != 11 {
+		t.Errorf("after assignments x = %v, y = %v, want 5 and 11", env.Vars["x"], env.Vars["y"])
+	}
+
+	node, err := Parse("sqrt = 1")
+	if err != nil {
+		t.Fatalf("Parse(%q) unexpected error: %v", "sqrt = 1", err)
+	}
+	if _, err := node.Eval(env); err == nil {
+		t.Errorf("Eval(%q) expected error assigning to a function", "sqrt = 1")
+	}
+
+	if _, err := Parse("1 = 2"); err == nil {
+		t.Errorf("Parse(%q) expected syntax error", "1 = 2")
+	}
+}
+
+func TestAngleEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected float64
+	}{
+		{"exact_sin", "sin(180)", 0},
+		{"exact_cos", "cos(60)", 0.5},
+		{"tan", "tan(45)", 1},
+		{"asin", "asin(0.5)", 30},
+		{"atan2", "atan2(-1, 0)", -90},
+	}
+
+	env := NewAngleEnv(calculator.Degrees)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			got, err := node.Eval(env)
+			if err != nil {
+				t.Fatalf("Eval(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("Eval(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	node, err := Parse("tan(90)")
+	if err != nil {
+		t.Fatalf("Parse(%q) unexpected error: %v", "tan(90)", err)
+	}
+	if _, err := node.Eval(env); !errors.Is(err, calculator.ErrUndefined) {
+		t.Errorf("Eval(%q) error = %v, want %v", "tan(90)", err, calculator.ErrUndefined)
+	}
+}
+
+func TestRegistryEnv(t *testing.T) {
+	registry := calculator.NewDefaultRegistry(calculator.Radians)
+	err := registry.Register(calculator.Operation{
+		Name:    "double",
+		Aliases: []string{"twice"},
+		Arity:   1,
+		Func:    func(args []float64) (float64, error) { return 2 * args[0], nil },
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	node, err := Parse("double(3) + twice(pow(2, 3)) + sqrt(16)")
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	got, err := node.Eval(NewRegistryEnv(registry))
+	if err != nil {
+		t.Fatalf("Eval() unexpected error: %v", err)
+	}
+	if got != 26 {
+		t.Errorf("Eval() = %v, want 26", got)
+	}
+}
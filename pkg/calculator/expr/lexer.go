@@ -0,0 +1,143 @@
+// Package expr parses and evaluates infix arithmetic expressions on top of the
+// calculator package.
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TokenKind identifies the lexical class of a Token.
+type TokenKind int
+
+// Token kinds produced by Tokenize.
+const (
+	EOF TokenKind = iota
+	Number
+	Ident
+	Operator
+	LParen
+	RParen
+	Comma
+)
+
+// String returns a human readable name for the token kind.
+func (k TokenKind) String() string {
+	switch k {
+	case EOF:
+		return "end of input"
+	case Number:
+		return "number"
+	case Ident:
+		return "identifier"
+	case Operator:
+		return "operator"
+	case LParen:
+		return "'('"
+	case RParen:
+		return "')'"
+	case Comma:
+		return "','"
+	default:
+		return "unknown"
+	}
+}
+
+// Token is a single lexical element of an expression.
+// Col is the 1-based column of the first character of the token.
+type Token struct {
+	Kind TokenKind
+	Text string
+	Col  int
+}
+
+// SyntaxError reports a problem found while tokenizing or parsing an
+// expression, together with the column of the offending token.
+type SyntaxError struct {
+	Col int
+	Msg string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Col, e.Msg)
+}
+
+// operatorChars lists the single-character operators understood by the lexer.
+const operatorChars = "+-*/^="
+
+// Tokenize splits an expression into tokens. The returned slice always ends
+// with an EOF token.
+func Tokenize(input string) ([]Token, error) {
+	var tokens []Token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		col := i + 1
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			i = scanNumber(runes, i)
+			text := string(runes[start:i])
+			if text == "." {
+				return nil, &SyntaxError{Col: col, Msg: "invalid number \".\""}
+			}
+			tokens = append(tokens, Token{Kind: Number, Text: text, Col: col})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: Ident, Text: string(runes[start:i]), Col: col})
+		case strings.ContainsRune(operatorChars, c):
+			tokens = append(tokens, Token{Kind: Operator, Text: string(c), Col: col})
+			i++
+		case c == '(':
+			tokens = append(tokens, Token{Kind: LParen, Text: "(", Col: col})
+			i++
+		case c == ')':
+			tokens = append(tokens, Token{Kind: RParen, Text: ")", Col: col})
+			i++
+		case c == ',':
+			tokens = append(tokens, Token{Kind: Comma, Text: ",", Col: col})
+			i++
+		default:
+			return nil, &SyntaxError{Col: col, Msg: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+
+	tokens = append(tokens, Token{Kind: EOF, Col: len(runes) + 1})
+	return tokens, nil
+}
+
+// scanNumber returns the index just past the number literal starting at i.
+// It accepts digits, an optional fractional part and an optional exponent.
+func scanNumber(runes []rune, i int) int {
+	for i < len(runes) && unicode.IsDigit(runes[i]) {
+		i++
+	}
+	if i < len(runes) && runes[i] == '.' {
+		i++
+		for i < len(runes) && unicode.IsDigit(runes[i]) {
+			i++
+		}
+	}
+	if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+		j := i + 1
+		if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+			j++
+		}
+		if j < len(runes) && unicode.IsDigit(runes[j]) {
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			i = j
+		}
+	}
+	return i
+}
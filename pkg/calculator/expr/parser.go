@@ -0,0 +1,337 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Node is an element of a parsed expression tree.
+type Node interface {
+	// Eval evaluates the node using the variables and functions in env.
+	Eval(env *Env) (float64, error)
+	// String returns the node rendered back as a fully parenthesized expression.
+	String() string
+}
+
+// NumberLit is a numeric literal. Text holds it as written, e.g. "0.1",
+// for evaluators that read literals exactly.
+type NumberLit struct {
+	Value float64
+	Text  string
+	Col   int
+}
+
+// VarRef is a reference to a named variable or constant.
+type VarRef struct {
+	Name string
+	Col  int
+}
+
+// UnaryExpr is a prefix operator applied to an operand.
+type UnaryExpr struct {
+	Op      string
+	Operand Node
+	Col     int
+}
+
+// BinaryExpr is an infix operator applied to two operands.
+type BinaryExpr struct {
+	Op          string
+	Left, Right Node
+	Col         int
+}
+
+// AssignExpr binds the value of an expression to a variable, as in x = 5.
+type AssignExpr struct {
+	Name  string
+	Value Node
+	Col   int
+}
+
+// ConvertExpr converts the value of an expression to a unit, as in
+// "3 km in mi". Only unit-aware evaluators, such as that of the units
+// package, can evaluate it.
+type ConvertExpr struct {
+	Value Node
+	Unit  Node
+	Col   int
+}
+
+// CallExpr is a function call such as sqrt(x).
+type CallExpr struct {
+	Name string
+	Args []Node
+	Col  int
+}
+
+// String returns the literal as written.
+func (n *NumberLit) String() string {
+	return strconv.FormatFloat(n.Value, 'g', -1, 64)
+}
+
+// String returns the variable name.
+func (n *VarRef) String() string {
+	return n.Name
+}
+
+// String returns the unary expression in parentheses.
+func (n *UnaryExpr) String() string {
+	return "(" + n.Op + n.Operand.String() + ")"
+}
+
+// String returns the binary expression in parentheses.
+func (n *BinaryExpr) String() string {
+	return "(" + n.Left.String() + " " + n.Op + " " + n.Right.String() + ")"
+}
+
+// String returns the assignment.
+func (n *AssignExpr) String() string {
+	return n.Name + " = " + n.Value.String()
+}
+
+// String returns the conversion in parentheses.
+func (n *ConvertExpr) String() string {
+	return "(" + n.Value.String() + " in " + n.Unit.String() + ")"
+}
+
+// String returns the call with its arguments.
+func (n *CallExpr) String() string {
+	args := make([]string, len(n.Args))
+	for i, a := range n.Args {
+		args[i] = a.String()
+	}
+	return n.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// binaryOp describes the precedence and associativity of an infix operator.
+type binaryOp struct {
+	prec       int
+	rightAssoc bool
+}
+
+// binaryOps is the operator table used by the precedence-climbing parser.
+var binaryOps = map[string]binaryOp{
+	"+": {prec: 1},
+	"-": {prec: 1},
+	"*": {prec: 2},
+	"/": {prec: 2},
+	"^": {prec: 4, rightAssoc: true},
+}
+
+// unaryPrec binds tighter than multiplication but looser than
+// exponentiation, so -2^2 parses as -(2^2).
+const unaryPrec = 3
+
+// Parse parses an infix expression into an expression tree. An expression
+// of the form "name = expr" parses as an *AssignExpr.
+// Errors are returned as *SyntaxError values carrying the column of the
+// offending token.
+func Parse(input string) (Node, error) {
+	tokens, err := Tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().Kind == EOF {
+		return nil, &SyntaxError{Col: p.peek().Col, Msg: "empty expression"}
+	}
+
+	node, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.Kind != EOF {
+		return nil, unexpected(tok)
+	}
+	return node, nil
+}
+
+// parser holds the state of a single Parse call.
+type parser struct {
+	tokens []Token
+	pos    int
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() Token {
+	tok := p.tokens[p.pos]
+	if tok.Kind != EOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseStatement parses either an assignment or a plain expression.
+func (p *parser) parseStatement() (Node, error) {
+	name := p.peek()
+	if name.Kind == Ident && p.pos+1 < len(p.tokens) {
+		if eq := p.tokens[p.pos+1]; eq.Kind == Operator && eq.Text == "=" {
+			p.next()
+			p.next()
+			value, err := p.parseConversion()
+			if err != nil {
+				return nil, err
+			}
+			return &AssignExpr{Name: name.Text, Value: value, Col: name.Col}, nil
+		}
+	}
+	return p.parseConversion()
+}
+
+// parseConversion parses an expression optionally followed by "in" and a
+// unit to convert it to.
+func (p *parser) parseConversion() (Node, error) {
+	node, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.Kind == Ident && tok.Text == "in" {
+		p.next()
+		unit, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		return &ConvertExpr{Value: node, Unit: unit, Col: tok.Col}, nil
+	}
+	return node, nil
+}
+
+// parseExpr parses a sequence of binary operations whose operators bind at
+// least as tightly as minPrec.
+func (p *parser) parseExpr(minPrec int) (Node, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.Kind != Operator {
+			return lhs, nil
+		}
+		op, ok := binaryOps[tok.Text]
+		if !ok || op.prec < minPrec {
+			return lhs, nil
+		}
+		p.next()
+
+		nextMin := op.prec + 1
+		if op.rightAssoc {
+			nextMin = op.prec
+		}
+		rhs, err := p.parseExpr(nextMin)
+		if err != nil {
+			return nil, err
+		}
+		lhs = &BinaryExpr{Op: tok.Text, Left: lhs, Right: rhs, Col: tok.Col}
+	}
+}
+
+// parseUnary parses an optional prefix sign followed by its operand.
+func (p *parser) parseUnary() (Node, error) {
+	tok := p.peek()
+	if tok.Kind == Operator && (tok.Text == "-" || tok.Text == "+") {
+		p.next()
+		operand, err := p.parseExpr(unaryPrec)
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Op: tok.Text, Operand: operand, Col: tok.Col}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a number, optionally followed by a name it multiplies,
+// a variable, a function call or a parenthesized sub-expression.
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+
+	switch tok.Kind {
+	case Number:
+		v, err := strconv.ParseFloat(tok.Text, 64)
+		if err != nil {
+			return nil, &SyntaxError{Col: tok.Col, Msg: fmt.Sprintf("invalid number %q", tok.Text)}
+		}
+		lit := &NumberLit{Value: v, Text: tok.Text, Col: tok.Col}
+		if next := p.peek(); next.Kind == Ident && next.Text != "in" && p.tokens[p.pos+1].Kind != LParen {
+			// A number followed by a name multiplies them, so "3 km" and
+			// "9.8 m/s^2" read naturally; the name binds like an exponent.
+			// "in" always starts a conversion
+			unit, err := p.parseExpr(binaryOps["^"].prec)
+			if err != nil {
+				return nil, err
+			}
+			return &BinaryExpr{Op: "*", Left: lit, Right: unit, Col: next.Col}, nil
+		}
+		return lit, nil
+	case Ident:
+		if p.peek().Kind == LParen {
+			p.next()
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			return &CallExpr{Name: tok.Text, Args: args, Col: tok.Col}, nil
+		}
+		return &VarRef{Name: tok.Text, Col: tok.Col}, nil
+	case LParen:
+		node, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.Kind != RParen {
+			return nil, &SyntaxError{Col: closing.Col, Msg: fmt.Sprintf("expected ')' to close '(' at column %d, found %s", tok.Col, describe(closing))}
+		}
+		return node, nil
+	default:
+		return nil, unexpected(tok)
+	}
+}
+
+// parseArgs parses a comma separated argument list. The opening parenthesis
+// has already been consumed.
+func (p *parser) parseArgs() ([]Node, error) {
+	var args []Node
+	if p.peek().Kind == RParen {
+		p.next()
+		return args, nil
+	}
+
+	for {
+		arg, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		tok := p.next()
+		switch tok.Kind {
+		case Comma:
+			continue
+		case RParen:
+			return args, nil
+		default:
+			return nil, &SyntaxError{Col: tok.Col, Msg: fmt.Sprintf("expected ',' or ')' in argument list, found %s", describe(tok))}
+		}
+	}
+}
+
+// unexpected builds the error for a token that cannot appear where it was found.
+func unexpected(tok Token) error {
+	return &SyntaxError{Col: tok.Col, Msg: "unexpected " + describe(tok)}
+}
+
+// describe renders a token for use in error messages.
+func describe(tok Token) string {
+	switch tok.Kind {
+	case Number, Ident, Operator:
+		return fmt.Sprintf("%s %q", tok.Kind, tok.Text)
+	default:
+		return tok.Kind.String()
+	}
+}
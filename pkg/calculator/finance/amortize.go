@@ -0,0 +1,75 @@
+package finance
+
+import (
+	"math/big"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Installment is one row of an amortization schedule. All amounts are
+// positive.
+type Installment struct {
+	// Period counts from 1.
+	Period int
+	// Payment is the amount paid, which is Interest plus Principal.
+	Payment calculator.Rational
+	// Interest is the part of the payment that pays interest.
+	Interest calculator.Rational
+	// Principal is the part of the payment that repays the loan.
+	Principal calculator.Rational
+	// Balance is the amount still owed after the payment.
+	Balance calculator.Rational
+}
+
+// Amortize returns the schedule of equal payments at the end of each period
+// that repay a loan of principal in nper periods at rate per period. The
+// payment and each period's interest are rounded to places decimal places,
+// e.g. 2 for cents, and the last payment is adjusted so the balance ends at
+// exactly zero.
+// Returns an error if principal is not positive, rate is -100% or less,
+// nper is not positive or places is negative, and wraps
+// calculator.ErrResultTooLarge if nper is above 100,000.
+func Amortize(principal, rate calculator.Rational, nper, places int) ([]Installment, error) {
+	var reason error
+	switch {
+	case principal.Rat().Sign() <= 0:
+		reason = ErrInvalidAmount
+	case rate.Rat().Cmp(big.NewRat(-1, 1)) <= 0:
+		reason = ErrInvalidRate
+	case nper <= 0:
+		reason = ErrInvalidPeriods
+	case nper > maxSchedulePeriods:
+		reason = calculator.ErrResultTooLarge
+	case places < 0:
+		reason = ErrInvalidPlaces
+	}
+	if reason != nil {
+		return nil, financeError("amortize", reason, principal, rate, nper)
+	}
+	pmt, err := PMT(rate, nper, principal, calculator.NewRational(0, 1), End)
+	if err != nil {
+		return nil, err
+	}
+	payment := round(new(big.Rat).Neg(pmt.Rat()), places)
+
+	r := rate.Rat()
+	balance := principal.Rat()
+	schedule := make([]Installment, nper)
+	for i := range schedule {
+		interest := round(new(big.Rat).Mul(balance, r), places)
+		paid := payment
+		if i == nper-1 {
+			paid = new(big.Rat).Add(balance, interest)
+		}
+		repaid := new(big.Rat).Sub(paid, interest)
+		balance = new(big.Rat).Sub(balance, repaid)
+		schedule[i] = Installment{
+			Period:    i + 1,
+			Payment:   calculator.NewRationalFromRat(paid),
+			Interest:  calculator.NewRationalFromRat(interest),
+			Principal: calculator.NewRationalFromRat(repaid),
+			Balance:   calculator.NewRationalFromRat(balance),
+		}
+	}
+	return schedule, nil
+}
@@ -0,0 +1,83 @@
+package finance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+func TestAmortize(t *testing.T) {
+	schedule, err := Amortize(mustRational(t, "1000"), mustRational(t, "0.01"), 12, 2)
+	if err != nil {
+		t.Fatalf("Amortize unexpected error: %v", err)
+	}
+	if len(schedule) != 12 {
+		t.Fatalf("len(schedule) = %d, want 12", len(schedule))
+	}
+
+	tests := []struct {
+		period                                int
+		payment, interest, principal, balance string
+	}{
+		{1, "88.85", "10.00", "78.85", "921.15"},
+		{2, "88.85", "9.21", "79.64", "841.51"},
+		{11, "88.85", "1.75", "87.10", "87.96"},
+		{12, "88.84", "0.88", "87.96", "0.00"},
+	}
+	for _, tt := range tests {
+		row := schedule[tt.period-1]
+		got := [4]string{
+			row.Payment.Rat().FloatString(2),
+			row.Interest.Rat().FloatString(2),
+			row.Principal.Rat().FloatString(2),
+			row.Balance.Rat().FloatString(2),
+		}
+		want := [4]string{tt.payment, tt.interest, tt.principal, tt.balance}
+		if row.Period != tt.period || got != want {
+			t.Errorf("period %d = %d %v, want %v", tt.period, row.Period, got, want)
+		}
+	}
+	if last := schedule[11].Balance.Rat(); last.Sign() != 0 {
+		t.Errorf("final balance = %s, want exactly 0", last.RatString())
+	}
+}
+
+func TestAmortizeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal string
+		rate      string
+		nper      int
+		places    int
+		err       error
+	}{
+		{"zero_principal", "0", "0.01", 12, 2, ErrInvalidAmount},
+		{"invalid_rate", "1000", "-1", 12, 2, ErrInvalidRate},
+		{"no_periods", "1000", "0.01", 0, 2, ErrInvalidPeriods},
+		{"negative_places", "1000", "0.01", 12, -1, ErrInvalidPlaces},
+		{"too_many_periods", "1000", "0.01", 100000000000, 2, calculator.ErrResultTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Amortize(mustRational(t, tt.principal), mustRational(t, tt.rate), tt.nper, tt.places)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Amortize error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAmortizeZeroRate(t *testing.T) {
+	schedule, err := Amortize(mustRational(t, "100"), mustRational(t, "0"), 3, 2)
+	if err != nil {
+		t.Fatalf("Amortize unexpected error: %v", err)
+	}
+	payments := []string{"33.33", "33.33", "33.34"}
+	for i, row := range schedule {
+		if got := row.Payment.Rat().FloatString(2); got != payments[i] {
+			t.Errorf("payment %d = %s, want %s", i+1, got, payments[i])
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package finance
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/numeric"
+)
+
+// daysPerYear is the length of the year XNPV and XIRR discount over, as in
+// spreadsheets.
+const daysPerYear = 365
+
+// rateGrid holds the trial rates solveRate brackets a root between, denser
+// near the rates that occur in practice.
+var rateGrid = []float64{
+	-0.99, -0.9, -0.75, -0.5, -0.25, -0.1, -0.05, 0,
+	0.05, 0.1, 0.15, 0.25, 0.5, 1, 2, 5, 10, 100, 1000,
+}
+
+// rateGuess is the rate solveRate prefers when several solve the equation.
+const rateGuess = 0.1
+
+// CashFlow is an amount paid or received on a date.
+type CashFlow struct {
+	Date   time.Time
+	Amount calculator.Rational
+}
+
+// NPV returns the net present value at rate per period of cash flows one
+// period apart. The first flow is at the present and is not discounted,
+// unlike the NPV function of most spreadsheets, so NPV(r, flows) is
+// zero exactly when r is the internal rate of return.
+// Returns an error if there are no flows or rate is -100% or less.
+func NPV(rate calculator.Rational, flows []calculator.Rational) (calculator.Rational, error) {
+	if len(flows) == 0 {
+		return calculator.Rational{}, financeError("npv", ErrNoCashFlows, rate)
+	}
+	if rate.Rat().Cmp(big.NewRat(-1, 1)) <= 0 {
+		return calculator.Rational{}, financeError("npv", ErrInvalidRate, rate)
+	}
+	return calculator.NewRationalFromRat(npv(rate.Rat(), flows)), nil
+}
+
+// npv returns the net present value of flows at rate r > -1, evaluated
+// from the last flow back as a polynomial in 1/(1+r).
+func npv(r *big.Rat, flows []calculator.Rational) *big.Rat {
+	discount := new(big.Rat).Inv(one(r))
+	v := new(big.Rat)
+	for i := len(flows) - 1; i >= 0; i-- {
+		v.Mul(v, discount)
+		v.Add(v, flows[i].Rat())
+	}
+	return v
+}
+
+// IRR returns the internal rate of return of cash flows one period apart:
+// the rate per period at which their net present value is zero. When
+// several rates are, it returns the one closest to 10%.
+// Returns an error wrapping ErrNoSignChange unless the flows include both
+// payments and receipts, or ErrNoSolution if no rate above -100% works.
+func IRR(flows []calculator.Rational) (float64, error) {
+	if err := checkSigns("irr", len(flows), func(i int) int { return flows[i].Rat().Sign() }); err != nil {
+		return 0, err
+	}
+	rate, err := solveRate(func(rate float64) float64 {
+		f, _ := npv(new(big.Rat).SetFloat64(rate), flows).Float64()
+		return f
+	})
+	if err != nil {
+		return 0, financeError("irr", err, len(flows))
+	}
+	return rate, nil
+}
+
+// XNPV returns the net present value at an annual rate of cash flows on
+// arbitrary dates, discounted to the earliest date over 365-day years. The
+// powers are fractional, so the result is a float64.
+// Returns an error if there are no flows or rate is -100% or less.
+func XNPV(rate float64, flows []CashFlow) (float64, error) {
+	if len(flows) == 0 {
+		return 0, financeError("xnpv", ErrNoCashFlows, rate)
+	}
+	if rate <= -1 || math.IsNaN(rate) {
+		return 0, financeError("xnpv", ErrInvalidRate, rate)
+	}
+	return xnpv(rate, flows), nil
+}
+
+// xnpv returns the net present value of flows at rate > -1.
+func xnpv(rate float64, flows []CashFlow) float64 {
+	start := flows[0].Date
+	for _, f := range flows {
+		if f.Date.Before(start) {
+			start = f.Date
+		}
+	}
+	var v float64
+	for _, f := range flows {
+		years := f.Date.Sub(start).Hours() / 24 / daysPerYear
+		v += f.Amount.Float64() / math.Pow(1+rate, years)
+	}
+	return v
+}
+
+// XIRR returns the annual internal rate of return of cash flows on
+// arbitrary dates: the rate at which their XNPV is zero. When several rates
+// are, it returns the one closest to 10%.
+// Returns an error wrapping ErrNoSignChange unless the flows include both
+// payments and receipts, or ErrNoSolution if no rate above -100% works.
+func XIRR(flows []CashFlow) (float64, error) {
+	if err := checkSigns("xirr", len(flows), func(i int) int { return flows[i].Amount.Rat().Sign() }); err != nil {
+		return 0, err
+	}
+	rate, err := solveRate(func(rate float64) float64 { return xnpv(rate, flows) })
+	if err != nil {
+		return 0, financeError("xirr", err, len(flows))
+	}
+	return rate, nil
+}
+
+// checkSigns returns an error for op unless the n flows, whose signs are
+// given by sign, include both a payment and a receipt.
+func checkSigns(op string, n int, sign func(i int) int) error {
+	if n == 0 {
+		return financeError(op, ErrNoCashFlows, n)
+	}
+	var payments, receipts bool
+	for i := 0; i < n; i++ {
+		switch sign(i) {
+		case -1:
+			payments = true
+		case 1:
+			receipts = true
+		}
+	}
+	if !payments || !receipts {
+		return financeError(op, ErrNoSignChange, n)
+	}
+	return nil
+}
+
+// solveRate returns a root of f above -100%, found with Brent's method
+// between the trial rates of rateGrid that bracket it. When several
+// brackets do, it uses the one closest to rateGuess.
+// Returns ErrNoSolution if f has the same sign at every trial rate.
+func solveRate(f func(rate float64) float64) (float64, error) {
+	type bracket struct{ lo, hi float64 }
+	var brackets []bracket
+	prev := f(rateGrid[0])
+	for i := 1; i < len(rateGrid); i++ {
+		v := f(rateGrid[i])
+		if v == 0 {
+			return rateGrid[i], nil
+		}
+		if !math.IsNaN(prev) && !math.IsNaN(v) && (prev < 0) != (v < 0) {
+			brackets = append(brackets, bracket{rateGrid[i-1], rateGrid[i]})
+		}
+		prev = v
+	}
+	if len(brackets) == 0 {
+		return 0, ErrNoSolution
+	}
+
+	distance := func(b bracket) float64 {
+		return math.Max(0, math.Max(b.lo-rateGuess, rateGuess-b.hi))
+	}
+	sort.SliceStable(brackets, func(i, j int) bool { return distance(brackets[i]) < distance(brackets[j]) })
+	result, err := numeric.Brent(f, brackets[0].lo, brackets[0].hi, numeric.Options{})
+	if err != nil {
+		return 0, err
+	}
+	return result.X, nil
+}
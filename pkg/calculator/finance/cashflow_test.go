@@ -0,0 +1,140 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// rationals parses each of values or fails the test
+func rationals(t *testing.T, values ...string) []calculator.Rational {
+	t.Helper()
+	rs := make([]calculator.Rational, len(values))
+	for i, v := range values {
+		rs[i] = mustRational(t, v)
+	}
+	return rs
+}
+
+func TestNPV(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  string
+		flows []string
+		want  string
+		err   error
+	}{
+		{"project", "0.1", []string{"-1000", "300", "400", "500"}, "-28000/1331", nil},
+		{"zero_rate", "0", []string{"-1000", "300", "400", "500"}, "200", nil},
+		{"single_flow", "0.1", []string{"42"}, "42", nil},
+		{"no_flows", "0.1", nil, "", ErrNoCashFlows},
+		{"invalid_rate", "-1", []string{"-1000", "1100"}, "", ErrInvalidRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NPV(mustRational(t, tt.rate), rationals(t, tt.flows...))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NPV error = %v, want %v", err, tt.err)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("NPV = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIRR(t *testing.T) {
+	tests := []struct {
+		name  string
+		flows []string
+		want  float64
+		err   error
+	}{
+		{"one_period", []string{"-100", "110"}, 0.1, nil},
+		{"two_periods", []string{"-1000", "0", "1210"}, 0.1, nil},
+		{"project", []string{"-1000", "300", "400", "500"}, 0.0889633947, nil},
+		{"break_even", []string{"-100", "50", "50"}, 0, nil},
+		{"loss", []string{"-100", "30", "30"}, -0.2821091654, nil},
+		{"all_payments", []string{"-100", "-50"}, 0, ErrNoSignChange},
+		{"no_flows", nil, 0, ErrNoCashFlows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IRR(rationals(t, tt.flows...))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("IRR error = %v, want %v", err, tt.err)
+			}
+			if err == nil && math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("IRR = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// cashFlows builds dated cash flows from "YYYY-MM-DD" dates and amounts or
+// fails the test
+func cashFlows(t *testing.T, flows ...[2]string) []CashFlow {
+	t.Helper()
+	result := make([]CashFlow, len(flows))
+	for i, f := range flows {
+		date, err := time.Parse("2006-01-02", f[0])
+		if err != nil {
+			t.Fatalf("time.Parse(%q) unexpected error: %v", f[0], err)
+		}
+		result[i] = CashFlow{Date: date, Amount: mustRational(t, f[1])}
+	}
+	return result
+}
+
+func TestXIRR(t *testing.T) {
+	investment := [][2]string{
+		{"2008-01-01", "-10000"},
+		{"2008-03-01", "2750"},
+		{"2008-10-30", "4250"},
+		{"2009-02-15", "3250"},
+		{"2009-04-01", "2750"},
+	}
+	tests := []struct {
+		name  string
+		flows [][2]string
+		want  float64
+		err   error
+	}{
+		{"investment", investment, 0.373362535, nil},
+		{"one_year", [][2]string{{"2023-01-01", "-100"}, {"2024-01-01", "110"}}, 0.1, nil},
+		{"unsorted", [][2]string{{"2024-01-01", "110"}, {"2023-01-01", "-100"}}, 0.1, nil},
+		{"all_receipts", [][2]string{{"2023-01-01", "100"}, {"2024-01-01", "110"}}, 0, ErrNoSignChange},
+		{"no_flows", nil, 0, ErrNoCashFlows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := XIRR(cashFlows(t, tt.flows...))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("XIRR error = %v, want %v", err, tt.err)
+			}
+			if err == nil && math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("XIRR = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXNPV(t *testing.T) {
+	flows := cashFlows(t, [2]string{"2023-01-01", "-100"}, [2]string{"2024-01-01", "110"})
+	got, err := XNPV(0.1, flows)
+	if err != nil {
+		t.Fatalf("XNPV unexpected error: %v", err)
+	}
+	if math.Abs(got) > 1e-12 {
+		t.Errorf("XNPV(0.1) = %v, want 0", got)
+	}
+	if _, err := XNPV(-1, flows); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("XNPV(-1) error = %v, want %v", err, ErrInvalidRate)
+	}
+}
@@ -0,0 +1,113 @@
+package finance
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Method selects how Depreciate spreads the cost of an asset over its life.
+type Method int
+
+const (
+	// StraightLine charges the same amount every year.
+	StraightLine Method = iota
+	// DecliningBalance charges twice the straight-line rate on the book
+	// value left at the start of each year, and the rest of the
+	// depreciable amount in the last year (double-declining balance).
+	DecliningBalance
+	// SumOfYearsDigits charges the years in the proportions n, n-1, ..., 1
+	// of their sum n(n+1)/2.
+	SumOfYearsDigits
+)
+
+// methodNames maps each Method to its name.
+var methodNames = []string{"straight-line", "declining-balance", "sum-of-years-digits"}
+
+// String returns the name of the method.
+func (m Method) String() string {
+	if m < 0 || int(m) >= len(methodNames) {
+		return fmt.Sprintf("Method(%d)", int(m))
+	}
+	return methodNames[m]
+}
+
+// ParseMethod converts a method name such as "straight-line" into a Method.
+func ParseMethod(name string) (Method, error) {
+	for i, n := range methodNames {
+		if n == name {
+			return Method(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown depreciation method %q (want %s)", name, strings.Join(methodNames, ", "))
+}
+
+// Depreciation is one year of a depreciation schedule.
+type Depreciation struct {
+	// Year counts from 1.
+	Year int
+	// Expense is the depreciation charged in the year.
+	Expense calculator.Rational
+	// Accumulated is the depreciation charged up to the end of the year.
+	Accumulated calculator.Rational
+	// BookValue is the cost less Accumulated.
+	BookValue calculator.Rational
+}
+
+// Depreciate returns the depreciation schedule of an asset bought for cost
+// and worth salvage at the end of its life of life years. The book value
+// never falls below salvage, and reaches it exactly in the last year.
+// Returns an error if cost is not positive, salvage is negative or more
+// than cost, life is not positive or method is unknown, and wraps
+// calculator.ErrResultTooLarge if life is above 100,000.
+func Depreciate(cost, salvage calculator.Rational, life int, method Method) ([]Depreciation, error) {
+	c, s := cost.Rat(), salvage.Rat()
+	var reason error
+	switch {
+	case c.Sign() <= 0:
+		reason = ErrInvalidAmount
+	case s.Sign() < 0 || s.Cmp(c) > 0:
+		reason = ErrInvalidSalvage
+	case life <= 0:
+		reason = ErrInvalidPeriods
+	case life > maxSchedulePeriods:
+		reason = calculator.ErrResultTooLarge
+	}
+	if reason != nil {
+		return nil, financeError("depreciate", reason, cost, salvage, life)
+	}
+	if method < StraightLine || method > SumOfYearsDigits {
+		return nil, fmt.Errorf("unknown depreciation method %v", method)
+	}
+
+	depreciable := new(big.Rat).Sub(c, s)
+	years := big.NewRat(int64(life), 1)
+	digits := big.NewRat(int64(life)*int64(life+1), 2)
+	book := new(big.Rat).Set(c)
+	schedule := make([]Depreciation, life)
+	for i := range schedule {
+		var expense *big.Rat
+		switch method {
+		case StraightLine:
+			expense = new(big.Rat).Quo(depreciable, years)
+		case DecliningBalance:
+			expense = new(big.Rat).Mul(book, big.NewRat(2, int64(life)))
+			if left := new(big.Rat).Sub(book, s); i == life-1 || expense.Cmp(left) > 0 {
+				expense = left
+			}
+		case SumOfYearsDigits:
+			expense = new(big.Rat).Mul(depreciable, big.NewRat(int64(life-i), 1))
+			expense.Quo(expense, digits)
+		}
+		book.Sub(book, expense)
+		schedule[i] = Depreciation{
+			Year:        i + 1,
+			Expense:     calculator.NewRationalFromRat(expense),
+			Accumulated: calculator.NewRationalFromRat(new(big.Rat).Sub(c, book)),
+			BookValue:   calculator.NewRationalFromRat(book),
+		}
+	}
+	return schedule, nil
+}
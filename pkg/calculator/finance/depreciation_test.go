@@ -0,0 +1,63 @@
+package finance
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDepreciate(t *testing.T) {
+	tests := []struct {
+		name          string
+		cost, salvage string
+		life          int
+		method        Method
+		expenses      []string
+		err           error
+	}{
+		{"straight_line", "10000", "1000", 5, StraightLine, []string{"1800", "1800", "1800", "1800", "1800"}, nil},
+		{"straight_line_fractional", "1000", "0", 3, StraightLine, []string{"1000/3", "1000/3", "1000/3"}, nil},
+		{"declining_balance", "10000", "1000", 5, DecliningBalance, []string{"4000", "2400", "1440", "864", "296"}, nil},
+		{"declining_balance_floor", "10000", "5000", 5, DecliningBalance, []string{"4000", "1000", "0", "0", "0"}, nil},
+		{"sum_of_years_digits", "10000", "1000", 5, SumOfYearsDigits, []string{"3000", "2400", "1800", "1200", "600"}, nil},
+		{"zero_cost", "0", "0", 5, StraightLine, nil, ErrInvalidAmount},
+		{"salvage_above_cost", "1000", "2000", 5, StraightLine, nil, ErrInvalidSalvage},
+		{"negative_salvage", "1000", "-1", 5, StraightLine, nil, ErrInvalidSalvage},
+		{"no_life", "1000", "0", 0, StraightLine, nil, ErrInvalidPeriods},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			salvage := mustRational(t, tt.salvage)
+			schedule, err := Depreciate(mustRational(t, tt.cost), salvage, tt.life, tt.method)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Depreciate error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			expenses := make([]string, len(schedule))
+			for i, d := range schedule {
+				expenses[i] = d.Expense.String()
+			}
+			if !reflect.DeepEqual(expenses, tt.expenses) {
+				t.Errorf("expenses = %v, want %v", expenses, tt.expenses)
+			}
+			if last := schedule[len(schedule)-1].BookValue; last.Rat().Cmp(salvage.Rat()) != 0 {
+				t.Errorf("final book value = %s, want %s", last, salvage)
+			}
+		})
+	}
+}
+
+func TestParseMethod(t *testing.T) {
+	for _, m := range []Method{StraightLine, DecliningBalance, SumOfYearsDigits} {
+		got, err := ParseMethod(m.String())
+		if err != nil || got != m {
+			t.Errorf("ParseMethod(%q) = %v, %v, want %v", m.String(), got, err, m)
+		}
+	}
+	if _, err := ParseMethod("accelerated"); err == nil {
+		t.Error("ParseMethod(\"accelerated\") expected an error")
+	}
+}
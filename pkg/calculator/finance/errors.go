@@ -0,0 +1,47 @@
+package finance
+
+import (
+	"errors"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Sentinel errors returned by this package. They are wrapped in a
+// *calculator.DomainError naming the function and its operands, e.g.
+// "pmt(-1.5, 12, 1000, 0): rate must be greater than -100%".
+var (
+	// ErrInvalidRate is returned for an interest or discount rate of -100%
+	// or less, at which money loses all its value.
+	ErrInvalidRate = errors.New("rate must be greater than -100%")
+
+	// ErrInvalidAmount is returned for a loan or asset cost that is not
+	// positive.
+	ErrInvalidAmount = errors.New("amount must be positive")
+
+	// ErrInvalidPeriods is returned for a number of periods that is not
+	// positive.
+	ErrInvalidPeriods = errors.New("number of periods must be positive")
+
+	// ErrNoSolution is returned when no number of periods or rate satisfies
+	// the cash flows, such as a payment too small to ever repay a loan.
+	ErrNoSolution = errors.New("no solution")
+
+	// ErrNoCashFlows is returned for an empty list of cash flows.
+	ErrNoCashFlows = errors.New("no cash flows")
+
+	// ErrNoSignChange is returned by IRR and XIRR for cash flows that are
+	// all payments or all receipts, which have no internal rate of return.
+	ErrNoSignChange = errors.New("cash flows must include both payments and receipts")
+
+	// ErrInvalidPlaces is returned for a negative number of decimal places.
+	ErrInvalidPlaces = errors.New("decimal places must not be negative")
+
+	// ErrInvalidSalvage is returned when the salvage value of an asset is
+	// negative or more than its cost.
+	ErrInvalidSalvage = errors.New("salvage value must be between 0 and the cost")
+)
+
+// financeError builds a *calculator.DomainError for op.
+func financeError(op string, reason error, operands ...interface{}) error {
+	return &calculator.DomainError{Op: op, Operands: operands, Reason: reason}
+}
@@ -0,0 +1,298 @@
+// Package finance provides time-value-of-money calculations: present and
+// future values, loan payments, net present value and internal rate of
+// return, amortization schedules, simple and compound interest and
+// depreciation.
+//
+// Amounts are calculator.Rational values and are computed exactly, so a
+// schedule of 360 payments does not drift by a cent. Only the number of
+// periods and rates of return are float64: they are generally irrational
+// and are found numerically, evaluating the cash flows exactly at each
+// trial rate, or with 256-bit floats when the exact powers would be huge.
+//
+// Exact powers of 1+rate are limited in size, and schedules to 100,000
+// periods; larger inputs are rejected with calculator.ErrResultTooLarge.
+//
+// Signs follow the spreadsheet convention: money received is positive and
+// money paid out is negative, so a loan of 1000 has a present value of 1000
+// and payments of about -88 a month.
+package finance
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// maxExactPowerBits bounds the size of the numerator and denominator of
+// (1+rate)^nper, as PowerRational does, so a typo in nper cannot exhaust
+// memory.
+const maxExactPowerBits = 1 << 24
+
+// maxExactRateBits bounds the size of the exact powers RATE evaluates at
+// each trial rate. Larger powers are evaluated with rateFloatPrec bits
+// instead, since the root finder needs only a float64 residual.
+const (
+	maxExactRateBits = 1 << 16
+	rateFloatPrec    = 256
+)
+
+// maxSchedulePeriods bounds the number of rows of an amortization or
+// depreciation schedule.
+const maxSchedulePeriods = 100000
+
+// Timing says when in each period payments are made.
+type Timing int
+
+const (
+	// End makes payments at the end of each period, as for most loans.
+	End Timing = iota
+	// Begin makes payments at the start of each period, as for rent.
+	Begin
+)
+
+// FV returns the future value after nper periods of a present value pv and
+// a payment pmt each period, at rate per period.
+// Returns an error if rate is -100% or less or nper is not positive.
+func FV(rate calculator.Rational, nper int, pmt, pv calculator.Rational, when Timing) (calculator.Rational, error) {
+	if err := checkTVM("fv", rate, nper, pmt, pv); err != nil {
+		return calculator.Rational{}, err
+	}
+	r := rate.Rat()
+	growth := pow(one(r), nper)
+	fv := new(big.Rat).Mul(pv.Rat(), growth)
+	fv.Add(fv, new(big.Rat).Mul(pmt.Rat(), annuity(r, nper, growth, when)))
+	return calculator.NewRationalFromRat(fv.Neg(fv)), nil
+}
+
+// PV returns the present value of a payment pmt each period for nper
+// periods followed by a future value fv, at rate per period.
+// Returns an error if rate is -100% or less or nper is not positive.
+func PV(rate calculator.Rational, nper int, pmt, fv calculator.Rational, when Timing) (calculator.Rational, error) {
+	if err := checkTVM("pv", rate, nper, pmt, fv); err != nil {
+		return calculator.Rational{}, err
+	}
+	r := rate.Rat()
+	growth := pow(one(r), nper)
+	pv := new(big.Rat).Mul(pmt.Rat(), annuity(r, nper, growth, when))
+	pv.Add(pv, fv.Rat())
+	pv.Quo(pv, growth)
+	return calculator.NewRationalFromRat(pv.Neg(pv)), nil
+}
+
+// PMT returns the payment each period that takes a present value pv to a
+// future value fv in nper periods, at rate per period.
+// Returns an error if rate is -100% or less or nper is not positive.
+func PMT(rate calculator.Rational, nper int, pv, fv calculator.Rational, when Timing) (calculator.Rational, error) {
+	if err := checkTVM("pmt", rate, nper, pv, fv); err != nil {
+		return calculator.Rational{}, err
+	}
+	r := rate.Rat()
+	growth := pow(one(r), nper)
+	pmt := new(big.Rat).Mul(pv.Rat(), growth)
+	pmt.Add(pmt, fv.Rat())
+	pmt.Quo(pmt, annuity(r, nper, growth, when))
+	return calculator.NewRationalFromRat(pmt.Neg(pmt)), nil
+}
+
+// NPER returns the number of periods, usually fractional, in which a
+// payment pmt each period takes a present value pv to a future value fv, at
+// rate per period.
+// Returns an error if rate is -100% or less, or wrapping ErrNoSolution if
+// fv can never be reached, for example because the payments do not cover
+// the interest.
+func NPER(rate, pmt, pv, fv calculator.Rational, when Timing) (float64, error) {
+	r := rate.Rat()
+	if r.Cmp(big.NewRat(-1, 1)) <= 0 {
+		return 0, financeError("nper", ErrInvalidRate, rate, pmt, pv, fv)
+	}
+	if r.Sign() == 0 {
+		// pv + pmt*n + fv = 0
+		if pmt.Rat().Sign() == 0 {
+			return 0, financeError("nper", ErrNoSolution, rate, pmt, pv, fv)
+		}
+		n := new(big.Rat).Add(pv.Rat(), fv.Rat())
+		n.Quo(n, pmt.Rat())
+		f, _ := n.Neg(n).Float64()
+		return checkPeriods(f, rate, pmt, pv, fv)
+	}
+
+	// With a = pmt*(1+r*when)/r, the equation pv*g + a*(g-1) + fv = 0 gives
+	// the growth g = (1+r)^n = (a-fv)/(a+pv).
+	a := new(big.Rat).Mul(pmt.Rat(), timingFactor(r, when))
+	a.Quo(a, r)
+	den := new(big.Rat).Add(a, pv.Rat())
+	if den.Sign() == 0 {
+		return 0, financeError("nper", ErrNoSolution, rate, pmt, pv, fv)
+	}
+	growth := new(big.Rat).Sub(a, fv.Rat())
+	growth.Quo(growth, den)
+	if growth.Sign() <= 0 {
+		return 0, financeError("nper", ErrNoSolution, rate, pmt, pv, fv)
+	}
+	g, _ := growth.Float64()
+	return checkPeriods(math.Log(g)/math.Log1p(rate.Float64()), rate, pmt, pv, fv)
+}
+
+// RATE returns the rate per period at which a payment pmt each period for
+// nper periods takes a present value pv to a future value fv. When several
+// rates do, it returns the one closest to 10%.
+// Returns an error if nper is not positive, wrapping ErrNoSolution if no
+// rate above -100% works, calculator.ErrResultTooLarge if nper is above
+// 100,000, or from the root finder if it fails to converge.
+func RATE(nper int, pmt, pv, fv calculator.Rational, when Timing) (float64, error) {
+	if nper <= 0 {
+		return 0, financeError("rate", ErrInvalidPeriods, nper, pmt, pv, fv)
+	}
+	if nper > maxSchedulePeriods {
+		return 0, financeError("rate", calculator.ErrResultTooLarge, nper, pmt, pv, fv)
+	}
+	residual := func(rate float64) float64 {
+		r := new(big.Rat).SetFloat64(rate)
+		if powerBits(one(r))*nper <= maxExactRateBits {
+			growth := pow(one(r), nper)
+			v := new(big.Rat).Mul(pv.Rat(), growth)
+			v.Add(v, new(big.Rat).Mul(pmt.Rat(), annuity(r, nper, growth, when)))
+			v.Add(v, fv.Rat())
+			// Discount values that grew, which keeps the sign and the root
+			// but not a float64 overflow at high trial rates.
+			if rate > 0 {
+				v.Quo(v, growth)
+			}
+			f, _ := v.Float64()
+			return f
+		}
+		return floatResidual(rate, nper, pmt.Rat(), pv.Rat(), fv.Rat(), when)
+	}
+	rate, err := solveRate(residual)
+	if err != nil {
+		return 0, financeError("rate", err, nper, pmt, pv, fv)
+	}
+	return rate, nil
+}
+
+// checkTVM validates the rate and number of periods of a time-value-of-money
+// function, returning an error for op that lists every operand.
+func checkTVM(op string, rate calculator.Rational, nper int, a, b calculator.Rational) error {
+	if rate.Rat().Cmp(big.NewRat(-1, 1)) <= 0 {
+		return financeError(op, ErrInvalidRate, rate, nper, a, b)
+	}
+	if nper <= 0 {
+		return financeError(op, ErrInvalidPeriods, rate, nper, a, b)
+	}
+	if err := checkPower(one(rate.Rat()), nper); err != nil {
+		return financeError(op, err, rate, nper, a, b)
+	}
+	return nil
+}
+
+// checkPower returns calculator.ErrResultTooLarge if the numerator or
+// denominator of x^n would exceed maxExactPowerBits bits, and nil otherwise.
+func checkPower(x *big.Rat, n int) error {
+	if bits := powerBits(x); bits > 1 && n > maxExactPowerBits/bits {
+		return calculator.ErrResultTooLarge
+	}
+	return nil
+}
+
+// powerBits returns the larger of the bit lengths of the numerator and
+// denominator of x, which x^n multiplies by n.
+func powerBits(x *big.Rat) int {
+	bits := x.Num().BitLen()
+	if d := x.Denom().BitLen(); d > bits {
+		bits = d
+	}
+	return bits
+}
+
+// floatResidual returns pv*(1+rate)^nper + pmt*annuity + fv, the value
+// RATE drives to zero, computed with rateFloatPrec bits of precision and
+// discounted by (1+rate)^nper when rate is positive, as RATE does.
+func floatResidual(rate float64, nper int, pmt, pv, fv *big.Rat, when Timing) float64 {
+	newFloat := func() *big.Float { return new(big.Float).SetPrec(rateFloatPrec) }
+	r := newFloat().SetFloat64(rate)
+	base := newFloat().Add(r, big.NewFloat(1))
+	growth := newFloat().SetInt64(1)
+	for n := nper; n > 0; {
+		if n&1 == 1 {
+			growth.Mul(growth, base)
+		}
+		if n >>= 1; n > 0 {
+			base.Mul(base, base)
+		}
+	}
+
+	annuity := newFloat().SetInt64(int64(nper))
+	if rate != 0 {
+		annuity.Sub(growth, big.NewFloat(1))
+		annuity.Quo(annuity, r)
+		if when == Begin {
+			annuity.Mul(annuity, newFloat().Add(r, big.NewFloat(1)))
+		}
+	}
+	v := newFloat().Mul(newFloat().SetRat(pv), growth)
+	v.Add(v, newFloat().Mul(newFloat().SetRat(pmt), annuity))
+	v.Add(v, newFloat().SetRat(fv))
+	if rate > 0 {
+		v.Quo(v, growth)
+	}
+	f, _ := v.Float64()
+	return f
+}
+
+// checkPeriods returns n if it is a positive number of periods, and an
+// ErrNoSolution error for NPER otherwise.
+func checkPeriods(n float64, rate, pmt, pv, fv calculator.Rational) (float64, error) {
+	if math.IsNaN(n) || math.IsInf(n, 0) || n < 0 {
+		return 0, financeError("nper", ErrNoSolution, rate, pmt, pv, fv)
+	}
+	return n, nil
+}
+
+// annuity returns the future value of a payment of 1 each period for nper
+// periods at rate r, given growth = (1+r)^nper: nper when r is zero and
+// (1+r*when)*(growth-1)/r otherwise.
+func annuity(r *big.Rat, nper int, growth *big.Rat, when Timing) *big.Rat {
+	if r.Sign() == 0 {
+		return new(big.Rat).SetInt64(int64(nper))
+	}
+	a := new(big.Rat).Sub(growth, big.NewRat(1, 1))
+	a.Quo(a, r)
+	return a.Mul(a, timingFactor(r, when))
+}
+
+// timingFactor returns 1+r for payments at the start of each period, which
+// earn one more period of interest, and 1 for payments at the end.
+func timingFactor(r *big.Rat, when Timing) *big.Rat {
+	if when == Begin {
+		return one(r)
+	}
+	return big.NewRat(1, 1)
+}
+
+// one returns 1+r.
+func one(r *big.Rat) *big.Rat {
+	return new(big.Rat).Add(r, big.NewRat(1, 1))
+}
+
+// pow returns x^n for n >= 0 by repeated squaring.
+func pow(x *big.Rat, n int) *big.Rat {
+	result := big.NewRat(1, 1)
+	base := new(big.Rat).Set(x)
+	for n > 0 {
+		if n&1 == 1 {
+			result.Mul(result, base)
+		}
+		if n >>= 1; n > 0 {
+			base.Mul(base, base)
+		}
+	}
+	return result
+}
+
+// round returns x rounded to places decimal places, with halves rounded
+// away from zero.
+func round(x *big.Rat, places int) *big.Rat {
+	rounded, _ := new(big.Rat).SetString(x.FloatString(places))
+	return rounded
+}
@@ -0,0 +1,176 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// mustRational parses a fraction or decimal or fails the test
+func mustRational(t *testing.T, s string) calculator.Rational {
+	t.Helper()
+	r, err := calculator.ParseRational(s)
+	if err != nil {
+		t.Fatalf("ParseRational(%q) unexpected error: %v", s, err)
+	}
+	return r
+}
+
+func TestTimeValueOfMoney(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(rate calculator.Rational, nper int, a, b calculator.Rational, when Timing) (calculator.Rational, error)
+		rate string
+		nper int
+		a, b string
+		when Timing
+		want string
+		err  error
+	}{
+		{"pmt_mortgage", PMT, "5/1200", 360, "200000", "0", End, "-1073.643246", nil},
+		{"pmt_begin", PMT, "5/1200", 360, "200000", "0", Begin, "-1069.188295", nil},
+		{"pmt_zero_rate", PMT, "0", 10, "1000", "0", End, "-100.000000", nil},
+		{"pmt_invalid_rate", PMT, "-1", 10, "1000", "0", End, "", ErrInvalidRate},
+		{"pmt_no_periods", PMT, "0.05", 0, "1000", "0", End, "", ErrInvalidPeriods},
+		{"fv_savings", FV, "0.005", 120, "-100", "-1000", End, "18207.331415", nil},
+		{"fv_begin", FV, "0.005", 120, "-100", "-1000", Begin, "18289.271088", nil},
+		{"fv_zero_rate", FV, "0", 12, "-50", "0", End, "600.000000", nil},
+		{"pv_annuity", PV, "8/1200", 240, "500", "0", End, "-59777.145851", nil},
+		{"pv_lump_sum", PV, "0.05", 10, "0", "-1000", End, "613.913254", nil},
+		{"pv_negative_periods", PV, "0.05", -1, "0", "-1000", End, "", ErrInvalidPeriods},
+		{"pv_too_many_periods", PV, "0.05", 1000000000000, "100", "0", End, "", calculator.ErrResultTooLarge},
+		{"fv_too_many_periods", FV, "1", 100000000, "1", "0", End, "", calculator.ErrResultTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(mustRational(t, tt.rate), tt.nper, mustRational(t, tt.a), mustRational(t, tt.b), tt.when)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if s := got.Rat().FloatString(6); s != tt.want {
+				t.Errorf("result = %s, want %s", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeValueOfMoneyRoundTrip(t *testing.T) {
+	rate, pv, zero := mustRational(t, "7/1200"), mustRational(t, "250000"), mustRational(t, "0")
+	pmt, err := PMT(rate, 300, pv, zero, End)
+	if err != nil {
+		t.Fatalf("PMT unexpected error: %v", err)
+	}
+	back, err := PV(rate, 300, pmt, zero, End)
+	if err != nil {
+		t.Fatalf("PV unexpected error: %v", err)
+	}
+	if back.Rat().Cmp(pv.Rat()) != 0 {
+		t.Errorf("PV(PMT(pv)) = %s, want exactly %s", back, pv)
+	}
+}
+
+func TestNPER(t *testing.T) {
+	tests := []struct {
+		name              string
+		rate, pmt, pv, fv string
+		when              Timing
+		want              float64
+		err               error
+	}{
+		{"loan", "0.01", "-100", "1000", "0", End, 10.588644459423, nil},
+		{"zero_rate", "0", "-100", "1000", "0", End, 10, nil},
+		{"savings_goal", "0.005", "-100", "-1000", "18207.331415", End, 120, nil},
+		{"payment_below_interest", "0.01", "-5", "1000", "0", End, 0, ErrNoSolution},
+		{"no_payment", "0", "0", "1000", "0", End, 0, ErrNoSolution},
+		{"invalid_rate", "-2", "-100", "1000", "0", End, 0, ErrInvalidRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NPER(mustRational(t, tt.rate), mustRational(t, tt.pmt), mustRational(t, tt.pv), mustRational(t, tt.fv), tt.when)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NPER error = %v, want %v", err, tt.err)
+			}
+			if err == nil && math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("NPER = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRATE(t *testing.T) {
+	tests := []struct {
+		name        string
+		nper        int
+		pmt, pv, fv string
+		when        Timing
+		want        float64
+		err         error
+	}{
+		{"mortgage", 360, "-1073.643246", "200000", "0", End, 0.05 / 12, nil},
+		{"begin", 360, "-1069.188295", "200000", "0", Begin, 0.05 / 12, nil},
+		{"lump_sum", 10, "0", "-1000", "2000", End, 0.071773462536293, nil},
+		{"zero_rate", 10, "-100", "1000", "0", End, 0, nil},
+		{"negative_rate", 1, "0", "-100", "90", End, -0.1, nil},
+		{"no_solution", 10, "100", "1000", "0", End, 0, ErrNoSolution},
+		{"no_periods", 0, "-100", "1000", "0", End, 0, ErrInvalidPeriods},
+		{"perpetuity", 100000, "-10", "1000", "0", End, 0.01, nil},
+		{"too_many_periods", 100001, "-10", "1000", "0", End, 0, calculator.ErrResultTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RATE(tt.nper, mustRational(t, tt.pmt), mustRational(t, tt.pv), mustRational(t, tt.fv), tt.when)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("RATE error = %v, want %v", err, tt.err)
+			}
+			if err == nil && math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("RATE = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterest(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal string
+		rate      string
+		periods   int
+		simple    string
+		compound  string
+		err       error
+	}{
+		{"ten_years", "1000", "0.05", 10, "500.000000", "628.894627", nil},
+		{"monthly", "1200", "0.01", 12, "144.000000", "152.190036", nil},
+		{"zero_periods", "1000", "0.05", 0, "0.000000", "0.000000", nil},
+		{"invalid_rate", "1000", "-1", 1, "-1000.000000", "", ErrInvalidRate},
+		{"negative_periods", "1000", "0.05", -1, "-50.000000", "", ErrInvalidPeriods},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, rate := mustRational(t, tt.principal), mustRational(t, tt.rate)
+			simple := SimpleInterest(principal, rate, calculator.NewRational(int64(tt.periods), 1))
+			if s := simple.Rat().FloatString(6); s != tt.simple {
+				t.Errorf("SimpleInterest = %s, want %s", s, tt.simple)
+			}
+
+			compound, err := CompoundInterest(principal, rate, tt.periods)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("CompoundInterest error = %v, want %v", err, tt.err)
+			}
+			if err == nil {
+				if s := compound.Rat().FloatString(6); s != tt.compound {
+					t.Errorf("CompoundInterest = %s, want %s", s, tt.compound)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package finance
+
+import (
+	"math/big"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// SimpleInterest returns the interest on principal at rate per period over
+// periods periods, which may be fractional, when interest is paid on the
+// principal only: principal*rate*periods.
+func SimpleInterest(principal, rate, periods calculator.Rational) calculator.Rational {
+	return calculator.MultiplyRational(calculator.MultiplyRational(principal, rate), periods)
+}
+
+// CompoundInterest returns the interest on principal at rate per period over
+// periods periods when interest is added to the balance at the end of each
+// period and earns interest itself: principal*((1+rate)^periods - 1).
+// Returns an error if rate is -100% or less or periods is negative, and
+// wraps calculator.ErrResultTooLarge if the exact result would be too large.
+func CompoundInterest(principal, rate calculator.Rational, periods int) (calculator.Rational, error) {
+	r := rate.Rat()
+	if r.Cmp(big.NewRat(-1, 1)) <= 0 {
+		return calculator.Rational{}, financeError("compound", ErrInvalidRate, principal, rate, periods)
+	}
+	if periods < 0 {
+		return calculator.Rational{}, financeError("compound", ErrInvalidPeriods, principal, rate, periods)
+	}
+	if err := checkPower(one(r), periods); err != nil {
+		return calculator.Rational{}, financeError("compound", err, principal, rate, periods)
+	}
+	growth := pow(one(r), periods)
+	interest := growth.Sub(growth, big.NewRat(1, 1))
+	return calculator.NewRationalFromRat(interest.Mul(interest, principal.Rat())), nil
+}
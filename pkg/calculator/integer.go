@@ -0,0 +1,443 @@
+package calculator
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+const (
+	// primalityRounds is the number of Miller-Rabin rounds IsPrime runs.
+	// big.Int adds a Baillie-PSW test, which makes the answer exact below
+	// 2^64.
+	primalityRounds = 20
+
+	// trialDivisionLimit is the largest divisor Factorize tries directly
+	// before switching to Pollard's rho.
+	trialDivisionLimit = 1000
+
+	// rhoAttempts is the number of polynomials x^2 + c Pollard's rho tries
+	// before giving up on a composite, and rhoSteps the number of steps it
+	// takes with each one.
+	rhoAttempts = 8
+	rhoSteps    = 1 << 20
+
+	// rhoBatch is the number of steps whose differences Pollard's rho
+	// multiplies together before taking a GCD.
+	rhoBatch = 128
+)
+
+// Integer is an integer of any size backed by big.Int. Operations never
+// modify their operands. The zero value is not usable; create values with
+// NewInteger or ParseInteger.
+type Integer struct {
+	i *big.Int
+}
+
+// NewInteger returns n as an Integer.
+func NewInteger(n int64) Integer {
+	return Integer{i: big.NewInt(n)}
+}
+
+// ParseInteger parses an integer such as "42", "-7" or "1e3", or one written
+// in binary, octal or hexadecimal with a 0b, 0o or 0x prefix, such as
+// "-0xff". A number with a fractional part, such as "2.5", is rejected with
+// an error wrapping ErrNotInteger rather than truncated.
+func ParseInteger(s string) (Integer, error) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(digits) > 2 && digits[0] == '0' {
+		if base, ok := integerPrefixes[strings.ToLower(digits[:2])]; ok {
+			n, ok := new(big.Int).SetString(digits[2:], base)
+			if !ok || len(s)-len(digits) > 1 || strings.ContainsAny(digits[2:3], "+-") {
+				return Integer{}, fmt.Errorf("invalid integer %q", s)
+			}
+			if s[0] == '-' {
+				n.Neg(n)
+			}
+			return Integer{i: n}, nil
+		}
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Integer{}, fmt.Errorf("invalid integer %q", s)
+	}
+	if !r.IsInt() {
+		return Integer{}, fmt.Errorf("%w: %q", ErrNotInteger, s)
+	}
+	return Integer{i: new(big.Int).Set(r.Num())}, nil
+}
+
+// integerPrefixes maps the prefixes ParseInteger accepts to their bases.
+var integerPrefixes = map[string]int{"0b": 2, "0o": 8, "0x": 16}
+
+// Text returns n in the given base, from 2 to 36, with a 0b, 0o or 0x
+// prefix in bases 2, 8 and 16 so that ParseInteger can read it back, e.g.
+// "-0xff". Digits above 9 are lower-case letters.
+func (n Integer) Text(base int) string {
+	prefix := ""
+	for p, b := range integerPrefixes {
+		if b == base {
+			prefix = p
+		}
+	}
+	text := new(big.Int).Abs(n.i).Text(base)
+	if n.i.Sign() < 0 {
+		return "-" + prefix + text
+	}
+	return prefix + text
+}
+
+// Int returns a copy of the underlying big.Int.
+func (n Integer) Int() *big.Int {
+	return new(big.Int).Set(n.i)
+}
+
+// String returns n in decimal.
+func (n Integer) String() string {
+	return n.i.String()
+}
+
+// Factor is a prime factor and the number of times it divides a number.
+type Factor struct {
+	Prime    Integer
+	Exponent int
+}
+
+// String returns the factor as "p" or "p^e".
+func (f Factor) String() string {
+	if f.Exponent == 1 {
+		return f.Prime.String()
+	}
+	return fmt.Sprintf("%s^%d", f.Prime, f.Exponent)
+}
+
+// AddInteger returns the sum of the values. AddInteger of no values is 0.
+func AddInteger(values ...Integer) Integer {
+	sum := new(big.Int)
+	for _, v := range values {
+		sum.Add(sum, v.i)
+	}
+	return Integer{i: sum}
+}
+
+// SubtractInteger returns a - b.
+func SubtractInteger(a, b Integer) Integer {
+	return Integer{i: new(big.Int).Sub(a.i, b.i)}
+}
+
+// MultiplyInteger returns the product of the values. MultiplyInteger of no
+// values is 1.
+func MultiplyInteger(values ...Integer) Integer {
+	product := big.NewInt(1)
+	for _, v := range values {
+		product.Mul(product, v.i)
+	}
+	return Integer{i: product}
+}
+
+// GCDInteger returns the greatest common divisor of the values, which is
+// never negative. GCDInteger(0, 0) is 0.
+// Returns an error if there are no values.
+func GCDInteger(values ...Integer) (Integer, error) {
+	if len(values) == 0 {
+		return Integer{}, domainError("gcd", ErrNoOperands)
+	}
+	result := new(big.Int)
+	for _, v := range values {
+		result.GCD(nil, nil, result, v.i)
+	}
+	return Integer{i: result}, nil
+}
+
+// LCMInteger returns the least common multiple of the values, which is
+// never negative. The LCM of values including 0 is 0.
+// Returns an error if there are no values.
+func LCMInteger(values ...Integer) (Integer, error) {
+	if len(values) == 0 {
+		return Integer{}, domainError("lcm", ErrNoOperands)
+	}
+	result := big.NewInt(1)
+	g := new(big.Int)
+	for _, v := range values {
+		if v.i.Sign() == 0 {
+			return NewInteger(0), nil
+		}
+		g.GCD(nil, nil, result, v.i)
+		result.Mul(result.Quo(result, g), new(big.Int).Abs(v.i))
+	}
+	return Integer{i: result}, nil
+}
+
+// ModPow returns base^exp mod m, between 0 and m - 1. A negative exponent
+// raises the modular inverse of base to -exp.
+// Returns an error if m is zero or negative, or if exp is negative and
+// base has no inverse modulo m.
+func ModPow(base, exp, m Integer) (Integer, error) {
+	if err := checkModulus("modpow", m, base, exp, m); err != nil {
+		return Integer{}, err
+	}
+	b := new(big.Int).Mod(base.i, m.i)
+	e := new(big.Int).Set(exp.i)
+	if e.Sign() < 0 {
+		if b.ModInverse(b, m.i) == nil {
+			return Integer{}, domainError("modpow", ErrNoInverse, base, exp, m)
+		}
+		e.Neg(e)
+	}
+	result := new(big.Int).Exp(b, e, m.i)
+	return Integer{i: result.Mod(result, m.i)}, nil
+}
+
+// ModInverse returns the x between 0 and m - 1 for which a*x mod m is 1.
+// Returns an error if m is zero or negative, or if a and m have a common
+// factor, in which case there is no inverse.
+func ModInverse(a, m Integer) (Integer, error) {
+	if err := checkModulus("modinv", m, a, m); err != nil {
+		return Integer{}, err
+	}
+	if m.i.Cmp(big.NewInt(1)) == 0 {
+		return NewInteger(0), nil
+	}
+	result := new(big.Int).Mod(a.i, m.i)
+	if result.ModInverse(result, m.i) == nil {
+		return Integer{}, domainError("modinv", ErrNoInverse, a, m)
+	}
+	return Integer{i: result}, nil
+}
+
+// checkModulus reports an error for op unless m is positive.
+func checkModulus(op string, m Integer, operands ...interface{}) error {
+	switch m.i.Sign() {
+	case 0:
+		return domainError(op, ErrDivisionByZero, operands...)
+	case -1:
+		return domainError(op, ErrUndefined, operands...)
+	}
+	return nil
+}
+
+// IsPrime reports whether n is prime, using the Miller-Rabin test. Numbers
+// below 2, including negative numbers, are not prime.
+func IsPrime(n Integer) bool {
+	return n.i.ProbablyPrime(primalityRounds)
+}
+
+// NextPrime returns the smallest prime greater than n.
+func NextPrime(n Integer) Integer {
+	two := big.NewInt(2)
+	if n.i.Cmp(two) < 0 {
+		return Integer{i: two}
+	}
+	candidate := new(big.Int).Add(n.i, big.NewInt(1))
+	if candidate.Bit(0) == 0 && candidate.Cmp(two) != 0 {
+		candidate.Add(candidate, big.NewInt(1))
+	}
+	for !candidate.ProbablyPrime(primalityRounds) {
+		candidate.Add(candidate, two)
+	}
+	return Integer{i: candidate}
+}
+
+// PrevPrime returns the largest prime less than n.
+// Returns an error if n is 2 or less, since there is no such prime.
+func PrevPrime(n Integer) (Integer, error) {
+	two := big.NewInt(2)
+	if n.i.Cmp(two) <= 0 {
+		return Integer{}, domainError("prevprime", ErrUndefined, n)
+	}
+	candidate := new(big.Int).Sub(n.i, big.NewInt(1))
+	if candidate.Cmp(two) == 0 {
+		return Integer{i: candidate}, nil
+	}
+	if candidate.Bit(0) == 0 {
+		candidate.Sub(candidate, big.NewInt(1))
+	}
+	for !candidate.ProbablyPrime(primalityRounds) {
+		candidate.Sub(candidate, two)
+	}
+	return Integer{i: candidate}, nil
+}
+
+// Factorize returns the prime factorization of n in increasing order of
+// the primes, found by trial division and then Pollard's rho. The
+// factorization of 1 is empty.
+// Returns an error if n is below 1, or if Pollard's rho cannot split a
+// composite factor within its iteration limit, which happens when n is the
+// product of two very large primes.
+func Factorize(n Integer) ([]Factor, error) {
+	if n.i.Sign() <= 0 {
+		return nil, domainError("factor", ErrUndefined, n)
+	}
+
+	var primes []*big.Int
+	rest := new(big.Int).Set(n.i)
+	quotient, remainder := new(big.Int), new(big.Int)
+	for d := int64(2); d <= trialDivisionLimit; d++ {
+		divisor := big.NewInt(d)
+		for {
+			quotient.QuoRem(rest, divisor, remainder)
+			if remainder.Sign() != 0 {
+				break
+			}
+			primes = append(primes, divisor)
+			rest.Set(quotient)
+		}
+	}
+
+	pending := []*big.Int{rest}
+	for len(pending) > 0 {
+		m := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		switch {
+		case m.Cmp(big.NewInt(1)) == 0:
+		case m.ProbablyPrime(primalityRounds):
+			primes = append(primes, m)
+		default:
+			d := pollardRho(m)
+			if d == nil {
+				return nil, domainError("factor", ErrFactorizationFailed, n)
+			}
+			pending = append(pending, d, new(big.Int).Quo(m, d))
+		}
+	}
+
+	sort.Slice(primes, func(i, j int) bool { return primes[i].Cmp(primes[j]) < 0 })
+	var factors []Factor
+	for _, p := range primes {
+		if k := len(factors) - 1; k >= 0 && factors[k].Prime.i.Cmp(p) == 0 {
+			factors[k].Exponent++
+			continue
+		}
+		factors = append(factors, Factor{Prime: Integer{i: p}, Exponent: 1})
+	}
+	return factors, nil
+}
+
+// pollardRho returns a non-trivial factor of the odd composite n, or nil if
+// none was found within the iteration limit.
+func pollardRho(n *big.Int) *big.Int {
+	for c := int64(1); c <= rhoAttempts; c++ {
+		if d := rho(n, big.NewInt(c)); d != nil {
+			return d
+		}
+	}
+	return nil
+}
+
+// rho runs Floyd's cycle finding on x^2 + c mod n, taking GCDs of batches
+// of differences to save work.
+func rho(n, c *big.Int) *big.Int {
+	one := big.NewInt(1)
+	step := func(v *big.Int) { v.Mul(v, v).Add(v, c).Mod(v, n) }
+
+	x, y := big.NewInt(2), big.NewInt(2)
+	savedX, savedY := new(big.Int), new(big.Int)
+	product, diff, d := big.NewInt(1), new(big.Int), new(big.Int)
+	for i := 0; i < rhoSteps; i += rhoBatch {
+		savedX.Set(x)
+		savedY.Set(y)
+		for j := 0; j < rhoBatch; j++ {
+			step(x)
+			step(y)
+			step(y)
+			product.Mul(product, diff.Sub(x, y).Abs(diff)).Mod(product, n)
+		}
+		d.GCD(nil, nil, product, n)
+		if d.Cmp(one) == 0 {
+			continue
+		}
+		if d.Cmp(n) != 0 {
+			return d
+		}
+
+		// The batch overshot; repeat its steps one at a time
+		x.Set(savedX)
+		y.Set(savedY)
+		for j := 0; j < rhoBatch; j++ {
+			step(x)
+			step(y)
+			step(y)
+			d.GCD(nil, nil, diff.Sub(x, y).Abs(diff), n)
+			if d.Cmp(one) != 0 {
+				break
+			}
+		}
+		if d.Cmp(n) == 0 {
+			// The sequence cycled without exposing a factor
+			return nil
+		}
+		return d
+	}
+	return nil
+}
+
+// Totient returns Euler's totient of n, the number of integers from 1 to n
+// that have no common factor with n.
+// Returns the same errors as Factorize.
+func Totient(n Integer) (Integer, error) {
+	if n.i.Sign() <= 0 {
+		return Integer{}, domainError("totient", ErrUndefined, n)
+	}
+	factors, err := Factorize(n)
+	if err != nil {
+		return Integer{}, err
+	}
+	result := big.NewInt(1)
+	for _, f := range factors {
+		p := f.Prime.i
+		result.Mul(result, new(big.Int).Sub(p, big.NewInt(1)))
+		result.Mul(result, new(big.Int).Exp(p, big.NewInt(int64(f.Exponent-1)), nil))
+	}
+	return Integer{i: result}, nil
+}
+
+// Binomial returns the binomial coefficient "n choose k", extended to
+// negative n by C(n, k) = (-1)^k C(k - n - 1, k). It is 0 if k is negative
+// or, for non-negative n, greater than n.
+// Returns an error if the result would be unreasonably large.
+func Binomial(n, k Integer) (Integer, error) {
+	if k.i.Sign() < 0 {
+		return NewInteger(0), nil
+	}
+	top := n.i
+	negative := false
+	if n.i.Sign() < 0 {
+		top = new(big.Int).Sub(k.i, n.i)
+		top.Sub(top, big.NewInt(1))
+		negative = k.i.Bit(0) == 1
+	}
+	if !top.IsInt64() || !k.i.IsInt64() {
+		return Integer{}, domainError("binomial", ErrResultTooLarge, n, k)
+	}
+	nn, kk := top.Int64(), k.i.Int64()
+	if kk > nn {
+		return NewInteger(0), nil
+	}
+	if nn-kk < kk {
+		kk = nn - kk
+	}
+	if kk > 0 && kk > maxExactPowerBits/int64(big.NewInt(nn).BitLen()) {
+		return Integer{}, domainError("binomial", ErrResultTooLarge, n, k)
+	}
+
+	result := new(big.Int).Binomial(nn, kk)
+	if negative {
+		result.Neg(result)
+	}
+	return Integer{i: result}, nil
+}
+
+// FactorialInteger returns n! exactly.
+// Returns an error if n is negative or the result would be unreasonably
+// large.
+func FactorialInteger(n Integer) (Integer, error) {
+	if n.i.Sign() < 0 {
+		return Integer{}, domainError("factorial", ErrUndefined, n)
+	}
+	if bits := n.i.BitLen(); !n.i.IsInt64() || bits > 1 && n.i.Int64() > maxExactPowerBits/int64(bits) {
+		return Integer{}, domainError("factorial", ErrResultTooLarge, n)
+	}
+	return Integer{i: new(big.Int).MulRange(1, n.i.Int64())}, nil
+}
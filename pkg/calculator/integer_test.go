@@ -0,0 +1,207 @@
+package calculator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// mustParseInteger parses an integer or fails the test
+func mustParseInteger(t *testing.T, s string) Integer {
+	t.Helper()
+	n, err := ParseInteger(s)
+	if err != nil {
+		t.Fatalf("ParseInteger(%q) unexpected error: %v", s, err)
+	}
+	return n
+}
+
+// mustParseIntegers parses integers or fails the test
+func mustParseIntegers(t *testing.T, values []string) []Integer {
+	t.Helper()
+	integers := make([]Integer, len(values))
+	for i, s := range values {
+		integers[i] = mustParseInteger(t, s)
+	}
+	return integers
+}
+
+func TestParseInteger(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		notInteger  bool
+		expectedErr bool
+	}{
+		{"integer", "42", "42", false, false},
+		{"negative", "-7", "-7", false, false},
+		{"huge", "123456789012345678901234567890", "123456789012345678901234567890", false, false},
+		{"exponent", "1e3", "1000", false, false},
+		{"integral_decimal", "2.0", "2", false, false},
+		{"hex", "0xff", "255", false, false},
+		{"hex_upper", "0X1F", "31", false, false},
+		{"negative_binary", "-0b101", "-5", false, false},
+		{"octal", "0o17", "15", false, false},
+		{"leading_zero_is_decimal", "010", "10", false, false},
+		{"hex_bad_digit", "0xg", "", false, true},
+		{"hex_inner_sign", "0x-1", "", false, true},
+		{"fraction", "2.5", "", true, true},
+		{"fraction_slash", "1/3", "", true, true},
+		{"invalid", "abc", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInteger(tt.input)
+			if tt.expectedErr {
+				if err == nil {
+					t.Fatalf("ParseInteger(%q) expected error, got %s", tt.input, got)
+				}
+				if errors.Is(err, ErrNotInteger) != tt.notInteger {
+					t.Errorf("ParseInteger(%q) error = %v, want ErrNotInteger: %v", tt.input, err, tt.notInteger)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseInteger(%q) unexpected error: %v", tt.input, err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("ParseInteger(%q) = %s, want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIntegerFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(args []Integer) (Integer, error)
+		args     []string
+		expected string
+		err      error
+	}{
+		{"gcd", func(a []Integer) (Integer, error) { return GCDInteger(a...) }, []string{"12", "-18", "30"}, "6", nil},
+		{"gcd_huge", func(a []Integer) (Integer, error) { return GCDInteger(a...) }, []string{"1000000000000000000000", "1500000000000000000000"}, "500000000000000000000", nil},
+		{"gcd_none", func(a []Integer) (Integer, error) { return GCDInteger(a...) }, nil, "", ErrNoOperands},
+		{"lcm", func(a []Integer) (Integer, error) { return LCMInteger(a...) }, []string{"4", "-6", "10"}, "60", nil},
+		{"lcm_zero", func(a []Integer) (Integer, error) { return LCMInteger(a...) }, []string{"4", "0"}, "0", nil},
+		{"lcm_beyond_float", func(a []Integer) (Integer, error) { return LCMInteger(a...) }, []string{"18446744073709551616", "3"}, "55340232221128654848", nil},
+		{"modpow", func(a []Integer) (Integer, error) { return ModPow(a[0], a[1], a[2]) }, []string{"4", "13", "497"}, "445", nil},
+		{"modpow_negative_base", func(a []Integer) (Integer, error) { return ModPow(a[0], a[1], a[2]) }, []string{"-2", "3", "5"}, "2", nil},
+		{"modpow_negative_exponent", func(a []Integer) (Integer, error) { return ModPow(a[0], a[1], a[2]) }, []string{"3", "-1", "7"}, "5", nil},
+		{"modpow_large", func(a []Integer) (Integer, error) { return ModPow(a[0], a[1], a[2]) }, []string{"2", "1000000", "1000000007"}, "235042059", nil},
+		{"modpow_zero_modulus", func(a []Integer) (Integer, error) { return ModPow(a[0], a[1], a[2]) }, []string{"2", "3", "0"}, "", ErrDivisionByZero},
+		{"modpow_negative_modulus", func(a []Integer) (Integer, error) { return ModPow(a[0], a[1], a[2]) }, []string{"2", "3", "-5"}, "", ErrUndefined},
+		{"modpow_no_inverse", func(a []Integer) (Integer, error) { return ModPow(a[0], a[1], a[2]) }, []string{"2", "-1", "4"}, "", ErrNoInverse},
+		{"modinv", func(a []Integer) (Integer, error) { return ModInverse(a[0], a[1]) }, []string{"3", "11"}, "4", nil},
+		{"modinv_negative", func(a []Integer) (Integer, error) { return ModInverse(a[0], a[1]) }, []string{"-3", "11"}, "7", nil},
+		{"modinv_modulus_one", func(a []Integer) (Integer, error) { return ModInverse(a[0], a[1]) }, []string{"5", "1"}, "0", nil},
+		{"modinv_not_coprime", func(a []Integer) (Integer, error) { return ModInverse(a[0], a[1]) }, []string{"6", "9"}, "", ErrNoInverse},
+		{"modinv_zero_modulus", func(a []Integer) (Integer, error) { return ModInverse(a[0], a[1]) }, []string{"3", "0"}, "", ErrDivisionByZero},
+		{"nextprime", func(a []Integer) (Integer, error) { return NextPrime(a[0]), nil }, []string{"13"}, "17", nil},
+		{"nextprime_small", func(a []Integer) (Integer, error) { return NextPrime(a[0]), nil }, []string{"-5"}, "2", nil},
+		{"nextprime_two", func(a []Integer) (Integer, error) { return NextPrime(a[0]), nil }, []string{"2"}, "3", nil},
+		{"nextprime_large", func(a []Integer) (Integer, error) { return NextPrime(a[0]), nil }, []string{"1000000000000"}, "1000000000039", nil},
+		{"prevprime", func(a []Integer) (Integer, error) { return PrevPrime(a[0]) }, []string{"17"}, "13", nil},
+		{"prevprime_three", func(a []Integer) (Integer, error) { return PrevPrime(a[0]) }, []string{"3"}, "2", nil},
+		{"prevprime_none", func(a []Integer) (Integer, error) { return PrevPrime(a[0]) }, []string{"2"}, "", ErrUndefined},
+		{"totient", func(a []Integer) (Integer, error) { return Totient(a[0]) }, []string{"36"}, "12", nil},
+		{"totient_one", func(a []Integer) (Integer, error) { return Totient(a[0]) }, []string{"1"}, "1", nil},
+		{"totient_prime", func(a []Integer) (Integer, error) { return Totient(a[0]) }, []string{"1000000007"}, "1000000006", nil},
+		{"totient_zero", func(a []Integer) (Integer, error) { return Totient(a[0]) }, []string{"0"}, "", ErrUndefined},
+		{"binomial", func(a []Integer) (Integer, error) { return Binomial(a[0], a[1]) }, []string{"10", "3"}, "120", nil},
+		{"binomial_large", func(a []Integer) (Integer, error) { return Binomial(a[0], a[1]) }, []string{"100", "50"}, "100891344545564193334812497256", nil},
+		{"binomial_k_above_n", func(a []Integer) (Integer, error) { return Binomial(a[0], a[1]) }, []string{"3", "5"}, "0", nil},
+		{"binomial_negative_k", func(a []Integer) (Integer, error) { return Binomial(a[0], a[1]) }, []string{"5", "-1"}, "0", nil},
+		{"binomial_negative_n", func(a []Integer) (Integer, error) { return Binomial(a[0], a[1]) }, []string{"-4", "3"}, "-20", nil},
+		{"binomial_too_large", func(a []Integer) (Integer, error) { return Binomial(a[0], a[1]) }, []string{"1000000000000", "500000000000"}, "", ErrResultTooLarge},
+		{"factorial", func(a []Integer) (Integer, error) { return FactorialInteger(a[0]) }, []string{"25"}, "15511210043330985984000000", nil},
+		{"factorial_zero", func(a []Integer) (Integer, error) { return FactorialInteger(a[0]) }, []string{"0"}, "1", nil},
+		{"factorial_negative", func(a []Integer) (Integer, error) { return FactorialInteger(a[0]) }, []string{"-1"}, "", ErrUndefined},
+		{"factorial_too_large", func(a []Integer) (Integer, error) { return FactorialInteger(a[0]) }, []string{"100000000"}, "", ErrResultTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(mustParseIntegers(t, tt.args))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("%s(%v) error = %v, want %v", tt.name, tt.args, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s(%v) unexpected error: %v", tt.name, tt.args, err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("%s(%v) = %s, want %s", tt.name, tt.args, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsPrime(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"-7", false},
+		{"0", false},
+		{"1", false},
+		{"2", true},
+		{"97", true},
+		{"561", false}, // Carmichael number
+		{"1000000007", true},
+		{"170141183460469231731687303715884105727", true}, // 2^127 - 1
+		{"170141183460469231731687303715884105729", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsPrime(mustParseInteger(t, tt.input)); got != tt.expected {
+				t.Errorf("IsPrime(%s) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFactorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		err      error
+	}{
+		{"one", "1", "", nil},
+		{"prime", "97", "97", nil},
+		{"small", "360", "2^3 * 3^2 * 5", nil},
+		{"semiprime", "1000036000099", "1000003 * 1000033", nil},
+		{"fermat", "18446744073709551617", "274177 * 67280421310721", nil},
+		{"prime_power", "1000006000009", "1000003^2", nil},
+		{"zero", "0", "", ErrUndefined},
+		{"negative", "-12", "", ErrUndefined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factors, err := Factorize(mustParseInteger(t, tt.input))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Factorize(%s) error = %v, want %v", tt.input, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Factorize(%s) unexpected error: %v", tt.input, err)
+			}
+			parts := make([]string, len(factors))
+			for i, f := range factors {
+				parts[i] = f.String()
+			}
+			if got := strings.Join(parts, " * "); got != tt.expected {
+				t.Errorf("Factorize(%s) = %s, want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
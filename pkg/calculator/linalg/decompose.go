@@ -0,0 +1,353 @@
+package linalg
+
+import (
+	"math"
+)
+
+// LU is the LU decomposition with partial pivoting of a square matrix A,
+// satisfying P*A = L*U where L is unit lower triangular and U is upper
+// triangular.
+type LU struct {
+	lu       Matrix // L below the diagonal, U on and above it
+	pivot    []int  // row i of P*A is row pivot[i] of A
+	sign     float64
+	singular bool
+}
+
+// QR is the Householder QR decomposition of a matrix A with at least as
+// many rows as columns, satisfying A = Q*R where Q is orthogonal and R is
+// upper triangular.
+type QR struct {
+	q, r Matrix
+}
+
+// DecomposeLU computes the LU decomposition of a square matrix. A singular
+// matrix can still be decomposed; IsSingular reports it.
+func DecomposeLU(a Matrix) (LU, error) {
+	if a.rows == 0 {
+		return LU{}, shapeError("lu", ErrEmpty, a)
+	}
+	if !a.IsSquare() {
+		return LU{}, shapeError("lu", ErrNotSquare, a)
+	}
+
+	n := a.rows
+	lu := a.clone()
+	pivot := make([]int, n)
+	for i := range pivot {
+		pivot[i] = i
+	}
+	sign := 1.0
+	singular := false
+	tol := singularTolerance(a)
+
+	for k := 0; k < n; k++ {
+		// Choose the largest remaining element of the column as the pivot
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu.At(i, k)) > math.Abs(lu.At(p, k)) {
+				p = i
+			}
+		}
+		if p != k {
+			swapRows(lu, p, k)
+			pivot[p], pivot[k] = pivot[k], pivot[p]
+			sign = -sign
+		}
+
+		pkk := lu.At(k, k)
+		if math.Abs(pkk) <= tol {
+			singular = true
+		}
+		if pkk == 0 {
+			// The rest of the column is already zero
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			factor := lu.At(i, k) / pkk
+			lu.set(i, k, factor)
+			for j := k + 1; j < n; j++ {
+				lu.set(i, j, lu.At(i, j)-factor*lu.At(k, j))
+			}
+		}
+	}
+	return LU{lu: lu, pivot: pivot, sign: sign, singular: singular}, nil
+}
+
+// L returns the unit lower triangular factor.
+func (d LU) L() Matrix {
+	n := d.lu.rows
+	l := Identity(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			l.set(i, j, d.lu.At(i, j))
+		}
+	}
+	return l
+}
+
+// U returns the upper triangular factor.
+func (d LU) U() Matrix {
+	n := d.lu.rows
+	u := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			u.set(i, j, d.lu.At(i, j))
+		}
+	}
+	return u
+}
+
+// P returns the permutation matrix.
+func (d LU) P() Matrix {
+	n := len(d.pivot)
+	p := Zeros(n, n)
+	for i, row := range d.pivot {
+		p.set(i, row, 1)
+	}
+	return p
+}
+
+// Pivot returns the row permutation: row i of P*A is row Pivot()[i] of A.
+func (d LU) Pivot() []int {
+	return append([]int(nil), d.pivot...)
+}
+
+// IsSingular reports whether the matrix is singular to working precision.
+func (d LU) IsSingular() bool {
+	return d.singular
+}
+
+// Det returns the determinant of the decomposed matrix.
+func (d LU) Det() float64 {
+	det := d.sign
+	for i := 0; i < d.lu.rows; i++ {
+		det *= d.lu.At(i, i)
+	}
+	return det
+}
+
+// Solve returns x such that A*x = b.
+func (d LU) Solve(b Vector) (Vector, error) {
+	n := d.lu.rows
+	if len(b) != n {
+		return nil, shapeError("solve", ErrDimensionMismatch, d.lu, b)
+	}
+	if d.singular {
+		return nil, shapeError("solve", ErrSingular, d.lu, b)
+	}
+
+	// Forward substitution with L, applying the row permutation to b
+	x := make(Vector, n)
+	for i := 0; i < n; i++ {
+		sum := b[d.pivot[i]]
+		for j := 0; j < i; j++ {
+			sum -= d.lu.At(i, j) * x[j]
+		}
+		x[i] = sum
+	}
+	// Back substitution with U
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for j := i + 1; j < n; j++ {
+			sum -= d.lu.At(i, j) * x[j]
+		}
+		x[i] = sum / d.lu.At(i, i)
+	}
+	return x, nil
+}
+
+// DecomposeQR computes the QR decomposition of a matrix with at least as
+// many rows as columns using Householder reflections.
+func DecomposeQR(a Matrix) (QR, error) {
+	if a.rows == 0 {
+		return QR{}, shapeError("qr", ErrEmpty, a)
+	}
+	if a.rows < a.cols {
+		return QR{}, shapeError("qr", ErrDimensionMismatch, a)
+	}
+
+	m, n := a.rows, a.cols
+	r := a.clone()
+	q := Identity(m)
+	for k := 0; k < n && k < m-1; k++ {
+		// Reflect column k below the diagonal onto the first axis
+		v := make(Vector, m-k)
+		for i := range v {
+			v[i] = r.At(k+i, k)
+		}
+		norm := v.Norm(2)
+		if norm == 0 {
+			continue
+		}
+		if v[0] > 0 {
+			norm = -norm
+		}
+		v[0] -= norm
+		vnorm := v.Norm(2)
+		for i := range v {
+			v[i] /= vnorm
+		}
+
+		// R = H*R, touching only rows k and below
+		for j := 0; j < n; j++ {
+			s := 0.0
+			for i := range v {
+				s += v[i] * r.At(k+i, j)
+			}
+			for i := range v {
+				r.set(k+i, j, r.At(k+i, j)-2*v[i]*s)
+			}
+		}
+		// Q = Q*H, touching only columns k and beyond
+		for i := 0; i < m; i++ {
+			s := 0.0
+			for j := range v {
+				s += q.At(i, k+j) * v[j]
+			}
+			for j := range v {
+				q.set(i, k+j, q.At(i, k+j)-2*s*v[j])
+			}
+		}
+	}
+
+	// Clear the rounding noise below the diagonal, and make the diagonal
+	// of R non-negative so the decomposition of a full-rank matrix is unique
+	for i := 0; i < m; i++ {
+		for j := 0; j < i && j < n; j++ {
+			r.set(i, j, 0)
+		}
+		if i < n && r.At(i, i) < 0 {
+			for j := i; j < n; j++ {
+				r.set(i, j, -r.At(i, j))
+			}
+			for k := 0; k < m; k++ {
+				q.set(k, i, -q.At(k, i))
+			}
+		}
+	}
+	return QR{q: q, r: r}, nil
+}
+
+// Q returns the orthogonal factor.
+func (d QR) Q() Matrix {
+	return d.q.clone()
+}
+
+// R returns the upper triangular factor.
+func (d QR) R() Matrix {
+	return d.r.clone()
+}
+
+// Solve returns the least-squares solution x minimizing |A*x - b|. For a
+// square matrix this is the exact solution. It returns ErrSingular if the
+// columns of A are linearly dependent.
+func (d QR) Solve(b Vector) (Vector, error) {
+	m, n := d.r.rows, d.r.cols
+	if len(b) != m {
+		return nil, shapeError("solve", ErrDimensionMismatch, d.r, b)
+	}
+	tol := singularTolerance(d.r)
+	for i := 0; i < n; i++ {
+		if math.Abs(d.r.At(i, i)) <= tol {
+			return nil, shapeError("solve", ErrSingular, d.r, b)
+		}
+	}
+
+	// x = R⁻¹ Qᵀ b, using only the first n rows of R
+	qtb, _ := MultiplyVector(Transpose(d.q), b)
+	x := make(Vector, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := qtb[i]
+		for j := i + 1; j < n; j++ {
+			sum -= d.r.At(i, j) * x[j]
+		}
+		x[i] = sum / d.r.At(i, i)
+	}
+	return x, nil
+}
+
+// Determinant returns the determinant of a square matrix. A singular
+// matrix has a determinant of zero, or close to it, rather than an error.
+func Determinant(a Matrix) (float64, error) {
+	lu, err := DecomposeLU(a)
+	if err != nil {
+		return 0, relabel(err, "det")
+	}
+	return lu.Det(), nil
+}
+
+// Inverse returns the inverse of a square matrix, or ErrSingular if it has
+// none.
+func Inverse(a Matrix) (Matrix, error) {
+	lu, err := DecomposeLU(a)
+	if err != nil {
+		return Matrix{}, relabel(err, "inverse")
+	}
+	if lu.IsSingular() {
+		return Matrix{}, shapeError("inverse", ErrSingular, a)
+	}
+
+	n := a.rows
+	inv := Zeros(n, n)
+	e := make(Vector, n)
+	for j := 0; j < n; j++ {
+		for i := range e {
+			e[i] = 0
+		}
+		e[j] = 1
+		col, _ := lu.Solve(e)
+		for i, x := range col {
+			inv.set(i, j, x)
+		}
+	}
+	return inv, nil
+}
+
+// Solve returns x such that a*x = b. A square system is solved by LU
+// decomposition; an overdetermined one, with more rows than columns, is
+// solved in the least-squares sense by QR decomposition.
+func Solve(a Matrix, b Vector) (Vector, error) {
+	if a.rows != len(b) {
+		return nil, shapeError("solve", ErrDimensionMismatch, a, b)
+	}
+	if a.IsSquare() {
+		lu, err := DecomposeLU(a)
+		if err != nil {
+			return nil, relabel(err, "solve")
+		}
+		if lu.IsSingular() {
+			return nil, shapeError("solve", ErrSingular, a, b)
+		}
+		return lu.Solve(b)
+	}
+
+	qr, err := DecomposeQR(a)
+	if err != nil {
+		return nil, shapeError("solve", ErrDimensionMismatch, a, b)
+	}
+	x, err := qr.Solve(b)
+	if err != nil {
+		return nil, shapeError("solve", ErrSingular, a, b)
+	}
+	return x, nil
+}
+
+// singularTolerance returns the magnitude below which a pivot of m is
+// treated as zero: n * machine epsilon * the largest element.
+func singularTolerance(m Matrix) float64 {
+	n := m.rows
+	if m.cols > n {
+		n = m.cols
+	}
+	return float64(n) * 0x1p-52 * m.maxAbs()
+}
+
+// swapRows exchanges rows i and j of m in place.
+func swapRows(m Matrix, i, j int) {
+	ri := m.data[i*m.cols : (i+1)*m.cols]
+	rj := m.data[j*m.cols : (j+1)*m.cols]
+	for k := range ri {
+		ri[k], rj[k] = rj[k], ri[k]
+	}
+}
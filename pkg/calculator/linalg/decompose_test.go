@@ -0,0 +1,198 @@
+package linalg
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDecomposeLU(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     [][]float64
+		singular bool
+	}{
+		{"needs_pivoting", [][]float64{{0, 2, 1}, {1, 1, 1}, {2, 1, 0}}, false},
+		{"well_conditioned", [][]float64{{4, 3}, {6, 3}}, false},
+		{"singular", [][]float64{{1, 2}, {2, 4}}, true},
+		{"zero", [][]float64{{0, 0}, {0, 0}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := mustMatrix(t, tt.rows)
+			lu, err := DecomposeLU(a)
+			if err != nil {
+				t.Fatalf("DecomposeLU error = %v", err)
+			}
+			if lu.IsSingular() != tt.singular {
+				t.Errorf("IsSingular() = %v, want %v", lu.IsSingular(), tt.singular)
+			}
+
+			pa, _ := Multiply(lu.P(), a)
+			product, _ := Multiply(lu.L(), lu.U())
+			if !matricesClose(pa, product) {
+				t.Errorf("P*A = %v, L*U = %v", pa.ToRows(), product.ToRows())
+			}
+		})
+	}
+
+	if _, err := DecomposeLU(mustMatrix(t, [][]float64{{1, 2, 3}})); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("DecomposeLU of 1x3 error = %v, want %v", err, ErrNotSquare)
+	}
+}
+
+func TestDecomposeQR(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]float64
+	}{
+		{"square", [][]float64{{12, -51, 4}, {6, 167, -68}, {-4, 24, -41}}},
+		{"tall", [][]float64{{1, 1}, {1, 2}, {1, 3}}},
+		{"rank_deficient", [][]float64{{1, 2}, {2, 4}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := mustMatrix(t, tt.rows)
+			qr, err := DecomposeQR(a)
+			if err != nil {
+				t.Fatalf("DecomposeQR error = %v", err)
+			}
+			q, r := qr.Q(), qr.R()
+
+			product, _ := Multiply(q, r)
+			if !matricesClose(product, a) {
+				t.Errorf("Q*R = %v, want %v", product.ToRows(), a.ToRows())
+			}
+			qtq, _ := Multiply(Transpose(q), q)
+			if !matricesClose(qtq, Identity(q.Rows())) {
+				t.Errorf("QᵀQ = %v, want the identity", qtq.ToRows())
+			}
+			for i := 0; i < r.Rows(); i++ {
+				for j := 0; j < i && j < r.Cols(); j++ {
+					if r.At(i, j) != 0 {
+						t.Errorf("R[%d][%d] = %v, want 0", i, j, r.At(i, j))
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := DecomposeQR(mustMatrix(t, [][]float64{{1, 2, 3}})); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("DecomposeQR of 1x3 error = %v, want %v", err, ErrDimensionMismatch)
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	tests := []struct {
+		name        string
+		rows        [][]float64
+		expected    float64
+		expectError error
+	}{
+		{"one_by_one", [][]float64{{-3}}, -3, nil},
+		{"two_by_two", [][]float64{{1, 2}, {3, 4}}, -2, nil},
+		{"permuted", [][]float64{{0, 1}, {1, 0}}, -1, nil},
+		{"three_by_three", [][]float64{{6, 1, 1}, {4, -2, 5}, {2, 8, 7}}, -306, nil},
+		{"singular", [][]float64{{1, 2}, {2, 4}}, 0, nil},
+		{"not_square", [][]float64{{1, 2}}, 0, ErrNotSquare},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Determinant(mustMatrix(t, tt.rows))
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("Determinant(%v) error = %v, want %v", tt.rows, err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && math.Abs(got-tt.expected) > tolerance {
+				t.Errorf("Determinant(%v) = %v, want %v", tt.rows, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInverse(t *testing.T) {
+	tests := []struct {
+		name        string
+		rows        [][]float64
+		expected    [][]float64
+		expectError error
+	}{
+		{"two_by_two", [][]float64{{4, 7}, {2, 6}}, [][]float64{{0.6, -0.7}, {-0.2, 0.4}}, nil},
+		{"identity", [][]float64{{1, 0}, {0, 1}}, [][]float64{{1, 0}, {0, 1}}, nil},
+		{"singular", [][]float64{{1, 2}, {2, 4}}, nil, ErrSingular},
+		{"nearly_singular", [][]float64{{1, 1}, {1, 1 + 1e-17}}, nil, ErrSingular},
+		{"not_square", [][]float64{{1, 2, 3}, {4, 5, 6}}, nil, ErrNotSquare},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Inverse(mustMatrix(t, tt.rows))
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("Inverse(%v) error = %v, want %v", tt.rows, err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && !matricesClose(got, mustMatrix(t, tt.expected)) {
+				t.Errorf("Inverse(%v) = %v, want %v", tt.rows, got.ToRows(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		rows        [][]float64
+		b           Vector
+		expected    Vector
+		expectError error
+	}{
+		{"square", [][]float64{{2, 1, -1}, {-3, -1, 2}, {-2, 1, 2}}, Vector{8, -11, -3}, Vector{2, 3, -1}, nil},
+		{"needs_pivoting", [][]float64{{0, 1}, {1, 0}}, Vector{2, 3}, Vector{3, 2}, nil},
+		{"least_squares", [][]float64{{1, 0}, {1, 1}, {1, 2}}, Vector{1, 2, 4}, Vector{5.0 / 6, 1.5}, nil},
+		{"singular", [][]float64{{1, 2}, {2, 4}}, Vector{1, 2}, nil, ErrSingular},
+		{"dependent_columns", [][]float64{{1, 2}, {2, 4}, {3, 6}}, Vector{1, 2, 3}, nil, ErrSingular},
+		{"wrong_length", [][]float64{{1, 0}, {0, 1}}, Vector{1, 2, 3}, nil, ErrDimensionMismatch},
+		{"underdetermined", [][]float64{{1, 2, 3}}, Vector{1}, nil, ErrDimensionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Solve(mustMatrix(t, tt.rows), tt.b)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("Solve error = %v, want %v", err, tt.expectError)
+				return
+			}
+			if tt.expectError != nil {
+				return
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Solve = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.expected[i]) > tolerance {
+					t.Errorf("Solve = %v, want %v", got, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestDecomposeQRPositiveDiagonal(t *testing.T) {
+	qr, err := DecomposeQR(mustMatrix(t, [][]float64{{3, 1}, {4, 2}}))
+	if err != nil {
+		t.Fatalf("DecomposeQR error = %v", err)
+	}
+	r := qr.R()
+	for i := 0; i < r.Cols(); i++ {
+		if r.At(i, i) < 0 {
+			t.Errorf("R[%d][%d] = %v, want a non-negative diagonal", i, i, r.At(i, i))
+		}
+	}
+	if got := r.At(0, 0); math.Abs(got-5) > tolerance {
+		t.Errorf("R[0][0] = %v, want 5", got)
+	}
+}
@@ -0,0 +1,67 @@
+package linalg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Sentinel errors returned by this package, always wrapped in a
+// *calculator.DomainError whose operands are the shapes of the inputs, e.g.
+// "multiply(2x3, 2x3): dimension mismatch".
+var (
+	// ErrDimensionMismatch is returned when the shapes of the operands are
+	// incompatible, such as adding a 2x2 matrix to a 3x3 matrix.
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+
+	// ErrNotSquare is returned by operations that need a square matrix.
+	ErrNotSquare = errors.New("matrix is not square")
+
+	// ErrSingular is returned when a matrix has no inverse, or is so close
+	// to singular that the result would be meaningless.
+	ErrSingular = errors.New("matrix is singular")
+
+	// ErrRagged is returned when the rows given to NewMatrix differ in
+	// length.
+	ErrRagged = errors.New("rows have different lengths")
+
+	// ErrEmpty is returned when a matrix or vector has no elements.
+	ErrEmpty = errors.New("matrix is empty")
+)
+
+// shapeError builds a *calculator.DomainError for op, describing each
+// operand by its shape.
+func shapeError(op string, reason error, operands ...shaped) error {
+	shapes := make([]interface{}, len(operands))
+	for i, o := range operands {
+		shapes[i] = o.shape()
+	}
+	return &calculator.DomainError{Op: op, Operands: shapes, Reason: reason}
+}
+
+// shaped is implemented by Matrix and Vector so errors can describe them.
+type shaped interface {
+	shape() string
+}
+
+func (m Matrix) shape() string {
+	return fmt.Sprintf("%dx%d", m.rows, m.cols)
+}
+
+func (v Vector) shape() string {
+	return fmt.Sprintf("vector(%d)", len(v))
+}
+
+// relabel returns err with the operation name of its *calculator.DomainError
+// replaced by op, so errors from helper operations name the function the
+// caller used.
+func relabel(err error, op string) error {
+	var domainErr *calculator.DomainError
+	if errors.As(err, &domainErr) {
+		relabelled := *domainErr
+		relabelled.Op = op
+		return &relabelled
+	}
+	return err
+}
@@ -0,0 +1,227 @@
+// Package linalg provides dense matrices and vectors of float64 values with
+// the usual arithmetic, decompositions and solvers. Operations never modify
+// their operands.
+package linalg
+
+import (
+	"math"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Matrix is a dense matrix stored in row-major order. The zero value is an
+// empty 0x0 matrix; create matrices with NewMatrix, Zeros or Identity.
+type Matrix struct {
+	rows, cols int
+	data       []float64
+}
+
+// NewMatrix returns a matrix with a copy of the given rows. Every row must
+// have the same, non-zero length.
+func NewMatrix(rows [][]float64) (Matrix, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return Matrix{}, &calculator.DomainError{Op: "matrix", Reason: ErrEmpty}
+	}
+	m := Zeros(len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return Matrix{}, &calculator.DomainError{Op: "matrix", Operands: []interface{}{i + 1}, Reason: ErrRagged}
+		}
+		for _, x := range row {
+			if math.IsNaN(x) || math.IsInf(x, 0) {
+				return Matrix{}, &calculator.DomainError{Op: "matrix", Reason: calculator.ErrNonFinite}
+			}
+		}
+		copy(m.data[i*m.cols:], row)
+	}
+	return m, nil
+}
+
+// Zeros returns a rows x cols matrix of zeros.
+func Zeros(rows, cols int) Matrix {
+	return Matrix{rows: rows, cols: cols, data: make([]float64, rows*cols)}
+}
+
+// Identity returns the n x n identity matrix.
+func Identity(n int) Matrix {
+	m := Zeros(n, n)
+	for i := 0; i < n; i++ {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+// Rows returns the number of rows.
+func (m Matrix) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns.
+func (m Matrix) Cols() int {
+	return m.cols
+}
+
+// At returns the element in row i and column j, counting from 0.
+func (m Matrix) At(i, j int) float64 {
+	return m.data[i*m.cols+j]
+}
+
+// Row returns a copy of row i.
+func (m Matrix) Row(i int) Vector {
+	return append(Vector(nil), m.data[i*m.cols:(i+1)*m.cols]...)
+}
+
+// Col returns a copy of column j.
+func (m Matrix) Col(j int) Vector {
+	v := make(Vector, m.rows)
+	for i := range v {
+		v[i] = m.At(i, j)
+	}
+	return v
+}
+
+// ToRows returns the elements as a slice of rows.
+func (m Matrix) ToRows() [][]float64 {
+	rows := make([][]float64, m.rows)
+	for i := range rows {
+		rows[i] = m.Row(i)
+	}
+	return rows
+}
+
+// IsSquare reports whether m has as many rows as columns.
+func (m Matrix) IsSquare() bool {
+	return m.rows == m.cols
+}
+
+// set assigns the element in row i and column j. Only used on matrices
+// under construction.
+func (m Matrix) set(i, j int, x float64) {
+	m.data[i*m.cols+j] = x
+}
+
+// clone returns a deep copy of m.
+func (m Matrix) clone() Matrix {
+	return Matrix{rows: m.rows, cols: m.cols, data: append([]float64(nil), m.data...)}
+}
+
+// Add returns the element-wise sum of two matrices of the same shape.
+func Add(a, b Matrix) (Matrix, error) {
+	if a.rows != b.rows || a.cols != b.cols {
+		return Matrix{}, shapeError("add", ErrDimensionMismatch, a, b)
+	}
+	sum := Zeros(a.rows, a.cols)
+	for i := range sum.data {
+		sum.data[i] = a.data[i] + b.data[i]
+	}
+	return sum, nil
+}
+
+// Subtract returns the element-wise difference of two matrices of the same
+// shape.
+func Subtract(a, b Matrix) (Matrix, error) {
+	if a.rows != b.rows || a.cols != b.cols {
+		return Matrix{}, shapeError("subtract", ErrDimensionMismatch, a, b)
+	}
+	diff := Zeros(a.rows, a.cols)
+	for i := range diff.data {
+		diff.data[i] = a.data[i] - b.data[i]
+	}
+	return diff, nil
+}
+
+// Scale returns m with every element multiplied by k.
+func Scale(m Matrix, k float64) Matrix {
+	scaled := m.clone()
+	for i := range scaled.data {
+		scaled.data[i] *= k
+	}
+	return scaled
+}
+
+// Multiply returns the matrix product a*b. The number of columns of a must
+// equal the number of rows of b.
+func Multiply(a, b Matrix) (Matrix, error) {
+	if a.cols != b.rows {
+		return Matrix{}, shapeError("multiply", ErrDimensionMismatch, a, b)
+	}
+	product := Zeros(a.rows, b.cols)
+	for i := 0; i < a.rows; i++ {
+		for k := 0; k < a.cols; k++ {
+			aik := a.At(i, k)
+			for j := 0; j < b.cols; j++ {
+				product.data[i*b.cols+j] += aik * b.At(k, j)
+			}
+		}
+	}
+	return product, nil
+}
+
+// MultiplyVector returns the matrix-vector product m*v. The length of v
+// must equal the number of columns of m.
+func MultiplyVector(m Matrix, v Vector) (Vector, error) {
+	if m.cols != len(v) {
+		return nil, shapeError("multiply", ErrDimensionMismatch, m, v)
+	}
+	product := make(Vector, m.rows)
+	for i := range product {
+		product[i] = dot(m.data[i*m.cols:(i+1)*m.cols], v)
+	}
+	return product, nil
+}
+
+// Transpose returns the transpose of m.
+func Transpose(m Matrix) Matrix {
+	t := Zeros(m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.set(j, i, m.At(i, j))
+		}
+	}
+	return t
+}
+
+// Trace returns the sum of the diagonal of a square matrix.
+func Trace(m Matrix) (float64, error) {
+	if !m.IsSquare() {
+		return 0, shapeError("trace", ErrNotSquare, m)
+	}
+	sum := 0.0
+	for i := 0; i < m.rows; i++ {
+		sum += m.At(i, i)
+	}
+	return sum, nil
+}
+
+// FrobeniusNorm returns the square root of the sum of the squares of the
+// elements of m.
+func FrobeniusNorm(m Matrix) float64 {
+	return Vector(m.data).Norm(2)
+}
+
+// OneNorm returns the maximum absolute column sum of m.
+func OneNorm(m Matrix) float64 {
+	return InfNorm(Transpose(m))
+}
+
+// InfNorm returns the maximum absolute row sum of m.
+func InfNorm(m Matrix) float64 {
+	max := 0.0
+	for i := 0; i < m.rows; i++ {
+		if s := m.Row(i).Norm(1); s > max {
+			max = s
+		}
+	}
+	return max
+}
+
+// maxAbs returns the largest absolute value of the elements of m.
+func (m Matrix) maxAbs() float64 {
+	max := 0.0
+	for _, x := range m.data {
+		if a := math.Abs(x); a > max {
+			max = a
+		}
+	}
+	return max
+}
@@ -0,0 +1,228 @@
+package linalg
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+const tolerance = 1e-9
+
+// mustMatrix returns the matrix with the given rows or fails the test.
+func mustMatrix(t *testing.T, rows [][]float64) Matrix {
+	t.Helper()
+	m, err := NewMatrix(rows)
+	if err != nil {
+		t.Fatalf("NewMatrix(%v) error = %v", rows, err)
+	}
+	return m
+}
+
+// matricesClose reports whether a and b have the same shape and elements
+// within tolerance.
+func matricesClose(a, b Matrix) bool {
+	if a.rows != b.rows || a.cols != b.cols {
+		return false
+	}
+	for i := range a.data {
+		if math.Abs(a.data[i]-b.data[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNewMatrix(t *testing.T) {
+	tests := []struct {
+		name        string
+		rows        [][]float64
+		expectError error
+	}{
+		{"square", [][]float64{{1, 2}, {3, 4}}, nil},
+		{"row", [][]float64{{1, 2, 3}}, nil},
+		{"empty", nil, ErrEmpty},
+		{"empty_row", [][]float64{{}}, ErrEmpty},
+		{"ragged", [][]float64{{1, 2}, {3}}, ErrRagged},
+		{"nan", [][]float64{{1, math.NaN()}}, calculator.ErrNonFinite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMatrix(tt.rows)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("NewMatrix(%v) error = %v, want %v", tt.rows, err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && !reflect.DeepEqual(m.ToRows(), tt.rows) {
+				t.Errorf("NewMatrix(%v).ToRows() = %v", tt.rows, m.ToRows())
+			}
+		})
+	}
+}
+
+func TestNewMatrixCopiesRows(t *testing.T) {
+	rows := [][]float64{{1, 2}, {3, 4}}
+	m := mustMatrix(t, rows)
+	rows[0][0] = 99
+	if got := m.At(0, 0); got != 1 {
+		t.Errorf("At(0, 0) = %v after changing the input, want 1", got)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := mustMatrix(t, [][]float64{{1, 2}, {3, 4}})
+	b := mustMatrix(t, [][]float64{{5, 6}, {7, 8}})
+	wide := mustMatrix(t, [][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	tests := []struct {
+		name        string
+		fn          func(Matrix, Matrix) (Matrix, error)
+		a, b        Matrix
+		expected    [][]float64
+		expectError error
+	}{
+		{"add", Add, a, b, [][]float64{{6, 8}, {10, 12}}, nil},
+		{"add_mismatch", Add, a, wide, nil, ErrDimensionMismatch},
+		{"subtract", Subtract, b, a, [][]float64{{4, 4}, {4, 4}}, nil},
+		{"subtract_mismatch", Subtract, wide, a, nil, ErrDimensionMismatch},
+		{"multiply", Multiply, a, b, [][]float64{{19, 22}, {43, 50}}, nil},
+		{"multiply_rectangular", Multiply, a, wide, [][]float64{{9, 12, 15}, {19, 26, 33}}, nil},
+		{"multiply_mismatch", Multiply, wide, a, nil, ErrDimensionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.a, tt.b)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("error = %v, want %v", err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && !reflect.DeepEqual(got.ToRows(), tt.expected) {
+				t.Errorf("got %v, want %v", got.ToRows(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestShapeErrorDetail(t *testing.T) {
+	a := mustMatrix(t, [][]float64{{1, 2, 3}, {4, 5, 6}})
+	_, err := Multiply(a, a)
+
+	var domainErr *calculator.DomainError
+	if !errors.As(err, &domainErr) {
+		t.Fatalf("Multiply error = %v, want a *calculator.DomainError", err)
+	}
+	if got, want := domainErr.Detail(), "multiply(2x3, 2x3): dimension mismatch"; got != want {
+		t.Errorf("Detail() = %q, want %q", got, want)
+	}
+}
+
+func TestMultiplyVector(t *testing.T) {
+	m := mustMatrix(t, [][]float64{{1, 2}, {3, 4}, {5, 6}})
+
+	got, err := MultiplyVector(m, Vector{1, -1})
+	if err != nil {
+		t.Fatalf("MultiplyVector error = %v", err)
+	}
+	if want := (Vector{-1, -1, -1}); !reflect.DeepEqual(got, want) {
+		t.Errorf("MultiplyVector = %v, want %v", got, want)
+	}
+
+	if _, err := MultiplyVector(m, Vector{1, 2, 3}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("MultiplyVector with 3 elements error = %v, want %v", err, ErrDimensionMismatch)
+	}
+}
+
+func TestTransposeAndScale(t *testing.T) {
+	m := mustMatrix(t, [][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	if got, want := Transpose(m).ToRows(), [][]float64{{1, 4}, {2, 5}, {3, 6}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Transpose = %v, want %v", got, want)
+	}
+	if got, want := Scale(m, 2).ToRows(), [][]float64{{2, 4, 6}, {8, 10, 12}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scale = %v, want %v", got, want)
+	}
+	if got := m.At(0, 0); got != 1 {
+		t.Errorf("Scale modified its operand: At(0, 0) = %v", got)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	got, err := Trace(mustMatrix(t, [][]float64{{1, 2}, {3, 4}}))
+	if err != nil || got != 5 {
+		t.Errorf("Trace = %v, %v, want 5, nil", got, err)
+	}
+	if _, err := Trace(mustMatrix(t, [][]float64{{1, 2}})); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("Trace of 1x2 error = %v, want %v", err, ErrNotSquare)
+	}
+}
+
+func TestMatrixNorms(t *testing.T) {
+	m := mustMatrix(t, [][]float64{{1, -2}, {-3, 4}})
+
+	tests := []struct {
+		name     string
+		fn       func(Matrix) float64
+		expected float64
+	}{
+		{"frobenius", FrobeniusNorm, math.Sqrt(30)},
+		{"one", OneNorm, 6},
+		{"inf", InfNorm, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(m); math.Abs(got-tt.expected) > tolerance {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVectorNorm(t *testing.T) {
+	v := Vector{3, -4}
+
+	tests := []struct {
+		name     string
+		p        float64
+		expected float64
+	}{
+		{"one", 1, 7},
+		{"two", 2, 5},
+		{"three", 3, math.Cbrt(91)},
+		{"inf", math.Inf(1), 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.Norm(tt.p); math.Abs(got-tt.expected) > tolerance {
+				t.Errorf("Norm(%v) = %v, want %v", tt.p, got, tt.expected)
+			}
+		})
+	}
+
+	if got := (Vector{1e200, 1e200}).Norm(2); math.IsInf(got, 0) {
+		t.Errorf("Norm(2) of large elements overflowed")
+	}
+}
+
+func TestDotAndCross(t *testing.T) {
+	got, err := Dot(Vector{1, 2, 3}, Vector{4, 5, 6})
+	if err != nil || got != 32 {
+		t.Errorf("Dot = %v, %v, want 32, nil", got, err)
+	}
+	if _, err := Dot(Vector{1, 2}, Vector{1, 2, 3}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Dot mismatch error = %v, want %v", err, ErrDimensionMismatch)
+	}
+
+	cross, err := Cross(Vector{1, 0, 0}, Vector{0, 1, 0})
+	if err != nil || !reflect.DeepEqual(cross, Vector{0, 0, 1}) {
+		t.Errorf("Cross = %v, %v, want [0 0 1], nil", cross, err)
+	}
+	if _, err := Cross(Vector{1, 2}, Vector{3, 4}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Cross of 2-vectors error = %v, want %v", err, ErrDimensionMismatch)
+	}
+}
@@ -0,0 +1,71 @@
+package linalg
+
+import (
+	"math"
+)
+
+// Vector is a dense vector.
+type Vector []float64
+
+// Norm returns the p-norm of v for p >= 1, or the maximum norm when p is
+// +Inf. The 2-norm is computed with scaling so it does not overflow for
+// large elements.
+func (v Vector) Norm(p float64) float64 {
+	switch {
+	case math.IsInf(p, 1):
+		max := 0.0
+		for _, x := range v {
+			if a := math.Abs(x); a > max {
+				max = a
+			}
+		}
+		return max
+	case p == 1:
+		sum := 0.0
+		for _, x := range v {
+			sum += math.Abs(x)
+		}
+		return sum
+	case p == 2:
+		norm := 0.0
+		for _, x := range v {
+			norm = math.Hypot(norm, x)
+		}
+		return norm
+	default:
+		sum := 0.0
+		for _, x := range v {
+			sum += math.Pow(math.Abs(x), p)
+		}
+		return math.Pow(sum, 1/p)
+	}
+}
+
+// Dot returns the dot product of two vectors of the same length.
+func Dot(u, v Vector) (float64, error) {
+	if len(u) != len(v) {
+		return 0, shapeError("dot", ErrDimensionMismatch, u, v)
+	}
+	return dot(u, v), nil
+}
+
+// Cross returns the cross product of two 3-dimensional vectors.
+func Cross(u, v Vector) (Vector, error) {
+	if len(u) != 3 || len(v) != 3 {
+		return nil, shapeError("cross", ErrDimensionMismatch, u, v)
+	}
+	return Vector{
+		u[1]*v[2] - u[2]*v[1],
+		u[2]*v[0] - u[0]*v[2],
+		u[0]*v[1] - u[1]*v[0],
+	}, nil
+}
+
+// dot returns the dot product of equal-length slices.
+func dot(u, v []float64) float64 {
+	sum := 0.0
+	for i := range u {
+		sum += u[i] * v[i]
+	}
+	return sum
+}
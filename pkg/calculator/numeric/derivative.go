@@ -0,0 +1,64 @@
+package numeric
+
+import (
+	"math"
+)
+
+// Derivative returns the derivative of f at x. It takes central differences
+// over shrinking step sizes and extrapolates them to a zero step (Ridders'
+// method), which is usually accurate to about ten significant digits. The
+// error is estimated from the extrapolation table.
+func Derivative(f func(float64) float64, x float64) (Estimate, error) {
+	const (
+		shrink = 1.4 // step size ratio between columns of the table
+		size   = 10  // maximum size of the extrapolation table
+		safe   = 2   // stop when the error grows by this factor
+	)
+	if err := checkFinite("derivative", x); err != nil {
+		return Estimate{}, err
+	}
+
+	h := 0.1 * math.Max(1, math.Abs(x))
+	central := func(h float64) (float64, error) {
+		hi, lo := f(x+h), f(x-h)
+		if math.IsNaN(hi) || math.IsInf(hi, 0) {
+			return 0, nonFiniteValue("derivative", x+h)
+		}
+		if math.IsNaN(lo) || math.IsInf(lo, 0) {
+			return 0, nonFiniteValue("derivative", x-h)
+		}
+		return (hi - lo) / (2 * h), nil
+	}
+
+	// table[j] holds the estimates extrapolated j times for the current
+	// step size; prev holds those of the previous step size
+	first, err := central(h)
+	if err != nil {
+		return Estimate{}, err
+	}
+	prev := []float64{first}
+	best := Estimate{Value: first, Error: math.Inf(1)}
+	for i := 1; i < size; i++ {
+		h /= shrink
+		d, err := central(h)
+		if err != nil {
+			return Estimate{}, err
+		}
+		table := []float64{d}
+		factor := shrink * shrink
+		for j := 1; j <= i; j++ {
+			table = append(table, (table[j-1]*factor-prev[j-1])/(factor-1))
+			factor *= shrink * shrink
+			e := math.Max(math.Abs(table[j]-table[j-1]), math.Abs(table[j]-prev[j-1]))
+			if e <= best.Error {
+				best = Estimate{Value: table[j], Error: e}
+			}
+		}
+		// Rounding error now dominates, so smaller steps will not help
+		if math.Abs(table[i]-prev[i-1]) >= safe*best.Error {
+			break
+		}
+		prev = table
+	}
+	return best, nil
+}
@@ -0,0 +1,64 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+func TestDerivative(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(float64) float64
+		x        float64
+		expected float64
+	}{
+		{"sin", math.Sin, 1, math.Cos(1)},
+		{"cubic_at_zero", func(x float64) float64 { return x * x * x }, 0, 0},
+		{"cubic", func(x float64) float64 { return x * x * x }, 2, 12},
+		{"exp_large", math.Exp, 10, math.Exp(10)},
+		{"log", math.Log, 0.5, 2},
+		{"large_x", math.Sqrt, 1e6, 0.5e-3},
+		{"abs_smooth_side", math.Abs, -3, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Derivative(tt.f, tt.x)
+			if err != nil {
+				t.Fatalf("Derivative() error = %v", err)
+			}
+			tolerance := 1e-9 * math.Max(1, math.Abs(tt.expected))
+			if math.Abs(got.Value-tt.expected) > tolerance {
+				t.Errorf("Derivative() = %v, want %v", got.Value, tt.expected)
+			}
+			if got.Error > tolerance {
+				t.Errorf("Derivative() error estimate = %v, want at most %v", got.Error, tolerance)
+			}
+		})
+	}
+}
+
+func TestDerivativeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(float64) float64
+		x    float64
+	}{
+		{"nan_point", math.Sin, math.NaN()},
+		{"infinite_point", math.Sin, math.Inf(-1)},
+		{"pole", func(x float64) float64 { return 1 / (x - 0.9) }, 1},
+		{"outside_domain", math.Log, 0.01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Derivative(tt.f, tt.x)
+			if !errors.Is(err, calculator.ErrNonFinite) {
+				t.Errorf("Derivative() error = %v, want %v", err, calculator.ErrNonFinite)
+			}
+		})
+	}
+}
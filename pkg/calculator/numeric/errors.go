@@ -0,0 +1,98 @@
+// Package numeric provides numerical calculus on real functions: derivatives
+// by central differences, adaptive integration with error estimates,
+// Runge–Kutta solvers for ordinary differential equations, and iterative
+// root finding and minimization.
+package numeric
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// ErrNoConvergence is the error wrapped by every *ConvergenceError, so
+// callers can test for non-convergence with errors.Is.
+var ErrNoConvergence = errors.New("did not converge")
+
+// ErrInvalidTolerance is returned when a tolerance is not positive.
+var ErrInvalidTolerance = errors.New("tolerance must be positive")
+
+// ErrInvalidSteps is returned when a fixed-step solver is asked for fewer
+// than one step.
+var ErrInvalidSteps = errors.New("number of steps must be positive")
+
+// ErrInvalidIterations is returned when an iteration limit is negative.
+var ErrInvalidIterations = errors.New("iteration limit must be positive")
+
+// ErrNoBracket is returned when a bracketing root finder is given an
+// interval where the function does not change sign.
+var ErrNoBracket = errors.New("function has the same sign at both ends of the interval")
+
+// ErrZeroDerivative is returned when Newton's method or the secant method
+// reaches a point where the slope is zero, so the next step is undefined.
+var ErrZeroDerivative = errors.New("derivative is zero")
+
+// ConvergenceError reports that an iterative method stopped before reaching
+// the requested tolerance. Estimate and ErrorEstimate are the best result
+// reached, which callers may still use; for the ODE solvers Estimate is the
+// time reached, and for NelderMead the smallest value of the function.
+type ConvergenceError struct {
+	// Op is the name of the method, e.g. "integrate".
+	Op string
+	// Iterations is the number of subintervals, steps or iterations used.
+	Iterations int
+	// Estimate is the best estimate reached.
+	Estimate float64
+	// ErrorEstimate is the estimated error of Estimate.
+	ErrorEstimate float64
+}
+
+// Error returns a description of e.
+func (e *ConvergenceError) Error() string {
+	return fmt.Sprintf("%s did not converge after %d iterations (estimate %g, estimated error %g)",
+		e.Op, e.Iterations, e.Estimate, e.ErrorEstimate)
+}
+
+// Unwrap returns ErrNoConvergence.
+func (e *ConvergenceError) Unwrap() error {
+	return ErrNoConvergence
+}
+
+// Estimate is a numerical result together with an estimate of its absolute
+// error.
+type Estimate struct {
+	Value float64
+	Error float64
+}
+
+// checkFinite returns a *calculator.DomainError for op if any of xs is NaN
+// or infinite.
+func checkFinite(op string, xs ...float64) error {
+	for _, x := range xs {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			operands := make([]interface{}, len(xs))
+			for i, v := range xs {
+				operands[i] = v
+			}
+			return &calculator.DomainError{Op: op, Operands: operands, Reason: calculator.ErrNonFinite}
+		}
+	}
+	return nil
+}
+
+// checkTolerance returns a *calculator.DomainError for op unless tol is a
+// positive number.
+func checkTolerance(op string, tol float64) error {
+	if !(tol > 0) || math.IsInf(tol, 1) {
+		return &calculator.DomainError{Op: op, Operands: []interface{}{tol}, Reason: ErrInvalidTolerance}
+	}
+	return nil
+}
+
+// nonFiniteValue returns the error reported when a function returns NaN or
+// an infinity at x.
+func nonFiniteValue(op string, x float64) error {
+	return &calculator.DomainError{Op: op, Operands: []interface{}{x}, Reason: calculator.ErrNonFinite}
+}
@@ -0,0 +1,250 @@
+package numeric
+
+import (
+	"math"
+	"sort"
+)
+
+// Limits of the adaptive integrators. Simpson's rule halves its tolerance
+// at each level of recursion, so beyond maxSimpsonDepth the tolerance is
+// below rounding error; Gauss–Kronrod gives up after maxIntervals
+// subintervals.
+const (
+	maxSimpsonDepth = 50
+	maxIntervals    = 1000
+)
+
+// Integrate returns the integral of f from a to b, using adaptive
+// Gauss–Kronrod quadrature. It is a shorthand for GaussKronrod with a
+// tolerance of 1e-10.
+func Integrate(f func(float64) float64, a, b float64) (Estimate, error) {
+	return GaussKronrod(f, a, b, 1e-10)
+}
+
+// Simpson returns the integral of f from a to b using adaptive Simpson's
+// rule. Subintervals are halved until the estimated error, relative to the
+// size of the integral or absolute when the integral is smaller than 1, is
+// below tol. It returns a *ConvergenceError if some subinterval cannot
+// reach the tolerance.
+func Simpson(f func(float64) float64, a, b, tol float64) (Estimate, error) {
+	if err := checkFinite("simpson", a, b); err != nil {
+		return Estimate{}, err
+	}
+	if err := checkTolerance("simpson", tol); err != nil {
+		return Estimate{}, err
+	}
+	if a == b {
+		return Estimate{}, nil
+	}
+
+	s := &simpson{f: f}
+	fa, fm, fb := s.eval(a), s.eval((a+b)/2), s.eval(b)
+	whole := (b - a) / 6 * (fa + 4*fm + fb)
+	// A coarse first estimate fixes the scale of the relative tolerance
+	target := tol * math.Max(1, math.Abs(whole))
+	value := s.integrate(a, b, fa, fm, fb, whole, target, maxSimpsonDepth)
+
+	if s.err != nil {
+		return Estimate{}, s.err
+	}
+	result := Estimate{Value: value, Error: s.errorSum}
+	if s.exhausted {
+		return result, &ConvergenceError{Op: "simpson", Iterations: s.intervals, Estimate: value, ErrorEstimate: s.errorSum}
+	}
+	return result, nil
+}
+
+// simpson holds the state of an adaptive Simpson integration.
+type simpson struct {
+	f         func(float64) float64
+	err       error   // the first non-finite value of f
+	errorSum  float64 // the sum of the error estimates of accepted intervals
+	intervals int     // the number of accepted intervals
+	exhausted bool    // set if an interval hit the depth limit
+}
+
+// eval returns f(x), recording an error if it is not finite.
+func (s *simpson) eval(x float64) float64 {
+	y := s.f(x)
+	if (math.IsNaN(y) || math.IsInf(y, 0)) && s.err == nil {
+		s.err = nonFiniteValue("simpson", x)
+	}
+	return y
+}
+
+// integrate refines whole, the Simpson estimate over [a, b], until the
+// halves agree to within tol.
+func (s *simpson) integrate(a, b, fa, fm, fb, whole, tol float64, depth int) float64 {
+	m := (a + b) / 2
+	lm, rm := (a+m)/2, (m+b)/2
+	flm, frm := s.eval(lm), s.eval(rm)
+	if s.err != nil {
+		return 0
+	}
+	left := (m - a) / 6 * (fa + 4*flm + fm)
+	right := (b - m) / 6 * (fm + 4*frm + fb)
+	diff := left + right - whole
+
+	// The halves are accurate to about diff/15 (Lyness), and adding
+	// diff/15 is a step of Richardson extrapolation
+	if math.Abs(diff) <= 15*tol || depth == 0 {
+		if depth == 0 && math.Abs(diff) > 15*tol {
+			s.exhausted = true
+		}
+		s.errorSum += math.Abs(diff) / 15
+		s.intervals++
+		return left + right + diff/15
+	}
+	return s.integrate(a, m, fa, flm, fm, left, tol/2, depth-1) +
+		s.integrate(m, b, fm, frm, fb, right, tol/2, depth-1)
+}
+
+// Nodes and weights of the 7-point Gauss and 15-point Kronrod rules on
+// [-1, 1]. The nodes are symmetric, so only the non-negative ones are
+// listed; the Gauss nodes are the odd-indexed Kronrod nodes.
+var (
+	kronrodNodes = [8]float64{
+		0.991455371120812639206854697526329, 0.949107912342758524526189684047851,
+		0.864864423359769072789712788640926, 0.741531185599394439863864773280788,
+		0.586087235467691130294144845693013, 0.405845151377397166906606412076961,
+		0.207784955007898467600689403773245, 0,
+	}
+	kronrodWeights = [8]float64{
+		0.022935322010529224963732008058970, 0.063092092629978553290700663189204,
+		0.104790010322250183839876322541518, 0.140653259715525918745189590510238,
+		0.169004726639267902826583426598550, 0.190350578064785409913256402421014,
+		0.204432940075298892414161999234649, 0.209482141084727828012999174891714,
+	}
+	gaussWeights = [4]float64{
+		0.129484966168869693270611432679082, 0.279705391489276667901467771423780,
+		0.381830050505118944950369775488975, 0.417959183673469387755102040816327,
+	}
+)
+
+// interval is a subinterval of an adaptive Gauss–Kronrod integration.
+type interval struct {
+	a, b         float64
+	value, error float64
+}
+
+// GaussKronrod returns the integral of f from a to b using adaptive 7-15
+// point Gauss–Kronrod quadrature. The subinterval with the largest error is
+// bisected until the total error, relative to the size of the integral or
+// absolute when the integral is smaller than 1, is below tol. The error of
+// each subinterval is estimated from the difference between the Gauss and
+// Kronrod results. It returns a *ConvergenceError if the tolerance is not reached within 1000
+// subintervals.
+func GaussKronrod(f func(float64) float64, a, b, tol float64) (Estimate, error) {
+	if err := checkFinite("integrate", a, b); err != nil {
+		return Estimate{}, err
+	}
+	if err := checkTolerance("integrate", tol); err != nil {
+		return Estimate{}, err
+	}
+	if a == b {
+		return Estimate{}, nil
+	}
+
+	first, err := kronrod(f, a, b)
+	if err != nil {
+		return Estimate{}, err
+	}
+	intervals := []interval{first}
+	total := Estimate{Value: first.value, Error: first.error}
+
+	for total.Error > tol*math.Max(1, math.Abs(total.Value)) {
+		if len(intervals) >= maxIntervals {
+			return total, &ConvergenceError{Op: "integrate", Iterations: len(intervals), Estimate: total.Value, ErrorEstimate: total.Error}
+		}
+
+		// Bisect the interval with the largest error, kept last
+		worst := intervals[len(intervals)-1]
+		m := (worst.a + worst.b) / 2
+		if m == worst.a || m == worst.b {
+			// The interval cannot be split any further
+			return total, &ConvergenceError{Op: "integrate", Iterations: len(intervals), Estimate: total.Value, ErrorEstimate: total.Error}
+		}
+		left, err := kronrod(f, worst.a, m)
+		if err != nil {
+			return Estimate{}, err
+		}
+		right, err := kronrod(f, m, worst.b)
+		if err != nil {
+			return Estimate{}, err
+		}
+		intervals = append(intervals[:len(intervals)-1], left, right)
+		sort.Slice(intervals, func(i, j int) bool { return intervals[i].error < intervals[j].error })
+
+		// Sum from scratch so the totals do not accumulate rounding error
+		total = Estimate{}
+		for _, iv := range intervals {
+			total.Value += iv.value
+			total.Error += iv.error
+		}
+	}
+	return total, nil
+}
+
+// kronrod applies the 15-point Kronrod rule to [a, b]. Its error is
+// estimated from the difference with the embedded 7-point Gauss rule,
+// scaled as in QUADPACK so it is neither wildly pessimistic for smooth
+// functions nor smaller than rounding error.
+func kronrod(f func(float64) float64, a, b float64) (interval, error) {
+	center, half := (a+b)/2, (b-a)/2
+
+	// values[i] holds f at the Kronrod nodes ±kronrodNodes[i]; the center
+	// is only evaluated once, into values[7][0]
+	var values [8][2]float64
+	for i, node := range kronrodNodes {
+		for j, x := range [2]float64{center - half*node, center + half*node} {
+			if i == 7 && j == 1 {
+				break
+			}
+			y := f(x)
+			if math.IsNaN(y) || math.IsInf(y, 0) {
+				return interval{}, nonFiniteValue("integrate", x)
+			}
+			values[i][j] = y
+		}
+	}
+
+	var k, g, abs float64
+	for i, v := range values {
+		w := kronrodWeights[i]
+		if i == 7 {
+			// The center is a single node
+			k += w * v[0]
+			g += gaussWeights[3] * v[0]
+			abs += w * math.Abs(v[0])
+			continue
+		}
+		k += w * (v[0] + v[1])
+		abs += w * (math.Abs(v[0]) + math.Abs(v[1]))
+		if i%2 == 1 {
+			g += gaussWeights[i/2] * (v[0] + v[1])
+		}
+	}
+
+	// asc measures how far f strays from its mean over the interval
+	mean := k / 2
+	var asc float64
+	for i, v := range values {
+		if i == 7 {
+			asc += kronrodWeights[i] * math.Abs(v[0]-mean)
+			continue
+		}
+		asc += kronrodWeights[i] * (math.Abs(v[0]-mean) + math.Abs(v[1]-mean))
+	}
+
+	half = math.Abs(half)
+	err := math.Abs((k - g) * half)
+	asc *= half
+	if asc != 0 && err != 0 {
+		err = asc * math.Min(1, math.Pow(200*err/asc, 1.5))
+	}
+	err = math.Max(err, 50*epsilon*abs*half)
+	return interval{a: a, b: b, value: k * (b - a) / 2, error: err}, nil
+}
+
+// epsilon is the machine epsilon of float64.
+const epsilon = 0x1p-52
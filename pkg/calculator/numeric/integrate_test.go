@@ -0,0 +1,124 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+func TestIntegrate(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(float64) float64
+		a, b     float64
+		expected float64
+	}{
+		{"sin", math.Sin, 0, math.Pi, 2},
+		{"polynomial", func(x float64) float64 { return 3*x*x + 1 }, 0, 2, 10},
+		{"exp", math.Exp, 0, 1, math.E - 1},
+		{"reversed_bounds", math.Exp, 1, 0, 1 - math.E},
+		{"empty_interval", math.Exp, 3, 3, 0},
+		{"oscillating", func(x float64) float64 { return math.Cos(50 * x) }, 0, 1, math.Sin(50) / 50},
+		{"endpoint_singularity", func(x float64) float64 { return 1 / math.Sqrt(x) }, 0, 1, 2},
+		{"peak", func(x float64) float64 { return 1 / (1e-4 + x*x) }, -1, 1, 2 * 100 * math.Atan(100)},
+	}
+
+	methods := []struct {
+		name      string
+		integrate func(f func(float64) float64, a, b float64) (Estimate, error)
+	}{
+		{"gauss_kronrod", Integrate},
+		{"simpson", func(f func(float64) float64, a, b float64) (Estimate, error) {
+			return Simpson(f, a, b, 1e-10)
+		}},
+	}
+
+	for _, m := range methods {
+		for _, tt := range tests {
+			if m.name == "simpson" && tt.name == "endpoint_singularity" {
+				// Simpson's rule evaluates the endpoints, where f is infinite
+				continue
+			}
+			t.Run(m.name+"/"+tt.name, func(t *testing.T) {
+				got, err := m.integrate(tt.f, tt.a, tt.b)
+				if err != nil {
+					t.Fatalf("error = %v", err)
+				}
+				scale := math.Max(1, math.Abs(tt.expected))
+				if math.Abs(got.Value-tt.expected) > 1e-9*scale {
+					t.Errorf("Value = %v, want %v", got.Value, tt.expected)
+				}
+				// Simpson scales its tolerance by a coarse first estimate, so
+				// allow its error estimate some slack
+				if got.Error > 1e-8*scale {
+					t.Errorf("Error = %v, want at most %v", got.Error, 1e-8*scale)
+				}
+			})
+		}
+	}
+}
+
+func TestIntegrateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      func() error
+		expected error
+	}{
+		{"infinite_bound", func() error {
+			_, err := Integrate(math.Sin, 0, math.Inf(1))
+			return err
+		}, calculator.ErrNonFinite},
+		{"nan_bound", func() error {
+			_, err := Simpson(math.Sin, math.NaN(), 1, 1e-10)
+			return err
+		}, calculator.ErrNonFinite},
+		{"pole", func() error {
+			_, err := Integrate(func(x float64) float64 { return 1 / x }, -1, 1)
+			return err
+		}, calculator.ErrNonFinite},
+		{"simpson_pole", func() error {
+			_, err := Simpson(func(x float64) float64 { return 1 / x }, 0, 1, 1e-10)
+			return err
+		}, calculator.ErrNonFinite},
+		{"zero_tolerance", func() error {
+			_, err := GaussKronrod(math.Sin, 0, 1, 0)
+			return err
+		}, ErrInvalidTolerance},
+		{"negative_tolerance", func() error {
+			_, err := Simpson(math.Sin, 0, 1, -1)
+			return err
+		}, ErrInvalidTolerance},
+		{"no_convergence", func() error {
+			_, err := GaussKronrod(func(x float64) float64 { return math.Sin(1 / x) }, 1e-300, 1, 1e-15)
+			return err
+		}, ErrNoConvergence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("error = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConvergenceErrorKeepsEstimate(t *testing.T) {
+	f := func(x float64) float64 { return math.Sin(1 / x) }
+	got, err := GaussKronrod(f, 1e-300, 1, 1e-15)
+
+	var convErr *ConvergenceError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("error = %v, want a *ConvergenceError", err)
+	}
+	if convErr.Op != "integrate" || convErr.Iterations == 0 {
+		t.Errorf("ConvergenceError = %+v", convErr)
+	}
+	// ∫₀¹ sin(1/x) dx = sin(1) - Ci(1) ≈ 0.5040670619
+	if math.Abs(got.Value-0.5040670619) > 1e-5 || got.Value != convErr.Estimate {
+		t.Errorf("Value = %v, Estimate = %v, want about 0.5040670619", got.Value, convErr.Estimate)
+	}
+}
@@ -0,0 +1,197 @@
+package numeric
+
+import (
+	"math"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// maxODESteps is the number of steps after which RK45 gives up.
+const maxODESteps = 100000
+
+// System is a system of first-order ordinary differential equations
+// y' = f(t, y). It returns the derivative of each component of y and must
+// not modify y.
+type System func(t float64, y []float64) []float64
+
+// Solution is the trajectory computed by an ODE solver: Y[i] is the state at
+// time T[i]. The first entry is the initial condition and the last is the
+// state at the end time.
+type Solution struct {
+	T []float64
+	Y [][]float64
+}
+
+// Final returns the state at the end time.
+func (s Solution) Final() []float64 {
+	return s.Y[len(s.Y)-1]
+}
+
+// RK4 solves y' = f(t, y) from t0 to t1 with the classical fourth-order
+// Runge–Kutta method in the given number of equal steps. t1 may be less
+// than t0 to integrate backwards in time.
+func RK4(f System, t0 float64, y0 []float64, t1 float64, steps int) (Solution, error) {
+	if steps < 1 {
+		return Solution{}, &calculator.DomainError{Op: "rk4", Operands: []interface{}{steps}, Reason: ErrInvalidSteps}
+	}
+	if err := checkFinite("rk4", append([]float64{t0, t1}, y0...)...); err != nil {
+		return Solution{}, err
+	}
+
+	h := (t1 - t0) / float64(steps)
+	sol := Solution{T: []float64{t0}, Y: [][]float64{append([]float64(nil), y0...)}}
+	y := sol.Y[0]
+	for i := 1; i <= steps; i++ {
+		t := t0 + float64(i-1)*h
+		k1 := f(t, y)
+		k2 := f(t+h/2, axpy(y, h/2, k1))
+		k3 := f(t+h/2, axpy(y, h/2, k2))
+		k4 := f(t+h, axpy(y, h, k3))
+
+		next := make([]float64, len(y))
+		for j := range next {
+			next[j] = y[j] + h/6*(k1[j]+2*k2[j]+2*k3[j]+k4[j])
+		}
+		t = t0 + float64(i)*h
+		if i == steps {
+			// Land exactly on t1 despite rounding in the step size
+			t = t1
+		}
+		if err := checkState("rk4", t, next); err != nil {
+			return Solution{}, err
+		}
+		sol.T = append(sol.T, t)
+		sol.Y = append(sol.Y, next)
+		y = next
+	}
+	return sol, nil
+}
+
+// Dormand–Prince coefficients for RK45: the nodes, the Runge–Kutta matrix,
+// the fifth-order weights (equal to the last row of the matrix, so the
+// final stage can be reused as the first of the next step) and the
+// difference between the fifth- and fourth-order weights.
+var (
+	dpNodes  = [7]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1, 1}
+	dpMatrix = [7][6]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+		{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84},
+	}
+	dpErrorWeights = [7]float64{
+		35.0/384 - 5179.0/57600, 0, 500.0/1113 - 7571.0/16695, 125.0/192 - 393.0/640,
+		-2187.0/6784 + 92097.0/339200, 11.0/84 - 187.0/2100, -1.0 / 40,
+	}
+)
+
+// RK45 solves y' = f(t, y) from t0 to t1 with the adaptive Dormand–Prince
+// method. The step size is chosen so that the estimated local error of each
+// component is below tol, relative to the size of the component or absolute
+// when it is smaller than 1. The solution holds every accepted step. It
+// returns a *ConvergenceError if the step size becomes negligible or the
+// end time is not reached within 100000 steps.
+func RK45(f System, t0 float64, y0 []float64, t1, tol float64) (Solution, error) {
+	if err := checkFinite("rk45", append([]float64{t0, t1}, y0...)...); err != nil {
+		return Solution{}, err
+	}
+	if err := checkTolerance("rk45", tol); err != nil {
+		return Solution{}, err
+	}
+
+	sol := Solution{T: []float64{t0}, Y: [][]float64{append([]float64(nil), y0...)}}
+	if t0 == t1 {
+		return sol, nil
+	}
+
+	t, y := t0, sol.Y[0]
+	h := (t1 - t0) / 100
+	var k [7][]float64
+	k[0] = f(t, y)
+	for step := 0; t != t1; step++ {
+		if step == maxODESteps || math.Abs(h) <= 16*epsilon*math.Abs(t) {
+			return sol, &ConvergenceError{Op: "rk45", Iterations: step, Estimate: t, ErrorEstimate: tol}
+		}
+		if (t+h-t1)*h > 0 {
+			// Do not step past the end time
+			h = t1 - t
+		}
+
+		for s := 1; s < 7; s++ {
+			state := append([]float64(nil), y...)
+			for r := 0; r < s; r++ {
+				if a := dpMatrix[s][r]; a != 0 {
+					for j := range state {
+						state[j] += h * a * k[r][j]
+					}
+				}
+			}
+			k[s] = f(t+dpNodes[s]*h, state)
+		}
+		// The last stage is evaluated at the fifth-order solution
+		next := make([]float64, len(y))
+		for j := range next {
+			next[j] = y[j]
+			for r := 0; r < 6; r++ {
+				next[j] += h * dpMatrix[6][r] * k[r][j]
+			}
+		}
+
+		// The largest error relative to the tolerance decides the step
+		ratio := 0.0
+		for j := range next {
+			e := 0.0
+			for r, w := range dpErrorWeights {
+				e += w * k[r][j]
+			}
+			scale := tol * math.Max(1, math.Max(math.Abs(y[j]), math.Abs(next[j])))
+			ratio = math.Max(ratio, math.Abs(h*e)/scale)
+		}
+		if math.IsNaN(ratio) || math.IsInf(ratio, 0) {
+			return Solution{}, nonFiniteValue("rk45", t+h)
+		}
+
+		if ratio <= 1 {
+			t += h
+			if err := checkState("rk45", t, next); err != nil {
+				return Solution{}, err
+			}
+			sol.T = append(sol.T, t)
+			sol.Y = append(sol.Y, next)
+			y = next
+			k[0] = k[6]
+		}
+
+		// Grow or shrink the step by at most a factor of 5, with a safety
+		// margin so the next step is likely to be accepted
+		factor := 5.0
+		if ratio > 0 {
+			factor = math.Min(5, math.Max(0.2, 0.9*math.Pow(ratio, -0.2)))
+		}
+		h *= factor
+	}
+	return sol, nil
+}
+
+// axpy returns y + a*x.
+func axpy(y []float64, a float64, x []float64) []float64 {
+	z := make([]float64, len(y))
+	for i := range z {
+		z[i] = y[i] + a*x[i]
+	}
+	return z
+}
+
+// checkState returns an error if a component of the state y at time t is
+// NaN or infinite.
+func checkState(op string, t float64, y []float64) error {
+	for _, v := range y {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nonFiniteValue(op, t)
+		}
+	}
+	return nil
+}
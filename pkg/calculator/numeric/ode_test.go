@@ -0,0 +1,144 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// oscillator is the harmonic oscillator as a first-order system: y' = v, v' = -y.
+func oscillator(t float64, y []float64) []float64 {
+	return []float64{y[1], -y[0]}
+}
+
+// growth is y' = y.
+func growth(t float64, y []float64) []float64 {
+	return []float64{y[0]}
+}
+
+func TestODESolvers(t *testing.T) {
+	tests := []struct {
+		name      string
+		f         System
+		t0, t1    float64
+		y0        []float64
+		expected  []float64
+		tolerance float64
+	}{
+		{"oscillator", oscillator, 0, math.Pi, []float64{0, 1}, []float64{0, -1}, 1e-7},
+		{"backwards", oscillator, math.Pi, 0, []float64{0, -1}, []float64{0, 1}, 1e-7},
+		{"growth", growth, 0, 1, []float64{1}, []float64{math.E}, 1e-7},
+		{"time_dependent", func(t float64, y []float64) []float64 {
+			return []float64{2 * t}
+		}, 1, 3, []float64{0}, []float64{8}, 1e-9},
+	}
+
+	solvers := []struct {
+		name  string
+		solve func(f System, t0 float64, y0 []float64, t1 float64) (Solution, error)
+	}{
+		{"rk4", func(f System, t0 float64, y0 []float64, t1 float64) (Solution, error) {
+			return RK4(f, t0, y0, t1, 200)
+		}},
+		{"rk45", func(f System, t0 float64, y0 []float64, t1 float64) (Solution, error) {
+			return RK45(f, t0, y0, t1, 1e-10)
+		}},
+	}
+
+	for _, s := range solvers {
+		for _, tt := range tests {
+			t.Run(s.name+"/"+tt.name, func(t *testing.T) {
+				got, err := s.solve(tt.f, tt.t0, tt.y0, tt.t1)
+				if err != nil {
+					t.Fatalf("error = %v", err)
+				}
+				if got.T[0] != tt.t0 || got.T[len(got.T)-1] != tt.t1 {
+					t.Errorf("T runs from %v to %v, want %v to %v", got.T[0], got.T[len(got.T)-1], tt.t0, tt.t1)
+				}
+				if len(got.T) != len(got.Y) {
+					t.Fatalf("len(T) = %d, len(Y) = %d", len(got.T), len(got.Y))
+				}
+				final := got.Final()
+				for i := range tt.expected {
+					if math.Abs(final[i]-tt.expected[i]) > tt.tolerance {
+						t.Errorf("Final() = %v, want %v", final, tt.expected)
+						break
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestRK4Steps(t *testing.T) {
+	got, err := RK4(growth, 0, []float64{1}, 1, 10)
+	if err != nil {
+		t.Fatalf("RK4() error = %v", err)
+	}
+	if len(got.T) != 11 {
+		t.Errorf("len(T) = %d, want 11", len(got.T))
+	}
+	// Fourth order: ten steps are accurate to about h^4 = 1e-4
+	if e := math.Abs(got.Final()[0] - math.E); e > 1e-5 {
+		t.Errorf("error = %v, want at most 1e-5", e)
+	}
+}
+
+func TestRK45DoesNotModifyInitialState(t *testing.T) {
+	y0 := []float64{0, 1}
+	if _, err := RK45(oscillator, 0, y0, 1, 1e-8); err != nil {
+		t.Fatalf("RK45() error = %v", err)
+	}
+	if y0[0] != 0 || y0[1] != 1 {
+		t.Errorf("y0 = %v, want [0 1]", y0)
+	}
+}
+
+func TestODEErrors(t *testing.T) {
+	// y' = y^2 with y(0) = 1 is 1/(1-t), which blows up at t = 1
+	blowUp := func(t float64, y []float64) []float64 {
+		return []float64{y[0] * y[0]}
+	}
+
+	tests := []struct {
+		name     string
+		err      func() error
+		expected error
+	}{
+		{"zero_steps", func() error {
+			_, err := RK4(growth, 0, []float64{1}, 1, 0)
+			return err
+		}, ErrInvalidSteps},
+		{"nan_initial_state", func() error {
+			_, err := RK4(growth, 0, []float64{math.NaN()}, 1, 10)
+			return err
+		}, calculator.ErrNonFinite},
+		{"infinite_end_time", func() error {
+			_, err := RK45(growth, 0, []float64{1}, math.Inf(1), 1e-8)
+			return err
+		}, calculator.ErrNonFinite},
+		{"invalid_tolerance", func() error {
+			_, err := RK45(growth, 0, []float64{1}, 1, 0)
+			return err
+		}, ErrInvalidTolerance},
+		{"rk4_blow_up", func() error {
+			_, err := RK4(blowUp, 0, []float64{1e200}, 1, 1)
+			return err
+		}, calculator.ErrNonFinite},
+		{"rk45_blow_up", func() error {
+			_, err := RK45(blowUp, 0, []float64{1}, 2, 1e-8)
+			return err
+		}, ErrNoConvergence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("error = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
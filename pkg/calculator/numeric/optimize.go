@@ -0,0 +1,197 @@
+package numeric
+
+import (
+	"math"
+	"sort"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// invPhi is 1/φ, the golden ratio conjugate.
+var invPhi = (math.Sqrt(5) - 1) / 2
+
+// GoldenSection finds a minimum of f in [a, b] by golden-section search,
+// which shrinks the interval by 1/φ per iteration while reusing one
+// function value. f should have a single minimum in the interval; otherwise
+// a local minimum is found.
+func GoldenSection(f func(float64) float64, a, b float64, opts Options) (Result, error) {
+	tol, maxIterations, err := opts.settings("golden", defaultMinimizeTol)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := checkFinite("golden", a, b); err != nil {
+		return Result{}, err
+	}
+	if a > b {
+		a, b = b, a
+	}
+
+	e := &evaluator{op: "golden", f: f}
+	c, d := b-invPhi*(b-a), a+invPhi*(b-a)
+	fc, fd := e.eval(c), e.eval(d)
+	var r Result
+	for {
+		if e.err != nil {
+			return Result{}, e.err
+		}
+		if fc < fd {
+			r.X, r.Value = c, fc
+		} else {
+			r.X, r.Value = d, fd
+		}
+		if within((b-a)/2, r.X, tol) {
+			r.Converged = true
+			return r, nil
+		}
+		if r.Iterations == maxIterations {
+			return notConverged("golden", r, (b-a)/2)
+		}
+		r.Iterations++
+
+		// Keep the part of the interval holding the lower value; its inner
+		// point becomes one of the new inner points
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = b - invPhi*(b-a)
+			fc = e.eval(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + invPhi*(b-a)
+			fd = e.eval(d)
+		}
+	}
+}
+
+// VectorResult is the outcome of a minimizer over several variables.
+type VectorResult struct {
+	// X is the minimizer found, or the best estimate when the method did
+	// not converge.
+	X []float64
+	// Value is f(X).
+	Value float64
+	// Iterations is the number of iterations used.
+	Iterations int
+	// Converged reports whether X is within the tolerance.
+	Converged bool
+}
+
+// NelderMead finds a local minimum of f near x0 with the Nelder–Mead
+// simplex method, which needs no derivatives. It has converged when the
+// vertices of the simplex are within the tolerance of each other and their
+// values agree to the same relative tolerance. f must not modify its
+// argument.
+func NelderMead(f func([]float64) float64, x0 []float64, opts Options) (VectorResult, error) {
+	tol, maxIterations, err := opts.settings("nelder-mead", defaultMinimizeTol)
+	if err != nil {
+		return VectorResult{}, err
+	}
+	if err := checkFinite("nelder-mead", x0...); err != nil {
+		return VectorResult{}, err
+	}
+
+	type vertex struct {
+		x     []float64
+		value float64
+	}
+	n := len(x0)
+	var evalErr error
+	eval := func(x []float64) vertex {
+		y := f(x)
+		if (math.IsNaN(y) || math.IsInf(y, 0)) && evalErr == nil {
+			operands := make([]interface{}, len(x))
+			for i, v := range x {
+				operands[i] = v
+			}
+			evalErr = &calculator.DomainError{Op: "nelder-mead", Operands: operands, Reason: calculator.ErrNonFinite}
+		}
+		return vertex{x: x, value: y}
+	}
+	// combine returns a + t*(a - b), the point at t times the distance from
+	// b to a beyond a
+	combine := func(a, b []float64, t float64) []float64 {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = a[i] + t*(a[i]-b[i])
+		}
+		return x
+	}
+
+	// The initial simplex steps 5% along each axis, or 0.00025 from zero
+	simplex := make([]vertex, n+1)
+	simplex[0] = eval(append([]float64(nil), x0...))
+	for i := 0; i < n; i++ {
+		x := append([]float64(nil), x0...)
+		if x[i] != 0 {
+			x[i] *= 1.05
+		} else {
+			x[i] = 0.00025
+		}
+		simplex[i+1] = eval(x)
+	}
+
+	var r VectorResult
+	for {
+		if evalErr != nil {
+			return VectorResult{}, evalErr
+		}
+		sort.SliceStable(simplex, func(i, j int) bool { return simplex[i].value < simplex[j].value })
+		best, worst := simplex[0], simplex[n]
+		r.X, r.Value = best.x, best.value
+
+		size := 0.0
+		for _, v := range simplex[1:] {
+			for i := range v.x {
+				size = math.Max(size, math.Abs(v.x[i]-best.x[i]))
+			}
+		}
+		scale := 0.0
+		for _, x := range best.x {
+			scale = math.Max(scale, math.Abs(x))
+		}
+		if within(size, scale, tol) && within(worst.value-best.value, best.value, tol) {
+			r.Converged = true
+			return r, nil
+		}
+		if r.Iterations == maxIterations {
+			return r, &ConvergenceError{Op: "nelder-mead", Iterations: r.Iterations, Estimate: r.Value, ErrorEstimate: size}
+		}
+		r.Iterations++
+
+		// Reflect the worst vertex through the centroid of the others
+		centroid := make([]float64, n)
+		for _, v := range simplex[:n] {
+			for i := range centroid {
+				centroid[i] += v.x[i] / float64(n)
+			}
+		}
+		reflected := eval(combine(centroid, worst.x, 1))
+		switch {
+		case reflected.value < best.value:
+			// Moving away from the worst vertex helps; try going further
+			expanded := eval(combine(centroid, worst.x, 2))
+			if expanded.value < reflected.value {
+				simplex[n] = expanded
+			} else {
+				simplex[n] = reflected
+			}
+		case reflected.value < simplex[n-1].value:
+			simplex[n] = reflected
+		default:
+			// Contract towards the better of the worst and reflected points
+			var contracted vertex
+			if reflected.value < worst.value {
+				contracted = eval(combine(centroid, worst.x, 0.5))
+			} else {
+				contracted = eval(combine(centroid, worst.x, -0.5))
+			}
+			if contracted.value < math.Min(worst.value, reflected.value) {
+				simplex[n] = contracted
+				break
+			}
+			// Shrink every vertex halfway towards the best one
+			for i := 1; i <= n; i++ {
+				simplex[i] = eval(combine(best.x, simplex[i].x, -0.5))
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+func TestGoldenSection(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(float64) float64
+		a, b     float64
+		expected float64
+	}{
+		{"parabola", func(x float64) float64 { return (x-1)*(x-1) + 3 }, -5, 5, 1},
+		{"cos", math.Cos, 0, 6, math.Pi},
+		{"reversed_interval", math.Cos, 6, 0, math.Pi},
+		{"minimum_at_end", func(x float64) float64 { return x }, 2, 4, 2},
+		{"abs", func(x float64) float64 { return math.Abs(x - 0.3) }, -1, 1, 0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GoldenSection(tt.f, tt.a, tt.b, Options{})
+			if err != nil {
+				t.Fatalf("GoldenSection() error = %v", err)
+			}
+			if !got.Converged {
+				t.Errorf("Converged = false")
+			}
+			if math.Abs(got.X-tt.expected) > 1e-7*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("X = %v, want %v", got.X, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNelderMead(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func([]float64) float64
+		x0       []float64
+		expected []float64
+	}{
+		{"one_variable", func(x []float64) float64 { return (x[0] - 3) * (x[0] - 3) }, []float64{0}, []float64{3}},
+		{"rosenbrock", func(x []float64) float64 {
+			return 100*math.Pow(x[1]-x[0]*x[0], 2) + math.Pow(1-x[0], 2)
+		}, []float64{-1.2, 1}, []float64{1, 1}},
+		{"bowl", func(x []float64) float64 {
+			return (x[0]-1)*(x[0]-1) + 2*(x[1]+2)*(x[1]+2) + 3*x[2]*x[2]
+		}, []float64{5, 5, 5}, []float64{1, -2, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NelderMead(tt.f, tt.x0, Options{})
+			if err != nil {
+				t.Fatalf("NelderMead() error = %v", err)
+			}
+			if !got.Converged {
+				t.Errorf("Converged = false")
+			}
+			for i := range tt.expected {
+				if math.Abs(got.X[i]-tt.expected[i]) > 1e-6 {
+					t.Errorf("X = %v, want %v", got.X, tt.expected)
+					break
+				}
+			}
+			if got.Value != tt.f(got.X) {
+				t.Errorf("Value = %v, want f(X) = %v", got.Value, tt.f(got.X))
+			}
+		})
+	}
+}
+
+func TestNelderMeadDoesNotModifyStart(t *testing.T) {
+	x0 := []float64{2, 2}
+	_, err := NelderMead(func(x []float64) float64 { return x[0]*x[0] + x[1]*x[1] }, x0, Options{})
+	if err != nil {
+		t.Fatalf("NelderMead() error = %v", err)
+	}
+	if x0[0] != 2 || x0[1] != 2 {
+		t.Errorf("x0 = %v, want [2 2]", x0)
+	}
+}
+
+func TestMinimizerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      func() error
+		expected error
+	}{
+		{"golden_iteration_limit", func() error {
+			_, err := GoldenSection(math.Cos, 0, 6, Options{MaxIterations: 3})
+			return err
+		}, ErrNoConvergence},
+		{"golden_infinite_bound", func() error {
+			_, err := GoldenSection(math.Cos, 0, math.Inf(1), Options{})
+			return err
+		}, calculator.ErrNonFinite},
+		{"golden_nan_value", func() error {
+			_, err := GoldenSection(math.Log, -2, -1, Options{})
+			return err
+		}, calculator.ErrNonFinite},
+		{"nelder_mead_iteration_limit", func() error {
+			_, err := NelderMead(func(x []float64) float64 { return x[0] * x[0] }, []float64{5}, Options{MaxIterations: 2})
+			return err
+		}, ErrNoConvergence},
+		{"nelder_mead_nan_value", func() error {
+			_, err := NelderMead(func(x []float64) float64 { return math.Log(x[0]) }, []float64{1}, Options{})
+			return err
+		}, calculator.ErrNonFinite},
+		{"nelder_mead_negative_tolerance", func() error {
+			_, err := NelderMead(func(x []float64) float64 { return x[0] }, []float64{1}, Options{Tol: -1})
+			return err
+		}, ErrInvalidTolerance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("error = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package numeric
+
+import (
+	"math"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Default settings of the root finders and minimizers.
+const (
+	// defaultRootTol is close to the best accuracy a root can have in
+	// float64.
+	defaultRootTol = 1e-12
+	// defaultMinimizeTol is about √ε: near a minimum f is flat to second
+	// order, so x cannot be located more precisely than that.
+	defaultMinimizeTol = 1e-8
+	// defaultMaxIterations caps every iterative method unless overridden.
+	defaultMaxIterations = 500
+)
+
+// Options configures the root finders and minimizers. The zero value
+// selects the defaults.
+type Options struct {
+	// Tol is the tolerance on x, relative to |x| or absolute when |x| is
+	// smaller than 1. It defaults to 1e-12 for root finders and 1e-8 for
+	// minimizers.
+	Tol float64
+	// MaxIterations is the number of iterations after which the method
+	// gives up. It defaults to 500.
+	MaxIterations int
+}
+
+// settings returns the tolerance and iteration limit of opts, with zero
+// fields replaced by the defaults, or an error for negative values.
+func (opts Options) settings(op string, defaultTol float64) (float64, int, error) {
+	tol, maxIterations := opts.Tol, opts.MaxIterations
+	if tol == 0 {
+		tol = defaultTol
+	}
+	if err := checkTolerance(op, tol); err != nil {
+		return 0, 0, err
+	}
+	if maxIterations == 0 {
+		maxIterations = defaultMaxIterations
+	}
+	if maxIterations < 0 {
+		return 0, 0, &calculator.DomainError{Op: op, Operands: []interface{}{maxIterations}, Reason: ErrInvalidIterations}
+	}
+	return tol, maxIterations, nil
+}
+
+// Result is the outcome of a scalar root finder or minimizer.
+type Result struct {
+	// X is the root or minimizer found, or the best estimate when the
+	// method did not converge.
+	X float64
+	// Value is f(X).
+	Value float64
+	// Iterations is the number of iterations used.
+	Iterations int
+	// Converged reports whether X is within the tolerance.
+	Converged bool
+}
+
+// notConverged returns r with its convergence error. The estimated error
+// is the last step or interval width, given as step.
+func notConverged(op string, r Result, step float64) (Result, error) {
+	return r, &ConvergenceError{Op: op, Iterations: r.Iterations, Estimate: r.X, ErrorEstimate: math.Abs(step)}
+}
+
+// evaluator calls f, recording an error for op at the first non-finite
+// value so the caller can stop.
+type evaluator struct {
+	op  string
+	f   func(float64) float64
+	err error
+}
+
+// eval returns f(x).
+func (e *evaluator) eval(x float64) float64 {
+	y := e.f(x)
+	if (math.IsNaN(y) || math.IsInf(y, 0)) && e.err == nil {
+		e.err = nonFiniteValue(e.op, x)
+	}
+	return y
+}
+
+// within reports whether a step of the given size from x is within tol.
+func within(step, x, tol float64) bool {
+	return math.Abs(step) <= tol*math.Max(1, math.Abs(x))
+}
+
+// checkBracket returns the values of f at a and b, or an error unless they
+// have opposite signs or one is zero.
+func checkBracket(e *evaluator, a, b float64) (fa, fb float64, err error) {
+	if err := checkFinite(e.op, a, b); err != nil {
+		return 0, 0, err
+	}
+	fa, fb = e.eval(a), e.eval(b)
+	if e.err != nil {
+		return 0, 0, e.err
+	}
+	if fa != 0 && fb != 0 && (fa > 0) == (fb > 0) {
+		return 0, 0, &calculator.DomainError{Op: e.op, Operands: []interface{}{a, b}, Reason: ErrNoBracket}
+	}
+	return fa, fb, nil
+}
+
+// Bisect finds a root of f in [a, b] by repeatedly halving the interval.
+// f(a) and f(b) must have opposite signs. It is slow but cannot fail on a
+// continuous function.
+func Bisect(f func(float64) float64, a, b float64, opts Options) (Result, error) {
+	tol, maxIterations, err := opts.settings("bisect", defaultRootTol)
+	if err != nil {
+		return Result{}, err
+	}
+	e := &evaluator{op: "bisect", f: f}
+	fa, fb, err := checkBracket(e, a, b)
+	if err != nil {
+		return Result{}, err
+	}
+	switch {
+	case fa == 0:
+		return Result{X: a, Converged: true}, nil
+	case fb == 0:
+		return Result{X: b, Converged: true}, nil
+	}
+
+	var r Result
+	for r.Iterations < maxIterations {
+		r.Iterations++
+		m := a + (b-a)/2
+		fm := e.eval(m)
+		if e.err != nil {
+			return Result{}, e.err
+		}
+		r.X, r.Value = m, fm
+		if fm == 0 || within((b-a)/2, m, tol) {
+			r.Converged = true
+			return r, nil
+		}
+		if (fm > 0) == (fa > 0) {
+			a, fa = m, fm
+		} else {
+			b = m
+		}
+	}
+	return notConverged("bisect", r, (b-a)/2)
+}
+
+// Newton finds a root of f with Newton–Raphson iteration from x0. df is the
+// derivative of f; if it is nil the derivative is estimated with
+// Derivative. Convergence is quadratic near a simple root, but the
+// iteration may diverge from a poor starting point.
+func Newton(f, df func(float64) float64, x0 float64, opts Options) (Result, error) {
+	tol, maxIterations, err := opts.settings("newton", defaultRootTol)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := checkFinite("newton", x0); err != nil {
+		return Result{}, err
+	}
+	e := &evaluator{op: "newton", f: f}
+	slope := func(x float64) (float64, error) {
+		if df != nil {
+			d := df(x)
+			if math.IsNaN(d) || math.IsInf(d, 0) {
+				return 0, nonFiniteValue("newton", x)
+			}
+			return d, nil
+		}
+		d, err := Derivative(f, x)
+		return d.Value, err
+	}
+
+	r := Result{X: x0, Value: e.eval(x0)}
+	step := math.Inf(1)
+	for r.Value != 0 && r.Iterations < maxIterations {
+		if e.err != nil {
+			return Result{}, e.err
+		}
+		r.Iterations++
+		d, err := slope(r.X)
+		if err != nil {
+			return Result{}, err
+		}
+		if d == 0 {
+			return Result{}, &calculator.DomainError{Op: "newton", Operands: []interface{}{r.X}, Reason: ErrZeroDerivative}
+		}
+		step = r.Value / d
+		r.X -= step
+		r.Value = e.eval(r.X)
+		if within(step, r.X, tol) {
+			break
+		}
+	}
+	if e.err != nil {
+		return Result{}, e.err
+	}
+	if r.Value != 0 && !within(step, r.X, tol) {
+		return notConverged("newton", r, step)
+	}
+	r.Converged = true
+	return r, nil
+}
+
+// Secant finds a root of f with the secant method, starting from x0 and
+// x1. It needs no derivative and converges almost as fast as Newton's
+// method near a simple root.
+func Secant(f func(float64) float64, x0, x1 float64, opts Options) (Result, error) {
+	tol, maxIterations, err := opts.settings("secant", defaultRootTol)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := checkFinite("secant", x0, x1); err != nil {
+		return Result{}, err
+	}
+	e := &evaluator{op: "secant", f: f}
+	f0 := e.eval(x0)
+	r := Result{X: x1, Value: e.eval(x1)}
+	step := x1 - x0
+	for r.Value != 0 && r.Iterations < maxIterations {
+		if e.err != nil {
+			return Result{}, e.err
+		}
+		r.Iterations++
+		if r.Value == f0 {
+			return Result{}, &calculator.DomainError{Op: "secant", Operands: []interface{}{x0, r.X}, Reason: ErrZeroDerivative}
+		}
+		step = r.Value * (r.X - x0) / (r.Value - f0)
+		x0, f0 = r.X, r.Value
+		r.X -= step
+		r.Value = e.eval(r.X)
+		if within(step, r.X, tol) {
+			break
+		}
+	}
+	if e.err != nil {
+		return Result{}, e.err
+	}
+	if r.Value != 0 && !within(step, r.X, tol) {
+		return notConverged("secant", r, step)
+	}
+	r.Converged = true
+	return r, nil
+}
+
+// Brent finds a root of f in [a, b] with Brent's method, which combines
+// inverse quadratic interpolation and the secant method with bisection. It
+// is as safe as bisection and usually converges superlinearly. f(a) and
+// f(b) must have opposite signs.
+func Brent(f func(float64) float64, a, b float64, opts Options) (Result, error) {
+	tol, maxIterations, err := opts.settings("brent", defaultRootTol)
+	if err != nil {
+		return Result{}, err
+	}
+	e := &evaluator{op: "brent", f: f}
+	fa, fb, err := checkBracket(e, a, b)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// b is the best estimate and c the other end of the bracket; a is the
+	// previous value of b. d is the last step and prev the one before it.
+	c, fc := a, fa
+	d := b - a
+	prev := d
+	var r Result
+	for {
+		if (fb > 0) == (fc > 0) {
+			c, fc = a, fa
+			d = b - a
+			prev = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		r.X, r.Value = b, fb
+
+		limit := 2*epsilon*math.Abs(b) + tol/2*math.Max(1, math.Abs(b))
+		half := (c - b) / 2
+		if math.Abs(half) <= limit || fb == 0 {
+			r.Converged = true
+			return r, nil
+		}
+		if r.Iterations == maxIterations {
+			return notConverged("brent", r, half)
+		}
+		r.Iterations++
+
+		if math.Abs(prev) >= limit && math.Abs(fa) > math.Abs(fb) {
+			// Try interpolation: secant with two points, inverse quadratic
+			// with three
+			s := fb / fa
+			var p, q float64
+			if a == c {
+				p = 2 * half * s
+				q = 1 - s
+			} else {
+				q, t := fa/fc, fb/fc
+				p = s * (2*half*q*(q-t) - (b-a)*(t-1))
+				q = (q - 1) * (t - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			// Accept the interpolation only if it stays well inside the
+			// bracket and shrinks faster than bisection would
+			if 2*p < math.Min(3*half*q-math.Abs(limit*q), math.Abs(prev*q)) {
+				prev, d = d, p/q
+			} else {
+				d, prev = half, half
+			}
+		} else {
+			d, prev = half, half
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > limit {
+			b += d
+		} else {
+			b += math.Copysign(limit, half)
+		}
+		fb = e.eval(b)
+		if e.err != nil {
+			return Result{}, e.err
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+func TestRootFinders(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(float64) float64
+		a, b     float64
+		expected float64
+	}{
+		{"sqrt2", func(x float64) float64 { return x*x - 2 }, 0, 2, math.Sqrt2},
+		{"cos", math.Cos, 0, 3, math.Pi / 2},
+		{"cubic", func(x float64) float64 { return x*x*x - 2*x - 5 }, 2, 3, 2.0945514815423265},
+		{"large_root", func(x float64) float64 { return x - 1e6 }, 0, 2e6, 1e6},
+		{"root_at_end", func(x float64) float64 { return x - 1 }, 1, 5, 1},
+	}
+
+	finders := []struct {
+		name string
+		find func(f func(float64) float64, a, b float64) (Result, error)
+	}{
+		{"bisect", func(f func(float64) float64, a, b float64) (Result, error) {
+			return Bisect(f, a, b, Options{})
+		}},
+		{"brent", func(f func(float64) float64, a, b float64) (Result, error) {
+			return Brent(f, a, b, Options{})
+		}},
+		{"newton", func(f func(float64) float64, a, b float64) (Result, error) {
+			return Newton(f, nil, (a+b)/2, Options{})
+		}},
+		{"secant", func(f func(float64) float64, a, b float64) (Result, error) {
+			return Secant(f, a, b, Options{})
+		}},
+	}
+
+	for _, m := range finders {
+		for _, tt := range tests {
+			t.Run(m.name+"/"+tt.name, func(t *testing.T) {
+				got, err := m.find(tt.f, tt.a, tt.b)
+				if err != nil {
+					t.Fatalf("error = %v", err)
+				}
+				if !got.Converged {
+					t.Errorf("Converged = false")
+				}
+				if math.Abs(got.X-tt.expected) > 1e-10*math.Max(1, math.Abs(tt.expected)) {
+					t.Errorf("X = %v, want %v", got.X, tt.expected)
+				}
+				if got.Value != tt.f(got.X) {
+					t.Errorf("Value = %v, want f(X) = %v", got.Value, tt.f(got.X))
+				}
+			})
+		}
+	}
+}
+
+func TestNewtonWithDerivative(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2 }
+	df := func(x float64) float64 { return 2 * x }
+	got, err := Newton(f, df, 1, Options{})
+	if err != nil {
+		t.Fatalf("Newton() error = %v", err)
+	}
+	// Quadratic convergence from 1 needs only a handful of steps
+	if math.Abs(got.X-math.Sqrt2) > 1e-15 || got.Iterations > 6 {
+		t.Errorf("Newton() = %+v, want %v in at most 6 iterations", got, math.Sqrt2)
+	}
+}
+
+func TestBrentIsFasterThanBisection(t *testing.T) {
+	f := func(x float64) float64 { return math.Exp(x) - 3 }
+	brent, err := Brent(f, -10, 10, Options{})
+	if err != nil {
+		t.Fatalf("Brent() error = %v", err)
+	}
+	bisect, err := Bisect(f, -10, 10, Options{})
+	if err != nil {
+		t.Fatalf("Bisect() error = %v", err)
+	}
+	if brent.Iterations >= bisect.Iterations {
+		t.Errorf("Brent() took %d iterations, bisection %d", brent.Iterations, bisect.Iterations)
+	}
+}
+
+func TestRootFinderErrors(t *testing.T) {
+	square := func(x float64) float64 { return x*x - 2 }
+
+	tests := []struct {
+		name     string
+		err      func() error
+		expected error
+	}{
+		{"no_bracket", func() error {
+			_, err := Brent(square, 3, 4, Options{})
+			return err
+		}, ErrNoBracket},
+		{"bisect_no_bracket", func() error {
+			_, err := Bisect(square, -2, 2, Options{})
+			return err
+		}, ErrNoBracket},
+		{"zero_derivative", func() error {
+			_, err := Newton(square, nil, 0, Options{})
+			return err
+		}, ErrZeroDerivative},
+		{"flat_secant", func() error {
+			_, err := Secant(square, -1, 1, Options{})
+			return err
+		}, ErrZeroDerivative},
+		{"iteration_limit", func() error {
+			_, err := Bisect(square, 0, 2, Options{MaxIterations: 5})
+			return err
+		}, ErrNoConvergence},
+		{"newton_diverges", func() error {
+			// Newton's method on the cube root overshoots further each step
+			_, err := Newton(math.Cbrt, nil, 1, Options{MaxIterations: 20})
+			return err
+		}, ErrNoConvergence},
+		{"negative_tolerance", func() error {
+			_, err := Secant(square, 0, 1, Options{Tol: -1})
+			return err
+		}, ErrInvalidTolerance},
+		{"negative_iterations", func() error {
+			_, err := Brent(square, 0, 2, Options{MaxIterations: -1})
+			return err
+		}, ErrInvalidIterations},
+		{"pole", func() error {
+			_, err := Brent(func(x float64) float64 { return 1 / x }, 0, 1, Options{})
+			return err
+		}, calculator.ErrNonFinite},
+		{"nan_start", func() error {
+			_, err := Newton(square, nil, math.NaN(), Options{})
+			return err
+		}, calculator.ErrNonFinite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("error = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNotConvergedKeepsResult(t *testing.T) {
+	got, err := Bisect(func(x float64) float64 { return x*x - 2 }, 0, 2, Options{MaxIterations: 5})
+
+	var convErr *ConvergenceError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("Bisect() error = %v, want a *ConvergenceError", err)
+	}
+	if got.Converged || got.Iterations != 5 {
+		t.Errorf("Bisect() = %+v, want 5 iterations and not converged", got)
+	}
+	if convErr.Estimate != got.X || math.Abs(got.X-math.Sqrt2) > convErr.ErrorEstimate {
+		t.Errorf("ConvergenceError = %+v, want the estimate %v within its error of √2", convErr, got.X)
+	}
+}
@@ -0,0 +1,337 @@
+// Package poly provides polynomials with real coefficients: parsing,
+// evaluation, arithmetic, calculus and root finding.
+package poly
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+)
+
+// Sentinel errors returned by this package, wrapped in a
+// *calculator.DomainError whose operands are the polynomials involved.
+var (
+	// ErrZeroPolynomial is returned when finding the roots of the zero
+	// polynomial, which has every number as a root.
+	ErrZeroPolynomial = errors.New("every number is a root of the zero polynomial")
+
+	// ErrNoConvergence is returned when the iterative root finder used above
+	// degree 4 fails to converge.
+	ErrNoConvergence = errors.New("root finding did not converge")
+)
+
+// maxDegree is the largest exponent accepted by Parse, so a typo such as
+// x^1000000000 cannot exhaust memory.
+const maxDegree = 1000
+
+// Polynomial is a polynomial with real coefficients. The zero value is the
+// zero polynomial. Polynomials are immutable.
+type Polynomial struct {
+	// coeffs[i] is the coefficient of x^i. The last element, if any, is
+	// non-zero, so the zero polynomial has no coefficients.
+	coeffs []float64
+}
+
+// New returns the polynomial with the given coefficients, from the highest
+// degree down to the constant term, so New(3, -2, 1) is 3x^2 - 2x + 1.
+func New(coeffs ...float64) Polynomial {
+	c := make([]float64, len(coeffs))
+	for i, x := range coeffs {
+		c[len(coeffs)-1-i] = x
+	}
+	return fromCoeffs(c)
+}
+
+// fromCoeffs returns the polynomial with coefficients lowest degree first,
+// taking ownership of c.
+func fromCoeffs(c []float64) Polynomial {
+	n := len(c)
+	for n > 0 && c[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		return Polynomial{}
+	}
+	return Polynomial{coeffs: c[:n]}
+}
+
+// Parse parses a polynomial in one variable such as "3x^2 - 2x + 1",
+// "x^3-6x^2+11x-6" or "0.5*t^4 + t". Terms may appear in any order and like
+// terms are combined. Errors are *expr.SyntaxError values giving the column
+// of the problem.
+func Parse(input string) (Polynomial, error) {
+	tokens, err := expr.Tokenize(input)
+	if err != nil {
+		return Polynomial{}, err
+	}
+
+	var coeffs []float64
+	variable := ""
+	pos := 0
+	next := func() expr.Token {
+		tok := tokens[pos]
+		if tok.Kind != expr.EOF {
+			pos++
+		}
+		return tok
+	}
+	isOp := func(tok expr.Token, ops string) bool {
+		return tok.Kind == expr.Operator && strings.Contains(ops, tok.Text)
+	}
+
+	for first := true; tokens[pos].Kind != expr.EOF; first = false {
+		sign := 1.0
+		tok := next()
+		switch {
+		case isOp(tok, "+-"):
+			if tok.Text == "-" {
+				sign = -1
+			}
+			tok = next()
+		case !first:
+			return Polynomial{}, &expr.SyntaxError{Col: tok.Col, Msg: fmt.Sprintf("expected '+' or '-', found %s", describe(tok))}
+		}
+
+		coeff, power := 1.0, 0
+		hasCoeff := false
+		if tok.Kind == expr.Number {
+			coeff, err = strconv.ParseFloat(tok.Text, 64)
+			if err != nil || math.IsInf(coeff, 0) {
+				return Polynomial{}, &expr.SyntaxError{Col: tok.Col, Msg: fmt.Sprintf("invalid number %q", tok.Text)}
+			}
+			hasCoeff = true
+			if isOp(tokens[pos], "*") {
+				next()
+				if tokens[pos].Kind != expr.Ident {
+					tok = tokens[pos]
+					return Polynomial{}, &expr.SyntaxError{Col: tok.Col, Msg: fmt.Sprintf("expected variable, found %s", describe(tok))}
+				}
+			}
+			tok = tokens[pos]
+			if tok.Kind == expr.Ident {
+				next()
+			}
+		}
+
+		if tok.Kind == expr.Ident {
+			if variable == "" {
+				variable = tok.Text
+			} else if tok.Text != variable {
+				return Polynomial{}, &expr.SyntaxError{Col: tok.Col, Msg: fmt.Sprintf("polynomial has more than one variable: %s and %s", variable, tok.Text)}
+			}
+			power = 1
+			if isOp(tokens[pos], "^") {
+				next()
+				exp := next()
+				n, err := strconv.Atoi(exp.Text)
+				if exp.Kind != expr.Number || err != nil || n < 0 {
+					return Polynomial{}, &expr.SyntaxError{Col: exp.Col, Msg: fmt.Sprintf("exponent must be a non-negative integer, found %s", describe(exp))}
+				}
+				if n > maxDegree {
+					return Polynomial{}, &expr.SyntaxError{Col: exp.Col, Msg: fmt.Sprintf("exponent %d is larger than %d", n, maxDegree)}
+				}
+				power = n
+			}
+		} else if !hasCoeff {
+			return Polynomial{}, &expr.SyntaxError{Col: tok.Col, Msg: fmt.Sprintf("expected term, found %s", describe(tok))}
+		}
+
+		for len(coeffs) <= power {
+			coeffs = append(coeffs, 0)
+		}
+		coeffs[power] += sign * coeff
+	}
+
+	if len(tokens) == 1 {
+		return Polynomial{}, &expr.SyntaxError{Col: 1, Msg: "empty polynomial"}
+	}
+	return fromCoeffs(coeffs), nil
+}
+
+// describe names a token for error messages.
+func describe(tok expr.Token) string {
+	if tok.Kind == expr.EOF {
+		return tok.Kind.String()
+	}
+	return fmt.Sprintf("%q", tok.Text)
+}
+
+// Degree returns the degree of p, or -1 for the zero polynomial.
+func (p Polynomial) Degree() int {
+	return len(p.coeffs) - 1
+}
+
+// Coefficients returns the coefficients of p from the highest degree down
+// to the constant term, the order taken by New. The zero polynomial has
+// none.
+func (p Polynomial) Coefficients() []float64 {
+	c := make([]float64, len(p.coeffs))
+	for i, x := range p.coeffs {
+		c[len(c)-1-i] = x
+	}
+	return c
+}
+
+// Coefficient returns the coefficient of x^i.
+func (p Polynomial) Coefficient(i int) float64 {
+	if i < 0 || i >= len(p.coeffs) {
+		return 0
+	}
+	return p.coeffs[i]
+}
+
+// IsZero reports whether p is the zero polynomial.
+func (p Polynomial) IsZero() bool {
+	return len(p.coeffs) == 0
+}
+
+// Eval returns p(x), computed with Horner's method.
+func (p Polynomial) Eval(x float64) float64 {
+	sum := 0.0
+	for i := len(p.coeffs) - 1; i >= 0; i-- {
+		sum = sum*x + p.coeffs[i]
+	}
+	return sum
+}
+
+// EvalComplex returns p(z) for a complex z, computed with Horner's method.
+func (p Polynomial) EvalComplex(z complex128) complex128 {
+	var sum complex128
+	for i := len(p.coeffs) - 1; i >= 0; i-- {
+		sum = sum*z + complex(p.coeffs[i], 0)
+	}
+	return sum
+}
+
+// String returns p in the form accepted by Parse, e.g. "3x^2 - 2x + 1".
+func (p Polynomial) String() string {
+	if p.IsZero() {
+		return "0"
+	}
+	var b strings.Builder
+	for i := len(p.coeffs) - 1; i >= 0; i-- {
+		c := p.coeffs[i]
+		if c == 0 {
+			continue
+		}
+		switch {
+		case b.Len() == 0 && c < 0:
+			b.WriteString("-")
+		case b.Len() > 0 && c < 0:
+			b.WriteString(" - ")
+		case b.Len() > 0:
+			b.WriteString(" + ")
+		}
+		c = math.Abs(c)
+		if c != 1 || i == 0 {
+			b.WriteString(strconv.FormatFloat(c, 'g', -1, 64))
+		}
+		switch {
+		case i == 1:
+			b.WriteString("x")
+		case i > 1:
+			b.WriteString("x^")
+			b.WriteString(strconv.Itoa(i))
+		}
+	}
+	return b.String()
+}
+
+// Add returns p + q.
+func Add(p, q Polynomial) Polynomial {
+	c := make([]float64, maxInt(len(p.coeffs), len(q.coeffs)))
+	copy(c, p.coeffs)
+	for i, x := range q.coeffs {
+		c[i] += x
+	}
+	return fromCoeffs(c)
+}
+
+// Subtract returns p - q.
+func Subtract(p, q Polynomial) Polynomial {
+	c := make([]float64, maxInt(len(p.coeffs), len(q.coeffs)))
+	copy(c, p.coeffs)
+	for i, x := range q.coeffs {
+		c[i] -= x
+	}
+	return fromCoeffs(c)
+}
+
+// Multiply returns p * q.
+func Multiply(p, q Polynomial) Polynomial {
+	if p.IsZero() || q.IsZero() {
+		return Polynomial{}
+	}
+	c := make([]float64, len(p.coeffs)+len(q.coeffs)-1)
+	for i, x := range p.coeffs {
+		for j, y := range q.coeffs {
+			c[i+j] += x * y
+		}
+	}
+	return fromCoeffs(c)
+}
+
+// Divide returns the quotient and remainder of p divided by q, such that
+// p = quotient*q + remainder and the remainder has a lower degree than q.
+// Returns an error if q is the zero polynomial.
+func Divide(p, q Polynomial) (quotient, remainder Polynomial, err error) {
+	if q.IsZero() {
+		return Polynomial{}, Polynomial{}, &calculator.DomainError{
+			Op:       "divide",
+			Operands: []interface{}{p.String(), q.String()},
+			Reason:   calculator.ErrDivisionByZero,
+		}
+	}
+	if p.Degree() < q.Degree() {
+		return Polynomial{}, p, nil
+	}
+
+	rem := append([]float64(nil), p.coeffs...)
+	quot := make([]float64, p.Degree()-q.Degree()+1)
+	lead := q.coeffs[len(q.coeffs)-1]
+	for i := len(quot) - 1; i >= 0; i-- {
+		// Cancel the current leading term of the remainder
+		factor := rem[i+q.Degree()] / lead
+		quot[i] = factor
+		for j, y := range q.coeffs {
+			rem[i+j] -= factor * y
+		}
+		rem[i+q.Degree()] = 0
+	}
+	return fromCoeffs(quot), fromCoeffs(rem[:q.Degree()]), nil
+}
+
+// Derivative returns the derivative of p.
+func (p Polynomial) Derivative() Polynomial {
+	if len(p.coeffs) <= 1 {
+		return Polynomial{}
+	}
+	c := make([]float64, len(p.coeffs)-1)
+	for i := range c {
+		c[i] = float64(i+1) * p.coeffs[i+1]
+	}
+	return fromCoeffs(c)
+}
+
+// Integral returns the antiderivative of p whose constant term is k.
+func (p Polynomial) Integral(k float64) Polynomial {
+	c := make([]float64, len(p.coeffs)+1)
+	c[0] = k
+	for i, x := range p.coeffs {
+		c[i+1] = x / float64(i+1)
+	}
+	return fromCoeffs(c)
+}
+
+// maxInt returns the larger of a and b.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
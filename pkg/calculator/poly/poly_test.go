@@ -0,0 +1,232 @@
+package poly
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+)
+
+const tolerance = 1e-9
+
+// mustParse returns the parsed polynomial or fails the test.
+func mustParse(t *testing.T, s string) Polynomial {
+	t.Helper()
+	p, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", s, err)
+	}
+	return p
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []float64 // highest degree first
+	}{
+		{"quadratic", "3x^2 - 2x + 1", []float64{3, -2, 1}},
+		{"no_spaces", "x^3-6x^2+11x-6", []float64{1, -6, 11, -6}},
+		{"explicit_multiplication", "0.5*t^4 + t", []float64{0.5, 0, 0, 1, 0}},
+		{"leading_minus", "-x", []float64{-1, 0}},
+		{"any_order", "1 + x^2", []float64{1, 0, 1}},
+		{"like_terms", "x + 2x - x^0", []float64{3, -1}},
+		{"constant", "42", []float64{42}},
+		{"exponent_notation", "1e2x", []float64{100, 0}},
+		{"cancels_to_zero", "x - x", []float64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mustParse(t, tt.input).Coefficients()
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Parse(%q).Coefficients() = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		col   int
+	}{
+		{"empty", "", 1},
+		{"trailing_operator", "3x^2 -", 7},
+		{"two_variables", "x + y", 5},
+		{"negative_exponent", "x^-1", 3},
+		{"fractional_exponent", "x^2.5", 3},
+		{"huge_exponent", "x^1001", 3},
+		{"missing_operator", "3x 2", 4},
+		{"dangling_multiplication", "2*", 3},
+		{"function_call", "sin(x)", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var syntaxErr *expr.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) error = %v, want a *expr.SyntaxError", tt.input, err)
+			}
+			if syntaxErr.Col != tt.col {
+				t.Errorf("Parse(%q) error column = %d, want %d (%v)", tt.input, syntaxErr.Col, tt.col, err)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        Polynomial
+		expected string
+	}{
+		{"quadratic", New(3, -2, 1), "3x^2 - 2x + 1"},
+		{"unit_coefficients", New(-1, 0, 1, -1), "-x^3 + x - 1"},
+		{"fraction", New(0.5, 0), "0.5x"},
+		{"constant", New(-7), "-7"},
+		{"zero", New(), "0"},
+		{"leading_zeros_dropped", New(0, 0, 2), "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.String(); got != tt.expected {
+				t.Errorf("String() = %q, want %q", got, tt.expected)
+			}
+			if got := mustParse(t, tt.expected); !reflect.DeepEqual(got, tt.p) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.expected, got, tt.p)
+			}
+		})
+	}
+}
+
+func TestDegreeAndCoefficient(t *testing.T) {
+	p := New(3, -2, 1)
+	if got := p.Degree(); got != 2 {
+		t.Errorf("Degree() = %d, want 2", got)
+	}
+	if got := New().Degree(); got != -1 {
+		t.Errorf("Degree() of the zero polynomial = %d, want -1", got)
+	}
+	if got := p.Coefficient(1); got != -2 {
+		t.Errorf("Coefficient(1) = %v, want -2", got)
+	}
+	if got := p.Coefficient(5); got != 0 {
+		t.Errorf("Coefficient(5) = %v, want 0", got)
+	}
+}
+
+func TestEval(t *testing.T) {
+	p := New(3, -2, 1)
+
+	tests := []struct {
+		x, expected float64
+	}{
+		{0, 1},
+		{1, 2},
+		{-2, 17},
+		{0.5, 0.75},
+	}
+
+	for _, tt := range tests {
+		if got := p.Eval(tt.x); got != tt.expected {
+			t.Errorf("Eval(%v) = %v, want %v", tt.x, got, tt.expected)
+		}
+	}
+
+	if got, want := p.EvalComplex(1i), complex(-2, -2); got != want {
+		t.Errorf("EvalComplex(i) = %v, want %v", got, want)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	p := New(1, 0, -1) // x^2 - 1
+	q := New(1, 1)     // x + 1
+
+	tests := []struct {
+		name     string
+		got      Polynomial
+		expected Polynomial
+	}{
+		{"add", Add(p, q), New(1, 1, 0)},
+		{"subtract", Subtract(p, q), New(1, -1, -2)},
+		{"subtract_self", Subtract(p, p), New()},
+		{"multiply", Multiply(p, q), New(1, 1, -1, -1)},
+		{"multiply_zero", Multiply(p, New()), New()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.expected) {
+				t.Errorf("got %v, want %v", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDivide(t *testing.T) {
+	tests := []struct {
+		name                string
+		p, q                Polynomial
+		quotient, remainder Polynomial
+		expectError         error
+	}{
+		{"exact", New(1, 0, -1), New(1, 1), New(1, -1), New(), nil},
+		{"with_remainder", New(2, 3, 4), New(1, 1), New(2, 1), New(3), nil},
+		{"non_monic_divisor", New(4, 0, 1), New(2, 0), New(2, 0), New(1), nil},
+		{"lower_degree", New(1, 2), New(1, 0, 0), New(), New(1, 2), nil},
+		{"by_zero", New(1, 2), New(), New(), New(), calculator.ErrDivisionByZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotient, remainder, err := Divide(tt.p, tt.q)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("Divide(%v, %v) error = %v, want %v", tt.p, tt.q, err, tt.expectError)
+				return
+			}
+			if tt.expectError != nil {
+				return
+			}
+			if !reflect.DeepEqual(quotient, tt.quotient) || !reflect.DeepEqual(remainder, tt.remainder) {
+				t.Errorf("Divide(%v, %v) = %v, %v, want %v, %v", tt.p, tt.q, quotient, remainder, tt.quotient, tt.remainder)
+			}
+		})
+	}
+}
+
+func TestCalculus(t *testing.T) {
+	p := New(3, -2, 1)
+
+	if got, want := p.Derivative(), New(6, -2); !reflect.DeepEqual(got, want) {
+		t.Errorf("Derivative() = %v, want %v", got, want)
+	}
+	if got := New(5).Derivative(); !got.IsZero() {
+		t.Errorf("Derivative() of a constant = %v, want 0", got)
+	}
+	if got, want := p.Integral(4), New(1, -1, 1, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("Integral(4) = %v, want %v", got, want)
+	}
+	if got := p.Integral(0).Derivative(); !reflect.DeepEqual(got, p) {
+		t.Errorf("Integral(0).Derivative() = %v, want %v", got, p)
+	}
+}
+
+func TestOperationsDoNotModifyOperands(t *testing.T) {
+	p := New(1, 2, 3)
+	_ = Add(p, p)
+	_, _, _ = Divide(p, New(1, 1))
+	_ = p.Derivative()
+	if got := p.Coefficients(); !reflect.DeepEqual(got, []float64{1, 2, 3}) {
+		t.Errorf("coefficients changed to %v", got)
+	}
+	if math.Abs(p.Eval(1)-6) > tolerance {
+		t.Errorf("Eval(1) = %v, want 6", p.Eval(1))
+	}
+}
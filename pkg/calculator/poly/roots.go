@@ -0,0 +1,286 @@
+package poly
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// realTolerance is the relative size below which the imaginary part of a
+// root is treated as rounding error and the root reported as real.
+const realTolerance = 1e-9
+
+// Roots returns all roots of p, real and complex, repeated according to
+// their multiplicity. Real roots come first in ascending order, followed by
+// complex roots ordered by real and then imaginary part; complex roots come
+// in conjugate pairs. Roots up to degree 4 use the closed-form solutions and
+// higher degrees the Durand–Kerner iteration, each polished with Newton's
+// method. A root of multiplicity k is accurate to only about the k-th root
+// of machine precision, as for any floating-point method. A non-zero
+// constant has no roots.
+func (p Polynomial) Roots() ([]complex128, error) {
+	if p.IsZero() {
+		return nil, &calculator.DomainError{Op: "roots", Operands: []interface{}{p.String()}, Reason: ErrZeroPolynomial}
+	}
+	for _, c := range p.coeffs {
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			return nil, &calculator.DomainError{Op: "roots", Operands: []interface{}{p.String()}, Reason: calculator.ErrNonFinite}
+		}
+	}
+
+	// Zero roots are exact: factor out the lowest power of x
+	zeros := 0
+	for p.coeffs[zeros] == 0 {
+		zeros++
+	}
+	roots := make([]complex128, zeros, p.Degree())
+	q := Polynomial{coeffs: p.coeffs[zeros:]}
+
+	var found []complex128
+	c := q.coeffs
+	switch q.Degree() {
+	case 0:
+	case 1:
+		found = []complex128{complex(-c[0]/c[1], 0)}
+	case 2:
+		found = quadraticRoots(c[2], c[1], c[0])
+	case 3:
+		found = cubicRoots(c[3], c[2], c[1], c[0])
+	case 4:
+		found = quarticRoots(c[4], c[3], c[2], c[1], c[0])
+	default:
+		var ok bool
+		found, ok = durandKerner(q)
+		if !ok {
+			return nil, &calculator.DomainError{Op: "roots", Operands: []interface{}{p.String()}, Reason: ErrNoConvergence}
+		}
+	}
+	for _, z := range found {
+		z = polish(q, z)
+		if math.Abs(imag(z)) <= realTolerance*math.Max(1, cmplx.Abs(z)) {
+			z = complex(real(z), 0)
+		}
+		// Adding zero turns -0 parts into 0
+		roots = append(roots, complex(real(z)+0, imag(z)+0))
+	}
+
+	sort.Slice(roots, func(i, j int) bool {
+		ri, rj := imag(roots[i]) == 0, imag(roots[j]) == 0
+		switch {
+		case ri != rj:
+			return ri
+		case real(roots[i]) != real(roots[j]):
+			return real(roots[i]) < real(roots[j])
+		default:
+			return imag(roots[i]) < imag(roots[j])
+		}
+	})
+	return roots, nil
+}
+
+// RealRoots returns the real roots of p in ascending order, repeated
+// according to their multiplicity.
+func (p Polynomial) RealRoots() ([]float64, error) {
+	roots, err := p.Roots()
+	if err != nil {
+		return nil, err
+	}
+	var values []float64
+	for _, z := range roots {
+		if imag(z) == 0 {
+			values = append(values, real(z))
+		}
+	}
+	return values, nil
+}
+
+// quadraticRoots returns the roots of ax^2 + bx + c, avoiding the
+// cancellation of the textbook formula.
+func quadraticRoots(a, b, c float64) []complex128 {
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		re := -b / (2 * a)
+		im := math.Sqrt(-disc) / (2 * math.Abs(a))
+		return []complex128{complex(re, -im), complex(re, im)}
+	}
+
+	q := -(b + math.Copysign(math.Sqrt(disc), b)) / 2
+	if q == 0 {
+		// b and c are both zero
+		return []complex128{0, 0}
+	}
+	return []complex128{complex(q/a, 0), complex(c/q, 0)}
+}
+
+// cubicRoots returns the roots of ax^3 + bx^2 + cx + d, using Cardano's
+// formula when there is one real root and the trigonometric method when
+// there are three.
+func cubicRoots(a, b, c, d float64) []complex128 {
+	// Substitute x = t - b/3a to get the depressed cubic t^3 + pt + q
+	b, c, d = b/a, c/a, d/a
+	shift := -b / 3
+	p := c - b*b/3
+	q := 2*b*b*b/27 - b*c/3 + d
+
+	disc := q*q/4 + p*p*p/27
+	scale := q*q/4 + math.Abs(p*p*p/27)
+	if math.Abs(disc) <= 1e-12*scale {
+		// A repeated root, which rounding would otherwise split
+		disc = 0
+	}
+
+	switch {
+	case p == 0 && q == 0:
+		return []complex128{complex(shift, 0), complex(shift, 0), complex(shift, 0)}
+	case disc > 0:
+		// One real root and a complex conjugate pair
+		u := -math.Copysign(math.Cbrt(math.Abs(q)/2+math.Sqrt(disc)), q)
+		v := 0.0
+		if u != 0 {
+			v = -p / (3 * u)
+		}
+		re := -(u+v)/2 + shift
+		im := math.Sqrt(3) / 2 * math.Abs(u-v)
+		return []complex128{complex(u+v+shift, 0), complex(re, -im), complex(re, im)}
+	default:
+		// Three real roots
+		r := 2 * math.Sqrt(-p/3)
+		cos := 3 * q / (p * r)
+		phi := math.Acos(math.Max(-1, math.Min(1, cos))) / 3
+		roots := make([]complex128, 3)
+		for k := range roots {
+			roots[k] = complex(r*math.Cos(phi-2*math.Pi*float64(k)/3)+shift, 0)
+		}
+		return roots
+	}
+}
+
+// quarticRoots returns the roots of ax^4 + bx^3 + cx^2 + dx + e using
+// Ferrari's method.
+func quarticRoots(a, b, c, d, e float64) []complex128 {
+	// Substitute x = y - b/4a to get the depressed quartic y^4 + py^2 + qy + r
+	b, c, d, e = b/a, c/a, d/a, e/a
+	shift := -b / 4
+	p := c - 3*b*b/8
+	q := b*b*b/8 - b*c/2 + d
+	r := -3*b*b*b*b/256 + b*b*c/16 - b*d/4 + e
+
+	var roots []complex128
+	if math.Abs(q) <= 1e-14*(1+math.Abs(p)+math.Abs(r)) {
+		// Biquadratic: solve for y^2
+		for _, z := range quadraticRoots(1, p, r) {
+			y := cmplx.Sqrt(z)
+			roots = append(roots, y, -y)
+		}
+	} else {
+		// A positive root m of the resolvent cubic splits the quartic into
+		// the quadratics y^2 ∓ sy + (p/2 + m ± q/2s), where s = √(2m). One
+		// exists because the resolvent is -q^2 < 0 at m = 0.
+		m := 0.0
+		for _, z := range cubicRoots(8, 8*p, 2*p*p-8*r, -q*q) {
+			if imag(z) == 0 && real(z) > m {
+				m = real(z)
+			}
+		}
+		s := math.Sqrt(2 * m)
+		roots = append(quadraticRoots(1, -s, p/2+m+q/(2*s)), quadraticRoots(1, s, p/2+m-q/(2*s))...)
+	}
+
+	for i := range roots {
+		roots[i] += complex(shift, 0)
+	}
+	return roots
+}
+
+// durandKerner finds all roots of p simultaneously with the Durand–Kerner
+// (Weierstrass) iteration. It reports false if the iteration did not
+// converge.
+func durandKerner(p Polynomial) ([]complex128, bool) {
+	const maxIterations = 1000
+	n := p.Degree()
+	lead := p.coeffs[n]
+
+	// Start on a circle enclosing every root (Cauchy's bound), at angles
+	// that avoid the symmetry of real coefficients
+	bound := 0.0
+	for _, c := range p.coeffs[:n] {
+		bound = math.Max(bound, math.Abs(c/lead))
+	}
+	bound++
+	roots := make([]complex128, n)
+	seed := complex(0.4, 0.9)
+	z := complex(1, 0)
+	for i := range roots {
+		roots[i] = complex(bound, 0) * z
+		z *= seed
+	}
+
+	for iter := 0; iter < maxIterations; iter++ {
+		converged := true
+		for i, zi := range roots {
+			denom := complex(lead, 0)
+			for j, zj := range roots {
+				if i != j {
+					denom *= zi - zj
+				}
+			}
+			if denom == 0 {
+				// Two estimates coincide; nudge one apart
+				roots[i] = zi + complex(1e-8, 1e-8)
+				converged = false
+				continue
+			}
+			value := p.EvalComplex(zi)
+			step := value / denom
+			roots[i] = zi - step
+			// Stop when the steps are negligible, or when p(zi) is as small
+			// as rounding allows, which is as close as a repeated root gets
+			small := cmplx.Abs(step) <= 1e-12*math.Max(1, cmplx.Abs(zi))
+			if !small && cmplx.Abs(value) > 1e-14*absEval(p, cmplx.Abs(zi)) {
+				converged = false
+			}
+		}
+		if converged {
+			break
+		}
+		if iter == maxIterations-1 {
+			return nil, false
+		}
+	}
+	return roots, true
+}
+
+// absEval returns the sum of |c_i| x^i over the coefficients of p, which
+// bounds the rounding error of evaluating p at a point of modulus x.
+func absEval(p Polynomial, x float64) float64 {
+	sum := 0.0
+	for i := len(p.coeffs) - 1; i >= 0; i-- {
+		sum = sum*x + math.Abs(p.coeffs[i])
+	}
+	return sum
+}
+
+// polish refines a root with a few steps of Newton's method, keeping a step
+// only if it makes |p(z)| smaller. Real roots stay real.
+func polish(p Polynomial, z complex128) complex128 {
+	dp := p.Derivative()
+	best := cmplx.Abs(p.EvalComplex(z))
+	for i := 0; i < 3 && best > 0; i++ {
+		d := dp.EvalComplex(z)
+		if d == 0 {
+			break
+		}
+		next := z - p.EvalComplex(z)/d
+		if imag(z) == 0 {
+			next = complex(real(next), 0)
+		}
+		value := cmplx.Abs(p.EvalComplex(next))
+		if value >= best {
+			break
+		}
+		z, best = next, value
+	}
+	return z
+}
@@ -0,0 +1,95 @@
+package poly
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+func TestRoots(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  []complex128
+		tolerance float64
+	}{
+		{"constant", "5", nil, tolerance},
+		{"linear", "2x - 3", []complex128{1.5}, tolerance},
+		{"quadratic_real", "x^2 - 3x + 2", []complex128{1, 2}, tolerance},
+		{"quadratic_complex", "x^2 + 1", []complex128{-1i, 1i}, tolerance},
+		{"quadratic_cancellation", "x^2 + 1e8x + 1", []complex128{-1e8, -1e-8}, 1e-15},
+		{"cubic_three_real", "x^3-6x^2+11x-6", []complex128{1, 2, 3}, tolerance},
+		{"cubic_one_real", "x^3 - 1", []complex128{1, complex(-0.5, -0.8660254037844386), complex(-0.5, 0.8660254037844386)}, tolerance},
+		{"cubic_double_root", "x^3 - 4x^2 + 5x - 2", []complex128{1, 1, 2}, 1e-6},
+		{"cubic_triple_root", "x^3 - 3x^2 + 3x - 1", []complex128{1, 1, 1}, tolerance},
+		{"zero_roots", "x^3 - x^2", []complex128{0, 0, 1}, tolerance},
+		{"quartic_real", "x^4 - 10x^2 + 9", []complex128{-3, -1, 1, 3}, tolerance},
+		{"quartic_complex", "x^4 + 1", []complex128{
+			complex(-0.7071067811865476, -0.7071067811865476), complex(-0.7071067811865476, 0.7071067811865476),
+			complex(0.7071067811865476, -0.7071067811865476), complex(0.7071067811865476, 0.7071067811865476),
+		}, tolerance},
+		{"quartic_mixed", "x^4 - 2x^3 + 2x^2 - 2x + 1", []complex128{1, 1, -1i, 1i}, 1e-6},
+		{"quintic", "x^5 - 15x^4 + 85x^3 - 225x^2 + 274x - 120", []complex128{1, 2, 3, 4, 5}, 1e-9},
+		{"sextic_complex", "x^6 + 1", []complex128{
+			complex(-0.8660254037844386, -0.5), complex(-0.8660254037844386, 0.5), -1i, 1i,
+			complex(0.8660254037844386, -0.5), complex(0.8660254037844386, 0.5),
+		}, tolerance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mustParse(t, tt.input).Roots()
+			if err != nil {
+				t.Fatalf("Roots() error = %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Roots() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if cmplx.Abs(got[i]-tt.expected[i]) > tt.tolerance*math.Max(1, cmplx.Abs(tt.expected[i])) {
+					t.Errorf("Roots() = %v, want %v", got, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRootsAreRoots(t *testing.T) {
+	// Degree 8 with no special structure, so only p(z) ≈ 0 can be checked
+	p := mustParse(t, "x^8 + 3x^5 - x + 100")
+	roots, err := p.Roots()
+	if err != nil {
+		t.Fatalf("Roots() error = %v", err)
+	}
+	if len(roots) != 8 {
+		t.Fatalf("len(Roots()) = %d, want 8", len(roots))
+	}
+	for _, z := range roots {
+		if v := cmplx.Abs(p.EvalComplex(z)); v > 1e-9 {
+			t.Errorf("|p(%v)| = %v, want 0", z, v)
+		}
+	}
+}
+
+func TestRealRoots(t *testing.T) {
+	got, err := mustParse(t, "x^3 - x^2 + x - 1").RealRoots()
+	if err != nil {
+		t.Fatalf("RealRoots() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("RealRoots() = %v, want [1]", got)
+	}
+}
+
+func TestRootsErrors(t *testing.T) {
+	if _, err := New().Roots(); !errors.Is(err, ErrZeroPolynomial) {
+		t.Errorf("Roots() of the zero polynomial error = %v, want %v", err, ErrZeroPolynomial)
+	}
+	if _, err := New(1, math.Inf(1)).Roots(); !errors.Is(err, calculator.ErrNonFinite) {
+		t.Errorf("Roots() with an infinite coefficient error = %v, want %v", err, calculator.ErrNonFinite)
+	}
+}
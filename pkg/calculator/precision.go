@@ -0,0 +1,274 @@
+package calculator
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// DefaultPrecision is the mantissa size, in bits, used by NewNumber and
+// ParseNumber when a precision of 0 is requested.
+const DefaultPrecision uint = 256
+
+// MaxPrecision is the largest mantissa size, in bits, of a Number, about
+// 4,900 decimal digits. Larger mantissas make printing and the
+// transcendental helpers too slow to be useful, and big.Float cannot print
+// the largest ones at all.
+const MaxPrecision uint = 16384
+
+// guardBits is the extra working precision used by the transcendental
+// helpers so that the final rounding to the caller's precision is correct.
+const guardBits = 64
+
+// Number is an arbitrary-precision floating-point number backed by
+// big.Float. The zero value is not usable; create values with NewNumber or
+// ParseNumber. Operations never modify their operands and return a result
+// with the larger of the operand precisions.
+type Number struct {
+	f *big.Float
+}
+
+// NewNumber returns a Number holding x with a mantissa of prec bits, or of
+// MaxPrecision bits if prec is larger. Like big.Float.SetFloat64, it
+// panics if x is NaN.
+func NewNumber(x float64, prec uint) Number {
+	if prec > MaxPrecision {
+		prec = MaxPrecision
+	}
+	return Number{f: new(big.Float).SetPrec(precisionOrDefault(prec)).SetFloat64(x)}
+}
+
+// ParseNumber parses a decimal string, such as "0.1" or "1e-30", into a
+// Number with a mantissa of prec bits. Unlike strconv.ParseFloat the value
+// is rounded only once, to prec bits. Infinite values are rejected.
+// Returns ErrPrecisionTooLarge if prec is above MaxPrecision.
+func ParseNumber(s string, prec uint) (Number, error) {
+	if prec > MaxPrecision {
+		return Number{}, fmt.Errorf("%w: %d bits (at most %d)", ErrPrecisionTooLarge, prec, MaxPrecision)
+	}
+	f, _, err := big.ParseFloat(s, 10, precisionOrDefault(prec), big.ToNearestEven)
+	if err != nil {
+		return Number{}, fmt.Errorf("invalid number %q: %w", s, err)
+	}
+	if f.IsInf() {
+		return Number{}, fmt.Errorf("invalid number %q: infinite values are not supported", s)
+	}
+	return Number{f: f}, nil
+}
+
+// Prec returns the mantissa precision of n in bits.
+func (n Number) Prec() uint {
+	return n.f.Prec()
+}
+
+// Float returns a copy of the underlying big.Float.
+func (n Number) Float() *big.Float {
+	return new(big.Float).Copy(n.f)
+}
+
+// Float64 returns the float64 value nearest to n.
+func (n Number) Float64() float64 {
+	f, _ := n.f.Float64()
+	return f
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of n.
+func (n Number) Sign() int {
+	return n.f.Sign()
+}
+
+// String returns the shortest decimal representation that identifies n
+// uniquely at its precision.
+func (n Number) String() string {
+	return n.f.Text('f', -1)
+}
+
+// AddNumber returns the sum of two numbers.
+func AddNumber(a, b Number) Number {
+	return Number{f: newResult(a, b).Add(a.f, b.f)}
+}
+
+// SubtractNumber returns the difference between two numbers.
+func SubtractNumber(a, b Number) Number {
+	return Number{f: newResult(a, b).Sub(a.f, b.f)}
+}
+
+// MultiplyNumber returns the product of two numbers.
+func MultiplyNumber(a, b Number) Number {
+	return Number{f: newResult(a, b).Mul(a.f, b.f)}
+}
+
+// DivideNumber returns the quotient of two numbers.
+// Returns the same error as Divide if the divisor is zero.
+func DivideNumber(a, b Number) (Number, error) {
+	if b.f.Sign() == 0 {
+		return Number{}, domainError("divide", ErrDivisionByZero, a, b)
+	}
+	return Number{f: newResult(a, b).Quo(a.f, b.f)}, nil
+}
+
+// PowerNumber returns the result of raising a to the power of b.
+// Integer exponents are computed by repeated squaring, so the result is
+// exact whenever it fits in the mantissa. Other exponents are computed as
+// exp(b*ln(a)). Returns the same errors as Power for a zero base with a
+// negative exponent, for a negative base with a fractional exponent and for
+// a result beyond the exponent range of big.Float.
+func PowerNumber(a, b Number) (Number, error) {
+	result, err := powerNumber(a, b)
+	if err == nil && result.f.IsInf() {
+		return Number{}, domainError("power", ErrOverflow, a, b)
+	}
+	return result, err
+}
+
+// powerNumber implements PowerNumber, returning an infinite result when the
+// power overflows.
+func powerNumber(a, b Number) (Number, error) {
+	prec := newResult(a, b).Prec()
+
+	if a.f.Sign() == 0 && b.f.Sign() < 0 {
+		return Number{}, domainError("power", ErrDivisionByZero, a, b)
+	}
+
+	negate := false
+	if b.f.IsInt() {
+		e, _ := b.f.Int(nil)
+		if e.IsInt64() {
+			return Number{f: powInt(a.f, e.Int64(), prec)}, nil
+		}
+		// Exponents too large for repeated squaring fall through to
+		// exp/ln on |a|, with the sign taken from the exponent's parity.
+		negate = a.f.Sign() < 0 && e.Bit(0) == 1
+	} else if a.f.Sign() < 0 {
+		return Number{}, domainError("power", ErrNegativeFractionalPower, a, b)
+	}
+
+	if a.f.Sign() == 0 {
+		return Number{f: new(big.Float).SetPrec(prec)}, nil
+	}
+
+	work := prec + guardBits
+	ln := bigLog(new(big.Float).Abs(a.f), work)
+	result := bigExp(new(big.Float).SetPrec(work).Mul(ln, b.f), work)
+	if negate {
+		result.Neg(result)
+	}
+	return Number{f: result.SetPrec(prec)}, nil
+}
+
+// SquareRootNumber returns the square root of a number.
+// Returns the same error as SquareRoot if the number is negative.
+func SquareRootNumber(a Number) (Number, error) {
+	if a.f.Sign() < 0 {
+		return Number{}, domainError("sqrt", ErrNegativeSquareRoot, a)
+	}
+	return Number{f: new(big.Float).SetPrec(a.f.Prec()).Sqrt(a.f)}, nil
+}
+
+// precisionOrDefault maps a precision of 0 to DefaultPrecision.
+func precisionOrDefault(prec uint) uint {
+	if prec == 0 {
+		return DefaultPrecision
+	}
+	return prec
+}
+
+// newResult returns an empty big.Float with the larger precision of a and b.
+func newResult(a, b Number) *big.Float {
+	prec := a.f.Prec()
+	if b.f.Prec() > prec {
+		prec = b.f.Prec()
+	}
+	return new(big.Float).SetPrec(prec)
+}
+
+// powInt raises x to an integer power by repeated squaring. x must not be
+// zero when e is negative.
+func powInt(x *big.Float, e int64, prec uint) *big.Float {
+	negative := e < 0
+	if negative {
+		e = -e
+	}
+
+	result := new(big.Float).SetPrec(prec).SetInt64(1)
+	base := new(big.Float).SetPrec(prec).Set(x)
+	for e > 0 {
+		if e&1 == 1 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+		e >>= 1
+	}
+
+	if negative {
+		result.Quo(new(big.Float).SetPrec(prec).SetInt64(1), result)
+	}
+	return result
+}
+
+// bigExp computes e^x to prec bits. The argument is halved until it is
+// small, the Taylor series is summed, and the result is squared back up.
+func bigExp(x *big.Float, prec uint) *big.Float {
+	half := new(big.Float).SetFloat64(0.5)
+	r := new(big.Float).SetPrec(prec).Set(x)
+	squarings := 0
+	for r.MantExp(nil) > -1 && r.Sign() != 0 {
+		r.Mul(r, half)
+		squarings++
+	}
+
+	sum := new(big.Float).SetPrec(prec).SetInt64(1)
+	term := new(big.Float).SetPrec(prec).SetInt64(1)
+	limit := -int(prec)
+	for k := int64(1); ; k++ {
+		term.Mul(term, r)
+		term.Quo(term, new(big.Float).SetInt64(k))
+		if term.Sign() == 0 || term.MantExp(nil) < limit {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	for i := 0; i < squarings; i++ {
+		sum.Mul(sum, sum)
+	}
+	return sum
+}
+
+// bigLog computes the natural logarithm of a positive x to prec bits using
+// ln(x) = ln(m) + e*ln(2) with x = m * 2^e, and ln(m) = 2*atanh((m-1)/(m+1)).
+func bigLog(x *big.Float, prec uint) *big.Float {
+	m := new(big.Float).SetPrec(prec)
+	exp := x.MantExp(m)
+
+	result := bigAtanhLog(m, prec)
+	if exp != 0 {
+		ln2 := bigAtanhLog(new(big.Float).SetPrec(prec).SetInt64(2), prec)
+		ln2.Mul(ln2, new(big.Float).SetInt64(int64(exp)))
+		result.Add(result, ln2)
+	}
+	return result
+}
+
+// bigAtanhLog computes ln(m) = 2*atanh((m-1)/(m+1)) by summing the series
+// z + z^3/3 + z^5/5 + ... which converges quickly for m near 1.
+func bigAtanhLog(m *big.Float, prec uint) *big.Float {
+	one := new(big.Float).SetInt64(1)
+	num := new(big.Float).SetPrec(prec).Sub(m, one)
+	den := new(big.Float).SetPrec(prec).Add(m, one)
+	z := new(big.Float).SetPrec(prec).Quo(num, den)
+	z2 := new(big.Float).SetPrec(prec).Mul(z, z)
+
+	sum := new(big.Float).SetPrec(prec).Set(z)
+	power := new(big.Float).SetPrec(prec).Set(z)
+	term := new(big.Float).SetPrec(prec)
+	limit := -int(prec)
+	for k := int64(3); z.Sign() != 0; k += 2 {
+		power.Mul(power, z2)
+		term.Quo(power, new(big.Float).SetInt64(k))
+		if term.Sign() == 0 || term.MantExp(nil) < limit {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	return sum.Mul(sum, new(big.Float).SetInt64(2))
+}
@@ -0,0 +1,167 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+// mustParse parses a decimal string at the given precision or fails the test
+func mustParse(t *testing.T, s string, prec uint) Number {
+	t.Helper()
+	n, err := ParseNumber(s, prec)
+	if err != nil {
+		t.Fatalf("ParseNumber(%q, %d) unexpected error: %v", s, prec, err)
+	}
+	return n
+}
+
+func TestNumberArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b Number) Number
+		a, b     string
+		expected string
+	}{
+		{"add_exact_tenths", AddNumber, "0.1", "0.2", "0.3"},
+		{"subtract", SubtractNumber, "1", "0.25", "0.75"},
+		{"multiply_large_integers", MultiplyNumber, "123456789012345678901234567890", "987654321098765432109876543210", "121932631137021795226185032733622923332237463801111263526900"},
+		{"multiply_negative", MultiplyNumber, "-2.5", "4", "-10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.op(mustParse(t, tt.a, 256), mustParse(t, tt.b, 256))
+			if got.String() != tt.expected {
+				t.Errorf("%s(%s, %s) = %s, want %s", tt.name, tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDivideNumber(t *testing.T) {
+	got, err := DivideNumber(mustParse(t, "1", 128), mustParse(t, "3", 128))
+	if err != nil {
+		t.Fatalf("DivideNumber(1, 3) unexpected error: %v", err)
+	}
+	if want := "0.333333333333333333333333333333333333334"; got.String() != want {
+		t.Errorf("DivideNumber(1, 3) = %s, want %s", got, want)
+	}
+
+	_, err = DivideNumber(mustParse(t, "5", 0), mustParse(t, "0", 0))
+	if _, floatErr := Divide(5, 0); err == nil || err.Error() != floatErr.Error() {
+		t.Errorf("DivideNumber(5, 0) error = %v, want %v", err, floatErr)
+	}
+}
+
+func TestPowerNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{"integer_exponent_exact", "2", "100", "1267650600228229401496703205376"},
+		{"negative_integer_exponent", "10", "-3", "0.001"},
+		{"negative_base_odd_exponent", "-3", "3", "-27"},
+		{"zero_exponent", "7", "0", "1"},
+		{"fractional_exponent", "2", "0.5", "1.414213562373095048801688724209698078569671875376948073176"},
+		{"fractional_exponent_of_large_base", "1e40", "0.25", "10000000000"},
+		{"zero_base", "0", "2.5", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PowerNumber(mustParse(t, tt.a, 200), mustParse(t, tt.b, 200))
+			if err != nil {
+				t.Fatalf("PowerNumber(%s, %s) unexpected error: %v", tt.a, tt.b, err)
+			}
+			if got.Prec() != 200 {
+				t.Errorf("PowerNumber(%s, %s) precision = %d, want 200", tt.a, tt.b, got.Prec())
+			}
+			if s := got.String(); len(s) < len(tt.expected) || s[:len(tt.expected)] != tt.expected {
+				t.Errorf("PowerNumber(%s, %s) = %s, want prefix %s", tt.a, tt.b, s, tt.expected)
+			}
+		})
+	}
+
+	if _, err := PowerNumber(mustParse(t, "-8", 0), mustParse(t, "0.5", 0)); !errors.Is(err, ErrNegativeFractionalPower) {
+		t.Errorf("PowerNumber(-8, 0.5) error = %v, want %v", err, ErrNegativeFractionalPower)
+	}
+	for _, b := range []string{"1e18", "1234567890123.5", "3e19"} {
+		if _, err := PowerNumber(mustParse(t, "2", 64), mustParse(t, b, 64)); !errors.Is(err, ErrOverflow) {
+			t.Errorf("PowerNumber(2, %s) error = %v, want %v", b, err, ErrOverflow)
+		}
+	}
+	if got, err := PowerNumber(mustParse(t, "2", 64), mustParse(t, "-1e18", 64)); err != nil || got.Sign() != 0 {
+		t.Errorf("PowerNumber(2, -1e18) = %v, %v, want 0", got, err)
+	}
+}
+
+func TestSquareRootNumber(t *testing.T) {
+	got, err := SquareRootNumber(mustParse(t, "2", 200))
+	if err != nil {
+		t.Fatalf("SquareRootNumber(2) unexpected error: %v", err)
+	}
+	if want := "1.414213562373095048801688724209698078569671875376948073176"; got.String()[:len(want)] != want {
+		t.Errorf("SquareRootNumber(2) = %s, want prefix %s", got, want)
+	}
+
+	_, err = SquareRootNumber(mustParse(t, "-4", 0))
+	if _, floatErr := SquareRoot(-4); err == nil || err.Error() != floatErr.Error() {
+		t.Errorf("SquareRootNumber(-4) error = %v, want %v", err, floatErr)
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		prec        uint
+		expectError bool
+		expectPrec  uint
+	}{
+		{"default_precision", "1.5", 0, false, DefaultPrecision},
+		{"custom_precision", "1.5", 80, false, 80},
+		{"exponent", "1e-30", 0, false, DefaultPrecision},
+		{"invalid", "abc", 0, true, 0},
+		{"infinite", "Inf", 0, true, 0},
+		{"max_precision", "1.5", MaxPrecision, false, MaxPrecision},
+		{"precision_too_large", "1.5", MaxPrecision + 1, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNumber(tt.input, tt.prec)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ParseNumber(%q) error = %v, expectError %v", tt.input, err, tt.expectError)
+			}
+			if !tt.expectError && got.Prec() != tt.expectPrec {
+				t.Errorf("ParseNumber(%q) precision = %d, want %d", tt.input, got.Prec(), tt.expectPrec)
+			}
+		})
+	}
+
+	if _, err := ParseNumber("1", MaxPrecision+1); !errors.Is(err, ErrPrecisionTooLarge) {
+		t.Errorf("ParseNumber(1, %d) error = %v, want %v", MaxPrecision+1, err, ErrPrecisionTooLarge)
+	}
+}
+
+func TestNewNumberPrecision(t *testing.T) {
+	tests := []struct {
+		name       string
+		prec       uint
+		expectPrec uint
+	}{
+		{"default_precision", 0, DefaultPrecision},
+		{"max_precision", MaxPrecision, MaxPrecision},
+		{"above_max_precision", MaxPrecision + 1, MaxPrecision},
+		{"largest_uint", ^uint(0), MaxPrecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewNumber(1.5, tt.prec).Prec(); got != tt.expectPrec {
+				t.Errorf("NewNumber(1.5, %d) precision = %d, want %d", tt.prec, got, tt.expectPrec)
+			}
+		})
+	}
+}
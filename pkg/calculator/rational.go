@@ -0,0 +1,116 @@
+package calculator
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// maxExactPowerBits bounds the size of the numerator and denominator that
+// PowerRational will compute, so a typo cannot exhaust memory.
+const maxExactPowerBits = 1 << 24
+
+// Rational is an exact fraction backed by big.Rat. Operations never modify
+// their operands and never round. The zero value is not usable; create
+// values with NewRational or ParseRational.
+type Rational struct {
+	r *big.Rat
+}
+
+// NewRational returns the fraction num/den in lowest terms.
+// It panics if den is zero.
+func NewRational(num, den int64) Rational {
+	return Rational{r: big.NewRat(num, den)}
+}
+
+// NewRationalFromRat returns a fraction equal to r, which is copied.
+func NewRationalFromRat(r *big.Rat) Rational {
+	return Rational{r: new(big.Rat).Set(r)}
+}
+
+// ParseRational parses a fraction such as "1/3", a decimal such as "0.25" or
+// "1e-3", or an integer. Decimal input is converted exactly, so "0.1" is
+// exactly one tenth.
+func ParseRational(s string) (Rational, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Rational{}, fmt.Errorf("invalid rational number %q", s)
+	}
+	return Rational{r: r}, nil
+}
+
+// Rat returns a copy of the underlying big.Rat.
+func (q Rational) Rat() *big.Rat {
+	return new(big.Rat).Set(q.r)
+}
+
+// Float64 returns the float64 value nearest to q.
+func (q Rational) Float64() float64 {
+	f, _ := q.r.Float64()
+	return f
+}
+
+// IsInt reports whether the denominator of q is 1.
+func (q Rational) IsInt() bool {
+	return q.r.IsInt()
+}
+
+// String returns q as "a/b", or as "a" if q is an integer.
+func (q Rational) String() string {
+	return q.r.RatString()
+}
+
+// AddRational returns the exact sum of two fractions.
+func AddRational(a, b Rational) Rational {
+	return Rational{r: new(big.Rat).Add(a.r, b.r)}
+}
+
+// SubtractRational returns the exact difference between two fractions.
+func SubtractRational(a, b Rational) Rational {
+	return Rational{r: new(big.Rat).Sub(a.r, b.r)}
+}
+
+// MultiplyRational returns the exact product of two fractions.
+func MultiplyRational(a, b Rational) Rational {
+	return Rational{r: new(big.Rat).Mul(a.r, b.r)}
+}
+
+// DivideRational returns the exact quotient of two fractions.
+// Returns the same error as Divide if the divisor is zero.
+func DivideRational(a, b Rational) (Rational, error) {
+	if b.r.Sign() == 0 {
+		return Rational{}, domainError("divide", ErrDivisionByZero, a, b)
+	}
+	return Rational{r: new(big.Rat).Quo(a.r, b.r)}, nil
+}
+
+// PowerRational raises a to an integer power exactly.
+// Returns an error if the exponent is not an integer, if a is zero and the
+// exponent is negative, or if the result would be unreasonably large.
+func PowerRational(a, b Rational) (Rational, error) {
+	if !b.r.IsInt() || !b.r.Num().IsInt64() {
+		return Rational{}, domainError("power", ErrNonIntegerExponent, a, b)
+	}
+	e := b.r.Num().Int64()
+
+	base := a.r
+	if e < 0 {
+		if a.r.Sign() == 0 {
+			return Rational{}, domainError("power", ErrDivisionByZero, a, b)
+		}
+		e = -e
+		base = new(big.Rat).Inv(a.r)
+	}
+
+	bits := base.Num().BitLen()
+	if d := base.Denom().BitLen(); d > bits {
+		bits = d
+	}
+	if bits > 1 && e > maxExactPowerBits/int64(bits) {
+		return Rational{}, domainError("power", ErrResultTooLarge, a, b)
+	}
+
+	exp := big.NewInt(e)
+	num := new(big.Int).Exp(base.Num(), exp, nil)
+	den := new(big.Int).Exp(base.Denom(), exp, nil)
+	return Rational{r: new(big.Rat).SetFrac(num, den)}, nil
+}
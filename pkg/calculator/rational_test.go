@@ -0,0 +1,113 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+// mustParseRational parses a fraction or fails the test
+func mustParseRational(t *testing.T, s string) Rational {
+	t.Helper()
+	q, err := ParseRational(s)
+	if err != nil {
+		t.Fatalf("ParseRational(%q) unexpected error: %v", s, err)
+	}
+	return q
+}
+
+func TestRationalArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b Rational) Rational
+		a, b     string
+		expected string
+	}{
+		{"add_tenths", AddRational, "0.1", "0.2", "3/10"},
+		{"add_fractions", AddRational, "1/3", "1/6", "1/2"},
+		{"subtract", SubtractRational, "1/2", "3/4", "-1/4"},
+		{"multiply", MultiplyRational, "2/3", "9/4", "3/2"},
+		{"multiply_to_integer", MultiplyRational, "1/3", "3", "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.op(mustParseRational(t, tt.a), mustParseRational(t, tt.b))
+			if got.String() != tt.expected {
+				t.Errorf("%s(%s, %s) = %s, want %s", tt.name, tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDivideRational(t *testing.T) {
+	got, err := DivideRational(NewRational(1, 1), NewRational(3, 1))
+	if err != nil {
+		t.Fatalf("DivideRational(1, 3) unexpected error: %v", err)
+	}
+	if got.String() != "1/3" {
+		t.Errorf("DivideRational(1, 3) = %s, want 1/3", got)
+	}
+
+	_, err = DivideRational(NewRational(1, 1), NewRational(0, 1))
+	if !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("DivideRational(1, 0) error = %v, want %v", err, ErrDivisionByZero)
+	}
+}
+
+func TestPowerRational(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        string
+		expected    string
+		expectError error
+	}{
+		{"positive_exponent", "2/3", "3", "8/27", nil},
+		{"negative_exponent", "2/3", "-2", "9/4", nil},
+		{"negative_base", "-1/2", "3", "-1/8", nil},
+		{"zero_exponent", "5/7", "0", "1", nil},
+		{"large_exact", "10", "30", "1000000000000000000000000000000", nil},
+		{"fractional_exponent", "4", "1/2", "", ErrNonIntegerExponent},
+		{"zero_negative_exponent", "0", "-1", "", ErrDivisionByZero},
+		{"too_large", "3", "100000000", "", ErrResultTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PowerRational(mustParseRational(t, tt.a), mustParseRational(t, tt.b))
+			if !errors.Is(err, tt.expectError) {
+				t.Fatalf("PowerRational(%s, %s) error = %v, want %v", tt.a, tt.b, err, tt.expectError)
+			}
+			if tt.expectError == nil && got.String() != tt.expected {
+				t.Errorf("PowerRational(%s, %s) = %s, want %s", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRational(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{"1/3", "1/3", false},
+		{"4/6", "2/3", false},
+		{"0.25", "1/4", false},
+		{"-1e-3", "-1/1000", false},
+		{"7", "7", false},
+		{"abc", "", true},
+		{"1/0", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRational(tt.input)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ParseRational(%q) error = %v, expectError %v", tt.input, err, tt.expectError)
+			}
+			if !tt.expectError && got.String() != tt.expected {
+				t.Errorf("ParseRational(%q) = %s, want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors returned when registering an operation.
+var (
+	// ErrInvalidOperation is returned when registering an operation without
+	// a name or implementation, or with an arity below 1.
+	ErrInvalidOperation = errors.New("invalid operation")
+
+	// ErrDuplicateOperation is returned when registering an operation whose
+	// name or alias is already taken.
+	ErrDuplicateOperation = errors.New("operation already registered")
+)
+
+// Operation describes a calculation on float64 operands that can be looked
+// up by name, so that a command line or an expression language can offer it
+// without knowing it in advance.
+type Operation struct {
+	// Name is the canonical name of the operation, e.g. "sqrt".
+	Name string
+	// Aliases are other names the operation can be looked up by.
+	Aliases []string
+	// Arity is the number of operands the operation takes, or the minimum
+	// number if it is Variadic.
+	Arity int
+	// Variadic operations take Arity or more operands.
+	Variadic bool
+	// Symbol is the operator used to display the operation, written
+	// between the operands, as in "5 + 3" or "1 + 2 + 3", or after the
+	// operand of a unary operation, as in "5!". Operations without a
+	// symbol are displayed as function calls, as in "sqrt(16)".
+	Symbol string
+	// Noun names the operation in error messages, e.g. "square root".
+	// Register sets it to Name if it is empty.
+	Noun string
+	// Description is a one-line summary of the operation for help text.
+	Description string
+	// Func computes the result. It is called with exactly Arity operands,
+	// or at least Arity if the operation is Variadic.
+	Func func(args []float64) (float64, error)
+}
+
+// Accepts reports whether the operation can be applied to n operands.
+func (op Operation) Accepts(n int) bool {
+	return n == op.Arity || op.Variadic && n > op.Arity
+}
+
+// Format displays the operation applied to operands, which are usually the
+// operands as the user wrote them, e.g. "5 + 3" or "sqrt(16)".
+func (op Operation) Format(operands []string) string {
+	switch {
+	case op.Symbol != "" && op.Arity == 1 && !op.Variadic:
+		return operands[0] + op.Symbol
+	case op.Symbol != "":
+		return strings.Join(operands, " "+op.Symbol+" ")
+	default:
+		return fmt.Sprintf("%s(%s)", op.Name, strings.Join(operands, ", "))
+	}
+}
+
+// Registry is a set of operations, looked up by name or alias. A Registry
+// may be read from several goroutines at once, but Register must not be
+// called concurrently with any other method.
+type Registry struct {
+	ops    []Operation
+	byName map[string]int
+}
+
+// NewRegistry returns an empty registry. Use NewDefaultRegistry for one
+// holding the operations of this package.
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]int)}
+}
+
+// Register adds an operation to the registry.
+// Returns an error if the operation has no name or Func, if its arity is
+// below 1, or if its name or an alias is already registered.
+func (r *Registry) Register(op Operation) error {
+	if op.Name == "" || op.Func == nil || op.Arity < 1 {
+		return fmt.Errorf("%w: %q needs a name, a Func and an arity of at least 1", ErrInvalidOperation, op.Name)
+	}
+	names := append([]string{op.Name}, op.Aliases...)
+	for i, name := range names {
+		if _, taken := r.byName[name]; taken || contains(names[:i], name) {
+			return fmt.Errorf("%w: %q", ErrDuplicateOperation, name)
+		}
+	}
+	if op.Noun == "" {
+		op.Noun = op.Name
+	}
+
+	r.ops = append(r.ops, op)
+	for _, name := range names {
+		r.byName[name] = len(r.ops) - 1
+	}
+	return nil
+}
+
+// contains reports whether names includes name.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup returns the operation registered under name, which may be an
+// alias, and whether there is one.
+func (r *Registry) Lookup(name string) (Operation, bool) {
+	i, ok := r.byName[name]
+	if !ok {
+		return Operation{}, false
+	}
+	return r.ops[i], true
+}
+
+// Operations returns the registered operations in the order they were
+// registered.
+func (r *Registry) Operations() []Operation {
+	return append([]Operation(nil), r.ops...)
+}
+
+// NewDefaultRegistry returns a registry holding the operations of this
+// package, with trigonometric operations taking and returning angles in
+// unit. The registry is new on every call, so operations registered on it
+// are not seen by other callers.
+func NewDefaultRegistry(unit AngleUnit) *Registry {
+	r := NewRegistry()
+	for _, op := range defaultOperations(unit) {
+		if err := r.Register(op); err != nil {
+			// The table below is fixed, so this is a programming error
+			panic(err)
+		}
+	}
+	return r
+}
+
+// defaultOperations lists the operations of NewDefaultRegistry.
+func defaultOperations(unit AngleUnit) []Operation {
+	return []Operation{
+		{Name: "add", Arity: 2, Variadic: true, Symbol: "+", Noun: "addition", Description: "Adds numbers, with compensated summation", Func: checkedN(Sum)},
+		{Name: "subtract", Aliases: []string{"sub"}, Arity: 2, Symbol: "-", Noun: "subtraction", Description: "Subtracts the second number from the first", Func: checked2(SubtractChecked)},
+		{Name: "multiply", Aliases: []string{"mul"}, Arity: 2, Variadic: true, Symbol: "*", Noun: "multiplication", Description: "Multiplies numbers", Func: checkedN(Product)},
+		{Name: "divide", Aliases: []string{"div"}, Arity: 2, Symbol: "/", Noun: "division", Description: "Divides the first number by the second", Func: checked2(Divide)},
+		{Name: "power", Aliases: []string{"pow"}, Arity: 2, Symbol: "^", Description: "Raises the first number to the power of the second", Func: checked2(Power)},
+		{Name: "min", Arity: 1, Variadic: true, Noun: "minimum", Description: "Finds the smallest number", Func: checkedN(Min)},
+		{Name: "max", Arity: 1, Variadic: true, Noun: "maximum", Description: "Finds the largest number", Func: checkedN(Max)},
+		{Name: "mean", Aliases: []string{"avg"}, Arity: 1, Variadic: true, Description: "Calculates the arithmetic mean of numbers", Func: checkedN(Mean)},
+		{Name: "gcd", Arity: 1, Variadic: true, Noun: "greatest common divisor", Description: "Calculates the greatest common divisor of integers", Func: checkedN(GCD)},
+		{Name: "lcm", Arity: 1, Variadic: true, Noun: "least common multiple", Description: "Calculates the least common multiple of integers", Func: checkedN(LCM)},
+		{Name: "random", Arity: 2, Noun: "random number", Description: "Generates a cryptographically secure random number between two values", Func: finite2("random", Random)},
+		{Name: "atan2", Arity: 2, Noun: "arctangent", Description: "Calculates the angle of the point (x, y), given y then x", Func: finite2("atan2", unit.Atan2)},
+		{Name: "log", Arity: 2, Noun: "logarithm", Description: "Calculates the logarithm of the first number in the base given by the second", Func: checked2(Log)},
+		{Name: "root", Arity: 2, Description: "Calculates the root of the first number given by the second", Func: checked2(Root)},
+		{Name: "hypot", Arity: 2, Noun: "hypotenuse", Description: "Calculates the square root of the sum of the squares of two numbers", Func: checked2(Hypot)},
+		{Name: "beta", Arity: 2, Noun: "beta function", Description: "Calculates the beta function", Func: checked2(Beta)},
+
+		{Name: "sqrt", Arity: 1, Noun: "square root", Description: "Calculates the square root of a number", Func: checked1(SquareRoot)},
+		{Name: "cbrt", Arity: 1, Noun: "cube root", Description: "Calculates the cube root of a number", Func: checked1(Cbrt)},
+		{Name: "sin", Arity: 1, Noun: "sine", Description: "Calculates the sine of an angle", Func: finite1("sin", unit.Sin)},
+		{Name: "cos", Arity: 1, Noun: "cosine", Description: "Calculates the cosine of an angle", Func: finite1("cos", unit.Cos)},
+		{Name: "tan", Arity: 1, Noun: "tangent", Description: "Calculates the tangent of an angle", Func: checked1(unit.Tan)},
+		{Name: "asin", Arity: 1, Noun: "arcsine", Description: "Calculates the arcsine of a number", Func: checked1(unit.Asin)},
+		{Name: "acos", Arity: 1, Noun: "arccosine", Description: "Calculates the arccosine of a number", Func: checked1(unit.Acos)},
+		{Name: "atan", Arity: 1, Noun: "arctangent", Description: "Calculates the arctangent of a number", Func: finite1("atan", unit.Atan)},
+		{Name: "sinh", Arity: 1, Noun: "hyperbolic function", Description: "Calculates the hyperbolic sine of a number", Func: checked1(Sinh)},
+		{Name: "cosh", Arity: 1, Noun: "hyperbolic function", Description: "Calculates the hyperbolic cosine of a number", Func: checked1(Cosh)},
+		{Name: "tanh", Arity: 1, Noun: "hyperbolic function", Description: "Calculates the hyperbolic tangent of a number", Func: checked1(Tanh)},
+		{Name: "asinh", Arity: 1, Noun: "hyperbolic function", Description: "Calculates the inverse hyperbolic sine of a number", Func: checked1(Asinh)},
+		{Name: "acosh", Arity: 1, Noun: "hyperbolic function", Description: "Calculates the inverse hyperbolic cosine of a number", Func: checked1(Acosh)},
+		{Name: "atanh", Arity: 1, Noun: "hyperbolic function", Description: "Calculates the inverse hyperbolic tangent of a number", Func: checked1(Atanh)},
+		{Name: "ln", Arity: 1, Noun: "logarithm", Description: "Calculates the natural logarithm of a number", Func: checked1(Ln)},
+		{Name: "log10", Arity: 1, Noun: "logarithm", Description: "Calculates the base-10 logarithm of a number", Func: checked1(Log10)},
+		{Name: "log2", Arity: 1, Noun: "logarithm", Description: "Calculates the base-2 logarithm of a number", Func: checked1(Log2)},
+		{Name: "log1p", Arity: 1, Noun: "logarithm", Description: "Calculates ln(1 + x), accurately near zero", Func: checked1(Log1p)},
+		{Name: "exp", Arity: 1, Noun: "exponential", Description: "Raises e to the power of a number", Func: checked1(Exp)},
+		{Name: "expm1", Arity: 1, Noun: "exponential", Description: "Calculates e^x - 1, accurately near zero", Func: checked1(Expm1)},
+		{Name: "gamma", Arity: 1, Noun: "gamma function", Description: "Calculates the gamma function", Func: checked1(Gamma)},
+		{Name: "factorial", Arity: 1, Symbol: "!", Description: "Calculates the factorial, through the gamma function for non-integers", Func: checked1(Factorial)},
+		{Name: "erf", Arity: 1, Noun: "error function", Description: "Calculates the error function", Func: checked1(Erf)},
+		{Name: "erfc", Arity: 1, Noun: "error function", Description: "Calculates the complementary error function", Func: checked1(Erfc)},
+	}
+}
+
+// finite1 adapts an infallible single-operand function to Operation.Func.
+// A NaN or infinite operand fails with ErrNonFinite, and a NaN or infinite
+// result with ErrOverflow, as for the fallible functions.
+func finite1(op string, fn func(float64) float64) func([]float64) (float64, error) {
+	return func(args []float64) (float64, error) { return checkFinite(op, fn(args[0]), args[0]) }
+}
+
+// finite2 adapts an infallible two-operand function to Operation.Func,
+// checking operands and result as finite1 does.
+func finite2(op string, fn func(a, b float64) float64) func([]float64) (float64, error) {
+	return func(args []float64) (float64, error) { return checkFinite(op, fn(args[0], args[1]), args[0], args[1]) }
+}
+
+// checked1 adapts a fallible single-operand function to Operation.Func.
+func checked1(fn func(float64) (float64, error)) func([]float64) (float64, error) {
+	return func(args []float64) (float64, error) { return fn(args[0]) }
+}
+
+// checkedN adapts a fallible variadic function to Operation.Func.
+func checkedN(fn func(...float64) (float64, error)) func([]float64) (float64, error) {
+	return func(args []float64) (float64, error) { return fn(args...) }
+}
+
+// checked2 adapts a fallible two-operand function to Operation.Func.
+func checked2(fn func(a, b float64) (float64, error)) func([]float64) (float64, error) {
+	return func(args []float64) (float64, error) { return fn(args[0], args[1]) }
+}
@@ -0,0 +1,181 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRegistryRegister(t *testing.T) {
+	double := func(args []float64) (float64, error) { return 2 * args[0], nil }
+
+	tests := []struct {
+		name        string
+		op          Operation
+		expectError error
+	}{
+		{"valid", Operation{Name: "double", Aliases: []string{"twice"}, Arity: 1, Func: double}, nil},
+		{"missing_name", Operation{Arity: 1, Func: double}, ErrInvalidOperation},
+		{"missing_func", Operation{Name: "double", Arity: 1}, ErrInvalidOperation},
+		{"zero_arity", Operation{Name: "double", Func: double}, ErrInvalidOperation},
+		{"duplicate_name", Operation{Name: "sqrt", Arity: 1, Func: double}, ErrDuplicateOperation},
+		{"duplicate_alias", Operation{Name: "double", Aliases: []string{"pow"}, Arity: 1, Func: double}, ErrDuplicateOperation},
+		{"alias_repeats_name", Operation{Name: "double", Aliases: []string{"double"}, Arity: 1, Func: double}, ErrDuplicateOperation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewDefaultRegistry(Radians)
+			before := len(r.Operations())
+			err := r.Register(tt.op)
+			if !errors.Is(err, tt.expectError) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.expectError)
+			}
+			if tt.expectError != nil {
+				if len(r.Operations()) != before {
+					t.Errorf("Register() added an operation despite failing")
+				}
+				return
+			}
+
+			for _, name := range []string{"double", "twice"} {
+				op, ok := r.Lookup(name)
+				if !ok || op.Name != "double" || op.Noun != "double" {
+					t.Errorf("Lookup(%q) = %+v, %v, want double with Noun defaulted to its name", name, op, ok)
+				}
+			}
+			if ops := r.Operations(); ops[len(ops)-1].Name != "double" {
+				t.Errorf("Operations() ends with %q, want the operation registered last", ops[len(ops)-1].Name)
+			}
+		})
+	}
+}
+
+func TestRegistryIsolation(t *testing.T) {
+	r := NewDefaultRegistry(Radians)
+	err := r.Register(Operation{Name: "double", Arity: 1, Func: func(args []float64) (float64, error) { return 2 * args[0], nil }})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if _, ok := NewDefaultRegistry(Radians).Lookup("double"); ok {
+		t.Errorf("an operation registered on one default registry is visible in another")
+	}
+
+	// Modifying the returned slice must not affect the registry
+	ops := r.Operations()
+	ops[0].Name = "changed"
+	if op, _ := r.Lookup("add"); op.Name != "add" {
+		t.Errorf("Lookup(%q) = %q after modifying Operations()", "add", op.Name)
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	tests := []struct {
+		name        string
+		unit        AngleUnit
+		args        []float64
+		expected    float64
+		expectError error
+	}{
+		{"add", Radians, []float64{2, 3}, 5, nil},
+		{"sub", Radians, []float64{2, 3}, -1, nil},
+		{"divide", Radians, []float64{1, 0}, 0, ErrDivisionByZero},
+		{"pow", Radians, []float64{2, 10}, 1024, nil},
+		{"sin", Degrees, []float64{90}, 1, nil},
+		{"tan", Degrees, []float64{90}, 0, ErrUndefined},
+		{"atan2", Degrees, []float64{1, 1}, 45, nil},
+		{"log", Radians, []float64{1000, 10}, 3, nil},
+		{"factorial", Radians, []float64{5}, 120, nil},
+		{"ln", Radians, []float64{-1}, 0, ErrLogOfNegative},
+		{"add", Radians, []float64{1e100, 1, -1e100}, 1, nil},
+		{"mul", Radians, []float64{2, 3, 4}, 24, nil},
+		{"avg", Radians, []float64{1, 2, 3, 4}, 2.5, nil},
+		{"lcm", Radians, []float64{4, 6}, 12, nil},
+		{"sin", Radians, []float64{math.Inf(1)}, 0, ErrNonFinite},
+		{"cos", Radians, []float64{math.NaN()}, 0, ErrNonFinite},
+		{"atan", Radians, []float64{math.Inf(-1)}, 0, ErrNonFinite},
+		{"atan2", Radians, []float64{math.NaN(), 1}, 0, ErrNonFinite},
+		{"random", Radians, []float64{math.NaN(), 1}, 0, ErrNonFinite},
+		{"random", Radians, []float64{1, math.Inf(1)}, 0, ErrNonFinite},
+		{"random", Radians, []float64{-math.MaxFloat64, math.MaxFloat64}, 0, ErrOverflow},
+		{"cbrt", Radians, []float64{math.Inf(1)}, 0, ErrNonFinite},
+		{"erf", Radians, []float64{math.NaN()}, 0, ErrNonFinite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, ok := NewDefaultRegistry(tt.unit).Lookup(tt.name)
+			if !ok {
+				t.Fatalf("Lookup(%q) found nothing", tt.name)
+			}
+			if !op.Accepts(len(tt.args)) {
+				t.Fatalf("Accepts(%d) = false", len(tt.args))
+			}
+			got, err := op.Func(tt.args)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("Func(%v) error = %v, want %v", tt.args, err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && got != tt.expected {
+				t.Errorf("Func(%v) = %v, want %v", tt.args, got, tt.expected)
+			}
+		})
+	}
+
+	for _, op := range NewDefaultRegistry(Radians).Operations() {
+		if op.Description == "" || op.Noun == "" {
+			t.Errorf("operation %q has no description or noun", op.Name)
+		}
+	}
+}
+
+func TestOperationFormat(t *testing.T) {
+	r := NewDefaultRegistry(Radians)
+	tests := []struct {
+		name     string
+		operands []string
+		expected string
+	}{
+		{"add", []string{"5", "3"}, "5 + 3"},
+		{"power", []string{"2", "-1"}, "2 ^ -1"},
+		{"factorial", []string{"5"}, "5!"},
+		{"sqrt", []string{"16"}, "sqrt(16)"},
+		{"atan2", []string{"1", "-1"}, "atan2(1, -1)"},
+		{"add", []string{"1", "2", "3"}, "1 + 2 + 3"},
+		{"max", []string{"7"}, "max(7)"},
+		{"gcd", []string{"12", "18", "24"}, "gcd(12, 18, 24)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, _ := r.Lookup(tt.name)
+			if got := op.Format(tt.operands); got != tt.expected {
+				t.Errorf("Format(%v) = %q, want %q", tt.operands, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOperationAccepts(t *testing.T) {
+	r := NewDefaultRegistry(Radians)
+	tests := []struct {
+		name     string
+		n        int
+		expected bool
+	}{
+		{"add", 1, false},
+		{"add", 2, true},
+		{"add", 10, true},
+		{"max", 1, true},
+		{"subtract", 2, true},
+		{"subtract", 3, false},
+		{"sqrt", 0, false},
+	}
+
+	for _, tt := range tests {
+		op, _ := r.Lookup(tt.name)
+		if got := op.Accepts(tt.n); got != tt.expected {
+			t.Errorf("%s.Accepts(%d) = %v, want %v", tt.name, tt.n, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package calculator
+
+import "math"
+
+// Ln returns the natural logarithm of a number.
+// Returns an error if the number is zero, negative, NaN or infinite.
+func Ln(a float64) (float64, error) {
+	return logarithm("ln", math.Log, a)
+}
+
+// Log10 returns the base-10 logarithm of a number.
+// Returns an error if the number is zero, negative, NaN or infinite.
+func Log10(a float64) (float64, error) {
+	return logarithm("log10", math.Log10, a)
+}
+
+// Log2 returns the base-2 logarithm of a number.
+// Returns an error if the number is zero, negative, NaN or infinite.
+func Log2(a float64) (float64, error) {
+	return logarithm("log2", math.Log2, a)
+}
+
+// Log returns the logarithm of a in the given base. Exact powers of the
+// base give exact results, so Log(1000, 10) is 3.
+// Returns an error if a is zero or negative, if the base is not positive
+// or is 1, or if an input is NaN or infinite.
+func Log(a, base float64) (float64, error) {
+	if !isFinite(a, base) {
+		return 0, domainError("log", ErrNonFinite, a, base)
+	}
+	if base <= 0 || base == 1 {
+		return 0, domainError("log", ErrInvalidBase, a, base)
+	}
+	if a == 0 {
+		return 0, domainError("log", ErrLogOfZero, a, base)
+	}
+	if a < 0 {
+		return 0, domainError("log", ErrLogOfNegative, a, base)
+	}
+	result := math.Log(a) / math.Log(base)
+	// The division can miss an integer result by an ulp
+	if n := math.Round(result); n != result && math.Pow(base, n) == a {
+		return n, nil
+	}
+	return result, nil
+}
+
+// logarithm checks the argument of a logarithm before applying fn.
+func logarithm(op string, fn func(float64) float64, a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError(op, ErrNonFinite, a)
+	}
+	if a == 0 {
+		return 0, domainError(op, ErrLogOfZero, a)
+	}
+	if a < 0 {
+		return 0, domainError(op, ErrLogOfNegative, a)
+	}
+	return fn(a), nil
+}
+
+// Log1p returns ln(1 + a), accurate even when a is near zero.
+// Returns an error if a is -1 or less, NaN or infinite.
+func Log1p(a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError("log1p", ErrNonFinite, a)
+	}
+	if a == -1 {
+		return 0, domainError("log1p", ErrLogOfZero, a)
+	}
+	if a < -1 {
+		return 0, domainError("log1p", ErrLogOfNegative, a)
+	}
+	return math.Log1p(a), nil
+}
+
+// Exp returns e raised to the power of a number.
+// Returns an error if the result overflows or the number is NaN or
+// infinite.
+func Exp(a float64) (float64, error) {
+	return checkOverflow("exp", math.Exp, a)
+}
+
+// Expm1 returns e raised to the power of a, minus 1, accurate even when a
+// is near zero.
+// Returns an error if the result overflows or a is NaN or infinite.
+func Expm1(a float64) (float64, error) {
+	return checkOverflow("expm1", math.Expm1, a)
+}
+
+// Sinh returns the hyperbolic sine of a number.
+// Returns an error if the result overflows or the number is NaN or
+// infinite.
+func Sinh(a float64) (float64, error) {
+	return checkOverflow("sinh", math.Sinh, a)
+}
+
+// Cosh returns the hyperbolic cosine of a number.
+// Returns an error if the result overflows or the number is NaN or
+// infinite.
+func Cosh(a float64) (float64, error) {
+	return checkOverflow("cosh", math.Cosh, a)
+}
+
+// checkOverflow applies fn to a finite a, reporting an error if the result
+// is infinite.
+func checkOverflow(op string, fn func(float64) float64, a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError(op, ErrNonFinite, a)
+	}
+	result := fn(a)
+	if math.IsInf(result, 0) {
+		return 0, domainError(op, ErrOverflow, a)
+	}
+	return result, nil
+}
+
+// Tanh returns the hyperbolic tangent of a number.
+// Returns an error if the number is NaN or infinite.
+func Tanh(a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError("tanh", ErrNonFinite, a)
+	}
+	return math.Tanh(a), nil
+}
+
+// Asinh returns the inverse hyperbolic sine of a number.
+// Returns an error if the number is NaN or infinite.
+func Asinh(a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError("asinh", ErrNonFinite, a)
+	}
+	return math.Asinh(a), nil
+}
+
+// Acosh returns the inverse hyperbolic cosine of a number.
+// Returns an error if the number is less than 1, NaN or infinite.
+func Acosh(a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError("acosh", ErrNonFinite, a)
+	}
+	if a < 1 {
+		return 0, domainError("acosh", ErrUndefined, a)
+	}
+	return math.Acosh(a), nil
+}
+
+// Atanh returns the inverse hyperbolic tangent of a number.
+// Returns an error unless the number is strictly between -1 and 1.
+func Atanh(a float64) (float64, error) {
+	if math.IsNaN(a) {
+		return 0, domainError("atanh", ErrNonFinite, a)
+	}
+	if a <= -1 || a >= 1 {
+		return 0, domainError("atanh", ErrUndefined, a)
+	}
+	return math.Atanh(a), nil
+}
+
+// Cbrt returns the cube root of a number. Unlike SquareRoot it accepts
+// negative input, so Cbrt(-8) is -2.
+// Returns an error if the number is NaN or infinite.
+func Cbrt(a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError("cbrt", ErrNonFinite, a)
+	}
+	return math.Cbrt(a), nil
+}
+
+// Root returns the nth root of a. Odd integer roots of negative numbers
+// are negative, and exact roots give exact results, so Root(-32, 5) is -2.
+// Returns an error if n is zero, if a is negative and n is not an odd
+// integer, if a is zero and n is negative, if the result overflows, or if
+// an input is NaN or infinite.
+func Root(a, n float64) (float64, error) {
+	if !isFinite(a, n) {
+		return 0, domainError("root", ErrNonFinite, a, n)
+	}
+	if n == 0 {
+		return 0, domainError("root", ErrUndefined, a, n)
+	}
+	if a == 0 && n < 0 {
+		return 0, domainError("root", ErrDivisionByZero, a, n)
+	}
+	sign := 1.0
+	if a < 0 {
+		if n != math.Trunc(n) {
+			return 0, domainError("root", ErrNegativeFractionalPower, a, n)
+		}
+		if math.Mod(n, 2) == 0 {
+			return 0, domainError("root", ErrNegativeEvenRoot, a, n)
+		}
+		sign = -1
+	}
+
+	var result float64
+	switch n {
+	case 2:
+		result = math.Sqrt(a)
+	case 3:
+		result = math.Cbrt(a)
+	default:
+		result = sign * math.Pow(math.Abs(a), 1/n)
+		// 1/n is rounded, so an exact root can come out an ulp away
+		if r := math.Round(result); r != result && math.Pow(r, n) == a {
+			result = r
+		}
+	}
+	if math.IsInf(result, 0) {
+		return 0, domainError("root", ErrOverflow, a, n)
+	}
+	return result, nil
+}
+
+// Hypot returns √(a² + b²), avoiding overflow and underflow in the squares.
+// Returns an error if the result overflows or an input is NaN or infinite.
+func Hypot(a, b float64) (float64, error) {
+	if !isFinite(a, b) {
+		return 0, domainError("hypot", ErrNonFinite, a, b)
+	}
+	result := math.Hypot(a, b)
+	if math.IsInf(result, 0) {
+		return 0, domainError("hypot", ErrOverflow, a, b)
+	}
+	return result, nil
+}
+
+// Gamma returns the gamma function of a number, which extends the
+// factorial so that Gamma(n) = (n-1)! for positive integers n.
+// Returns an error at zero and the negative integers, where the function
+// has poles, if the result overflows, or if the number is NaN or infinite.
+func Gamma(a float64) (float64, error) {
+	return gamma("gamma", a, a)
+}
+
+// Factorial returns a! for non-negative integers, and Gamma(a + 1) for
+// other numbers, so Factorial(0.5) is √π/2.
+// Returns an error at the negative integers, if the result overflows, or if
+// the number is NaN or infinite.
+func Factorial(a float64) (float64, error) {
+	if isFinite(a) && a >= 0 && a == math.Trunc(a) {
+		// Multiplying integers is exact for as long as the product fits
+		result := 1.0
+		for i := 2.0; i <= a && !math.IsInf(result, 0); i++ {
+			result *= i
+		}
+		if math.IsInf(result, 0) {
+			return 0, domainError("factorial", ErrOverflow, a)
+		}
+		return result, nil
+	}
+	return gamma("factorial", a+1, a)
+}
+
+// gamma computes Gamma(x), reporting errors for the operation op applied to
+// operand.
+func gamma(op string, x, operand float64) (float64, error) {
+	if !isFinite(x) {
+		return 0, domainError(op, ErrNonFinite, operand)
+	}
+	if isNonPositiveInteger(x) {
+		return 0, domainError(op, ErrUndefined, operand)
+	}
+	result := math.Gamma(x)
+	if math.IsInf(result, 0) {
+		return 0, domainError(op, ErrOverflow, operand)
+	}
+	return result, nil
+}
+
+// Beta returns the beta function B(a, b) = Γ(a)Γ(b) / Γ(a + b).
+// Returns an error if a or b is zero or a negative integer, if the result
+// overflows, or if an input is NaN or infinite.
+func Beta(a, b float64) (float64, error) {
+	if !isFinite(a, b) {
+		return 0, domainError("beta", ErrNonFinite, a, b)
+	}
+	if isNonPositiveInteger(a) || isNonPositiveInteger(b) {
+		return 0, domainError("beta", ErrUndefined, a, b)
+	}
+	if isNonPositiveInteger(a + b) {
+		// Γ(a + b) has a pole, so the quotient vanishes
+		return 0, nil
+	}
+	// Working with logarithms keeps Γ(a) and Γ(b) from overflowing
+	// when their quotient would not
+	la, sa := math.Lgamma(a)
+	lb, sb := math.Lgamma(b)
+	lab, sab := math.Lgamma(a + b)
+	result := float64(sa*sb*sab) * math.Exp(la+lb-lab)
+	if math.IsInf(result, 0) {
+		return 0, domainError("beta", ErrOverflow, a, b)
+	}
+	return result, nil
+}
+
+// isNonPositiveInteger reports whether x is 0, -1, -2, ...
+func isNonPositiveInteger(x float64) bool {
+	return x <= 0 && x == math.Trunc(x)
+}
+
+// Erf returns the error function of a number.
+// Returns an error if the number is NaN or infinite.
+func Erf(a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError("erf", ErrNonFinite, a)
+	}
+	return math.Erf(a), nil
+}
+
+// Erfc returns the complementary error function 1 - Erf(a), accurate even
+// when Erf(a) is close to 1.
+// Returns an error if the number is NaN or infinite.
+func Erfc(a float64) (float64, error) {
+	if !isFinite(a) {
+		return 0, domainError("erfc", ErrNonFinite, a)
+	}
+	return math.Erfc(a), nil
+}
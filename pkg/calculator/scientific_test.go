@@ -0,0 +1,144 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestUnaryScientific(t *testing.T) {
+	tests := []struct {
+		name        string
+		fn          func(float64) (float64, error)
+		a           float64
+		expected    float64
+		expectError error
+	}{
+		{"ln_e", Ln, math.E, 1, nil},
+		{"ln_one", Ln, 1, 0, nil},
+		{"ln_zero", Ln, 0, 0, ErrLogOfZero},
+		{"ln_negative", Ln, -1, 0, ErrLogOfNegative},
+		{"ln_infinite", Ln, math.Inf(1), 0, ErrNonFinite},
+		{"log10_power", Log10, 1000, 3, nil},
+		{"log10_fraction", Log10, 0.01, -2, nil},
+		{"log10_negative", Log10, -10, 0, ErrLogOfNegative},
+		{"log2_power", Log2, 1024, 10, nil},
+		{"log2_zero", Log2, 0, 0, ErrLogOfZero},
+		{"log1p_small", Log1p, 1e-20, 1e-20, nil},
+		{"log1p_minus_one", Log1p, -1, 0, ErrLogOfZero},
+		{"log1p_below_minus_one", Log1p, -2, 0, ErrLogOfNegative},
+		{"exp_zero", Exp, 0, 1, nil},
+		{"exp_one", Exp, 1, math.E, nil},
+		{"exp_underflow", Exp, -1000, 0, nil},
+		{"exp_overflow", Exp, 1000, 0, ErrOverflow},
+		{"exp_nan", Exp, math.NaN(), 0, ErrNonFinite},
+		{"expm1_small", Expm1, 1e-20, 1e-20, nil},
+		{"expm1_overflow", Expm1, 710, 0, ErrOverflow},
+		{"sinh", Sinh, 1, 1.1752011936438014, nil},
+		{"sinh_overflow", Sinh, -800, 0, ErrOverflow},
+		{"cosh_zero", Cosh, 0, 1, nil},
+		{"cosh_overflow", Cosh, 800, 0, ErrOverflow},
+		{"acosh_one", Acosh, 1, 0, nil},
+		{"acosh", Acosh, 2, 1.3169578969248166, nil},
+		{"acosh_below_one", Acosh, 0.5, 0, ErrUndefined},
+		{"atanh", Atanh, 0.5, 0.5493061443340549, nil},
+		{"atanh_one", Atanh, 1, 0, ErrUndefined},
+		{"atanh_out_of_range", Atanh, -2, 0, ErrUndefined},
+		{"tanh", Tanh, 0.5, 0.46211715726000974, nil},
+		{"tanh_large", Tanh, -100, -1, nil},
+		{"tanh_infinite", Tanh, math.Inf(-1), 0, ErrNonFinite},
+		{"asinh", Asinh, 1, 0.881373587019543, nil},
+		{"asinh_nan", Asinh, math.NaN(), 0, ErrNonFinite},
+		{"cbrt", Cbrt, 27, 3, nil},
+		{"cbrt_negative", Cbrt, -8, -2, nil},
+		{"cbrt_infinite", Cbrt, math.Inf(1), 0, ErrNonFinite},
+		{"erf_zero", Erf, 0, 0, nil},
+		{"erf", Erf, 1, 0.8427007929497149, nil},
+		{"erf_infinite", Erf, math.Inf(1), 0, ErrNonFinite},
+		{"erfc", Erfc, 1, 0.15729920705028513, nil},
+		{"erfc_tail", Erfc, 10, 2.088487583762545e-45, nil},
+		{"erfc_nan", Erfc, math.NaN(), 0, ErrNonFinite},
+		{"gamma_integer", Gamma, 5, 24, nil},
+		{"gamma_half", Gamma, 0.5, math.Sqrt(math.Pi), nil},
+		{"gamma_negative", Gamma, -1.5, 4 * math.Sqrt(math.Pi) / 3, nil},
+		{"gamma_zero", Gamma, 0, 0, ErrUndefined},
+		{"gamma_negative_integer", Gamma, -3, 0, ErrUndefined},
+		{"gamma_overflow", Gamma, 200, 0, ErrOverflow},
+		{"factorial_zero", Factorial, 0, 1, nil},
+		{"factorial", Factorial, 10, 3628800, nil},
+		{"factorial_large", Factorial, 20, 2432902008176640000, nil},
+		{"factorial_half", Factorial, 0.5, math.Sqrt(math.Pi) / 2, nil},
+		{"factorial_negative_fraction", Factorial, -0.5, math.Sqrt(math.Pi), nil},
+		{"factorial_negative_integer", Factorial, -1, 0, ErrUndefined},
+		{"factorial_overflow", Factorial, 171, 0, ErrOverflow},
+		{"factorial_infinite", Factorial, math.Inf(1), 0, ErrNonFinite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.a)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("error = %v, want %v", err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && math.Abs(got-tt.expected) > 1e-15*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBinaryScientific(t *testing.T) {
+	tests := []struct {
+		name        string
+		fn          func(a, b float64) (float64, error)
+		a, b        float64
+		expected    float64
+		expectError error
+	}{
+		{"log_exact_power", Log, 1000, 10, 3, nil},
+		{"log_base_three", Log, 243, 3, 5, nil},
+		{"log_fraction", Log, 0.001, 10, -3, nil},
+		{"log_fractional_base", Log, 8, 0.5, -3, nil},
+		{"log_general", Log, 10, 2, 3.3219280948873626, nil},
+		{"log_base_one", Log, 5, 1, 0, ErrInvalidBase},
+		{"log_negative_base", Log, 5, -2, 0, ErrInvalidBase},
+		{"log_zero", Log, 0, 10, 0, ErrLogOfZero},
+		{"log_negative", Log, -5, 10, 0, ErrLogOfNegative},
+		{"root_square", Root, 16, 2, 4, nil},
+		{"root_cube_negative", Root, -27, 3, -3, nil},
+		{"root_fifth_negative", Root, -32, 5, -2, nil},
+		{"root_fourth", Root, 81, 4, 3, nil},
+		{"root_negative_index", Root, 4, -2, 0.5, nil},
+		{"root_fractional_index", Root, 8, 1.5, 4, nil},
+		{"root_even_of_negative", Root, -16, 4, 0, ErrNegativeEvenRoot},
+		{"root_fractional_of_negative", Root, -8, 1.5, 0, ErrNegativeFractionalPower},
+		{"root_zero_index", Root, 5, 0, 0, ErrUndefined},
+		{"root_of_zero_negative_index", Root, 0, -2, 0, ErrDivisionByZero},
+		{"root_overflow", Root, 1e300, 0.001, 0, ErrOverflow},
+		{"hypot", Hypot, 3, 4, 5, nil},
+		{"hypot_large", Hypot, 3e200, 4e200, 5e200, nil},
+		{"hypot_overflow", Hypot, math.MaxFloat64, math.MaxFloat64, 0, ErrOverflow},
+		{"hypot_nan", Hypot, math.NaN(), 1, 0, ErrNonFinite},
+		{"beta", Beta, 2, 3, 1.0 / 12, nil},
+		{"beta_half", Beta, 0.5, 0.5, math.Pi, nil},
+		{"beta_large", Beta, 200, 200, 4.2183075500472706e-122, nil},
+		{"beta_negative", Beta, -0.5, 2, -4, nil},
+		{"beta_denominator_pole", Beta, -0.5, -0.5, 0, nil},
+		{"beta_pole", Beta, 0, 2, 0, ErrUndefined},
+		{"beta_infinite", Beta, math.Inf(1), 2, 0, ErrNonFinite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.a, tt.b)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("error = %v, want %v", err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && math.Abs(got-tt.expected) > 1e-13*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Accumulator computes statistics over a stream of values in constant
+// memory. The mean and variance use Welford's algorithm and quantiles are
+// estimated with a t-digest. Accumulators filled on separate goroutines can
+// be combined with Merge. All methods are safe for concurrent use. The zero
+// value is an empty accumulator ready to use.
+type Accumulator struct {
+	mu     sync.Mutex
+	count  int64
+	mean   float64
+	m2     float64 // sum of squared deviations from the mean
+	sum    float64
+	comp   float64 // Neumaier compensation for sum
+	min    float64
+	max    float64
+	digest *tdigest
+}
+
+// NewAccumulator returns an empty Accumulator.
+func NewAccumulator() *Accumulator {
+	return &Accumulator{}
+}
+
+// Add records a value. NaN and infinite values are rejected with
+// calculator.ErrNonFinite and leave the accumulator unchanged.
+func (a *Accumulator) Add(x float64) error {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return calculator.ErrNonFinite
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.count == 0 || x < a.min {
+		a.min = x
+	}
+	if a.count == 0 || x > a.max {
+		a.max = x
+	}
+	a.count++
+	n := float64(a.count)
+	delta := x - a.mean
+	if math.IsInf(delta, 0) {
+		// x and the mean are too far apart to subtract; scale them first
+		a.mean += x/n - a.mean/n
+	} else {
+		a.mean += delta / n
+	}
+	a.m2 += delta * (x - a.mean)
+	a.addToSum(x)
+	a.digestLocked().add(x)
+	return nil
+}
+
+// Merge adds every value recorded by other, as if they had been added to a
+// directly. other is not modified.
+func (a *Accumulator) Merge(other *Accumulator) {
+	// Copy other first so the two locks are never held together
+	other.mu.Lock()
+	b := Accumulator{
+		count: other.count, mean: other.mean, m2: other.m2,
+		sum: other.sum, comp: other.comp, min: other.min, max: other.max,
+	}
+	if other.digest != nil {
+		b.digest = other.digest.clone()
+	}
+	other.mu.Unlock()
+
+	if b.count == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.count == 0 || b.min < a.min {
+		a.min = b.min
+	}
+	if a.count == 0 || b.max > a.max {
+		a.max = b.max
+	}
+
+	// Chan et al.'s parallel update of the mean and squared deviations
+	n := float64(a.count + b.count)
+	delta := b.mean - a.mean
+	if math.IsInf(delta, 0) {
+		a.mean = a.mean*(float64(a.count)/n) + b.mean*(float64(b.count)/n)
+	} else {
+		a.mean += delta * (float64(b.count) / n)
+	}
+	a.m2 += b.m2 + delta*delta*float64(a.count)*float64(b.count)/n
+	a.count += b.count
+
+	a.addToSum(b.sum)
+	a.addToSum(b.comp)
+	a.digestLocked().merge(b.digest)
+}
+
+// Count returns the number of values recorded.
+func (a *Accumulator) Count() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.count
+}
+
+// Sum returns the compensated sum of the values recorded.
+func (a *Accumulator) Sum() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sum + a.comp
+}
+
+// Mean returns the arithmetic mean of the values recorded.
+func (a *Accumulator) Mean() (float64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.checkLocked(1); err != nil {
+		return 0, err
+	}
+	return a.mean, nil
+}
+
+// Min returns the smallest value recorded.
+func (a *Accumulator) Min() (float64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.checkLocked(1); err != nil {
+		return 0, err
+	}
+	return a.min, nil
+}
+
+// Max returns the largest value recorded.
+func (a *Accumulator) Max() (float64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.checkLocked(1); err != nil {
+		return 0, err
+	}
+	return a.max, nil
+}
+
+// Variance returns the population variance of the values recorded.
+func (a *Accumulator) Variance() (float64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.checkLocked(1); err != nil {
+		return 0, err
+	}
+	return a.m2 / float64(a.count), nil
+}
+
+// SampleVariance returns the sample variance of the values recorded. It
+// needs at least two values.
+func (a *Accumulator) SampleVariance() (float64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.checkLocked(2); err != nil {
+		return 0, err
+	}
+	return a.m2 / float64(a.count-1), nil
+}
+
+// StdDev returns the population standard deviation.
+func (a *Accumulator) StdDev() (float64, error) {
+	v, err := a.Variance()
+	return math.Sqrt(v), err
+}
+
+// SampleStdDev returns the sample standard deviation. It needs at least two
+// values.
+func (a *Accumulator) SampleStdDev() (float64, error) {
+	v, err := a.SampleVariance()
+	return math.Sqrt(v), err
+}
+
+// Percentile estimates the p-th percentile, for p between 0 and 100. The
+// 0th and 100th percentiles are exact; others are approximate.
+func (a *Accumulator) Percentile(p float64) (float64, error) {
+	if math.IsNaN(p) || p < 0 || p > 100 {
+		return 0, fmt.Errorf("%w: %g", ErrInvalidPercentile, p)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.checkLocked(1); err != nil {
+		return 0, err
+	}
+	switch p {
+	case 0:
+		return a.min, nil
+	case 100:
+		return a.max, nil
+	}
+	return a.digest.quantile(p/100, a.min, a.max), nil
+}
+
+// addToSum adds x to the compensated sum. The caller must hold a.mu.
+func (a *Accumulator) addToSum(x float64) {
+	t := a.sum + x
+	if math.Abs(a.sum) >= math.Abs(x) {
+		a.comp += (a.sum - t) + x
+	} else {
+		a.comp += (x - t) + a.sum
+	}
+	a.sum = t
+}
+
+// digestLocked returns the t-digest, creating it on first use. The caller
+// must hold a.mu.
+func (a *Accumulator) digestLocked() *tdigest {
+	if a.digest == nil {
+		a.digest = newTDigest(defaultCompression)
+	}
+	return a.digest
+}
+
+// checkLocked returns an error if fewer than min values were recorded. The
+// caller must hold a.mu.
+func (a *Accumulator) checkLocked(min int64) error {
+	switch {
+	case a.count == 0:
+		return ErrEmpty
+	case a.count < min:
+		return fmt.Errorf("%w: need at least %d, got %d", ErrTooFewValues, min, a.count)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+func TestAccumulatorMatchesBatch(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9, 1e9 + 1, -3.5}
+
+	var acc Accumulator
+	for _, x := range data {
+		if err := acc.Add(x); err != nil {
+			t.Fatalf("Add(%v) error: %v", x, err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		got   func() (float64, error)
+		exact func([]float64) (float64, error)
+	}{
+		{"mean", acc.Mean, Mean},
+		{"min", acc.Min, Min},
+		{"max", acc.Max, Max},
+		{"variance", acc.Variance, Variance},
+		{"sample_variance", acc.SampleVariance, SampleVariance},
+		{"stddev", acc.StdDev, StdDev},
+		{"sample_stddev", acc.SampleStdDev, SampleStdDev},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.got()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want, _ := tt.exact(data)
+			if math.Abs(got-want) > 1e-9*math.Max(1, math.Abs(want)) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+
+	if got := acc.Count(); got != int64(len(data)) {
+		t.Errorf("Count() = %d, want %d", got, len(data))
+	}
+	if got, want := acc.Sum(), Sum(data); got != want {
+		t.Errorf("Sum() = %v, want %v", got, want)
+	}
+}
+
+func TestAccumulatorErrors(t *testing.T) {
+	var empty Accumulator
+	if _, err := empty.Mean(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Mean() of empty accumulator error = %v, want %v", err, ErrEmpty)
+	}
+	if _, err := empty.Percentile(50); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Percentile() of empty accumulator error = %v, want %v", err, ErrEmpty)
+	}
+
+	one := NewAccumulator()
+	_ = one.Add(1)
+	if _, err := one.SampleVariance(); !errors.Is(err, ErrTooFewValues) {
+		t.Errorf("SampleVariance() of one value error = %v, want %v", err, ErrTooFewValues)
+	}
+	if _, err := one.Percentile(101); !errors.Is(err, ErrInvalidPercentile) {
+		t.Errorf("Percentile(101) error = %v, want %v", err, ErrInvalidPercentile)
+	}
+	if err := one.Add(math.NaN()); !errors.Is(err, calculator.ErrNonFinite) {
+		t.Errorf("Add(NaN) error = %v, want %v", err, calculator.ErrNonFinite)
+	}
+	if one.Count() != 1 {
+		t.Errorf("Add(NaN) changed the count to %d", one.Count())
+	}
+}
+
+func TestAccumulatorLargeMean(t *testing.T) {
+	a, b := NewAccumulator(), NewAccumulator()
+	for _, x := range []float64{1.6e308, -1.6e308} {
+		_ = a.Add(x)
+	}
+	for _, x := range []float64{1.6e308, 1.6e308} {
+		_ = b.Add(x)
+	}
+	if got, err := a.Mean(); err != nil || got != 0 {
+		t.Errorf("Mean() = %v, %v, want 0", got, err)
+	}
+	a.Merge(b)
+	if got, err := a.Mean(); err != nil || got != 0.8e308 {
+		t.Errorf("Mean() after Merge = %v, %v, want 8e307", got, err)
+	}
+}
+
+func TestAccumulatorPercentileAccuracy(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	tests := []struct {
+		name     string
+		generate func() float64
+	}{
+		{"uniform", rng.Float64},
+		{"normal", rng.NormFloat64},
+		{"exponential", rng.ExpFloat64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const n = 100000
+			data := make([]float64, n)
+			acc := NewAccumulator()
+			for i := range data {
+				data[i] = tt.generate()
+				_ = acc.Add(data[i])
+			}
+			sorted := sortedCopy(data)
+
+			for _, p := range []float64{0, 1, 10, 25, 50, 75, 90, 99, 99.9, 100} {
+				got, err := acc.Percentile(p)
+				if err != nil {
+					t.Fatalf("Percentile(%v) error: %v", p, err)
+				}
+				// Compare ranks rather than values, since the error of a
+				// t-digest is bounded in rank
+				rank := float64(sort.SearchFloat64s(sorted, got)) / n * 100
+				if math.Abs(rank-p) > 0.5 {
+					t.Errorf("Percentile(%v) = %v, which has rank %v", p, got, rank)
+				}
+			}
+			if size := acc.digest.size(); size > 5*defaultCompression+defaultCompression*2 {
+				t.Errorf("digest holds %d entries, want a bounded number", size)
+			}
+		})
+	}
+}
+
+func TestAccumulatorSmallPercentiles(t *testing.T) {
+	acc := NewAccumulator()
+	for _, x := range []float64{4, 1, 3, 2} {
+		_ = acc.Add(x)
+	}
+
+	tests := []struct {
+		p        float64
+		expected float64
+	}{
+		{0, 1},
+		{50, 2.5},
+		{100, 4},
+	}
+	for _, tt := range tests {
+		if got, _ := acc.Percentile(tt.p); got != tt.expected {
+			t.Errorf("Percentile(%v) = %v, want %v", tt.p, got, tt.expected)
+		}
+	}
+}
+
+func TestAccumulatorMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	data := make([]float64, 20000)
+	for i := range data {
+		data[i] = rng.NormFloat64()*10 + 100
+	}
+
+	// Fill one accumulator per goroutine, then merge them
+	const parts = 4
+	accs := make([]*Accumulator, parts)
+	var wg sync.WaitGroup
+	for i := range accs {
+		accs[i] = NewAccumulator()
+		wg.Add(1)
+		go func(acc *Accumulator, chunk []float64) {
+			defer wg.Done()
+			for _, x := range chunk {
+				_ = acc.Add(x)
+			}
+		}(accs[i], data[i*len(data)/parts:(i+1)*len(data)/parts])
+	}
+	wg.Wait()
+
+	merged := NewAccumulator()
+	for _, acc := range accs {
+		merged.Merge(acc)
+	}
+	merged.Merge(NewAccumulator())
+
+	if merged.Count() != int64(len(data)) {
+		t.Fatalf("Count() = %d, want %d", merged.Count(), len(data))
+	}
+	mean, _ := merged.Mean()
+	wantMean, _ := Mean(data)
+	if math.Abs(mean-wantMean) > 1e-9 {
+		t.Errorf("Mean() = %v, want %v", mean, wantMean)
+	}
+	variance, _ := merged.SampleVariance()
+	wantVariance, _ := SampleVariance(data)
+	if math.Abs(variance-wantVariance) > 1e-9*wantVariance {
+		t.Errorf("SampleVariance() = %v, want %v", variance, wantVariance)
+	}
+	min, _ := merged.Min()
+	max, _ := merged.Max()
+	wantMin, _ := Min(data)
+	wantMax, _ := Max(data)
+	if min != wantMin || max != wantMax {
+		t.Errorf("Min(), Max() = %v, %v, want %v, %v", min, max, wantMin, wantMax)
+	}
+	median, _ := merged.Percentile(50)
+	wantMedian, _ := Median(data)
+	if math.Abs(median-wantMedian) > 0.1 {
+		t.Errorf("Percentile(50) = %v, want about %v", median, wantMedian)
+	}
+}
+
+func TestAccumulatorConcurrentAdd(t *testing.T) {
+	acc := NewAccumulator()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 1; j <= 1000; j++ {
+				_ = acc.Add(float64(j))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if acc.Count() != 8000 {
+		t.Errorf("Count() = %d, want 8000", acc.Count())
+	}
+	if mean, _ := acc.Mean(); math.Abs(mean-500.5) > 1e-9 {
+		t.Errorf("Mean() = %v, want 500.5", mean)
+	}
+}
@@ -0,0 +1,432 @@
+// Package stats provides descriptive statistics over datasets of float64
+// values. Functions never modify their input slice.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Sentinel errors returned by this package. Datasets containing NaN or an
+// infinity are rejected with calculator.ErrNonFinite.
+var (
+	// ErrEmpty is returned when a statistic is requested for no values.
+	ErrEmpty = errors.New("dataset is empty")
+
+	// ErrTooFewValues is returned when a statistic needs more values than
+	// were given, such as the sample variance of a single value.
+	ErrTooFewValues = errors.New("not enough values")
+
+	// ErrZeroVariance is returned for the skewness and kurtosis of a dataset
+	// whose values are all equal.
+	ErrZeroVariance = errors.New("variance is zero")
+
+	// ErrInvalidPercentile is returned for a percentile outside [0, 100].
+	ErrInvalidPercentile = errors.New("percentile must be between 0 and 100")
+
+	// ErrInvalidBins is returned when a histogram is requested with fewer
+	// than one bin.
+	ErrInvalidBins = errors.New("number of bins must be at least 1")
+)
+
+// Interpolation selects how Percentile estimates a value that falls between
+// two data points. The names follow NumPy.
+type Interpolation int
+
+const (
+	// Linear interpolates between the two closest ranks (NumPy's default
+	// and R's type 7).
+	Linear Interpolation = iota
+	// Lower takes the lower of the two closest values.
+	Lower
+	// Higher takes the higher of the two closest values.
+	Higher
+	// Nearest takes the closest value, rounding ties to the even rank.
+	Nearest
+	// Midpoint takes the mean of the two closest values.
+	Midpoint
+)
+
+// interpolationNames maps each Interpolation to its name.
+var interpolationNames = []string{"linear", "lower", "higher", "nearest", "midpoint"}
+
+// String returns the name of the method.
+func (m Interpolation) String() string {
+	if m < 0 || int(m) >= len(interpolationNames) {
+		return fmt.Sprintf("Interpolation(%d)", int(m))
+	}
+	return interpolationNames[m]
+}
+
+// ParseInterpolation converts a method name such as "linear" into an
+// Interpolation.
+func ParseInterpolation(name string) (Interpolation, error) {
+	for i, n := range interpolationNames {
+		if n == name {
+			return Interpolation(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown interpolation method %q (want %s)", name, strings.Join(interpolationNames, ", "))
+}
+
+// Bucket is one bin of a histogram. Values v with Lower <= v < Upper are
+// counted, except in the last bucket, which also includes Upper.
+type Bucket struct {
+	Lower float64
+	Upper float64
+	Count int
+}
+
+// Sum returns the sum of the values using Neumaier's variant of Kahan
+// summation, so the rounding error does not grow with the number of values.
+// The sum of no values is 0.
+func Sum(data []float64) float64 {
+	sum, compensation := 0.0, 0.0
+	for _, x := range data {
+		t := sum + x
+		if math.Abs(sum) >= math.Abs(x) {
+			compensation += (sum - t) + x
+		} else {
+			compensation += (x - t) + sum
+		}
+		sum = t
+	}
+	if math.IsInf(sum, 0) || math.IsNaN(sum) {
+		// The compensation is meaningless once the sum is not finite
+		return sum
+	}
+	return sum + compensation
+}
+
+// Mean returns the arithmetic mean of the values.
+// Returns calculator.ErrOverflow if the mean is not finite.
+func Mean(data []float64) (float64, error) {
+	if err := check(data, 1); err != nil {
+		return 0, err
+	}
+	m := average(data)
+	if math.IsInf(m, 0) || math.IsNaN(m) {
+		return 0, calculator.ErrOverflow
+	}
+	return m, nil
+}
+
+// average returns the mean of data, which must not be empty. If the sum
+// overflows, the values are divided by their count before they are summed,
+// so the mean of values near math.MaxFloat64 is still finite.
+func average(data []float64) float64 {
+	n := float64(len(data))
+	if m := Sum(data) / n; !math.IsInf(m, 0) && !math.IsNaN(m) {
+		return m
+	}
+	scaled := make([]float64, len(data))
+	for i, x := range data {
+		scaled[i] = x / n
+	}
+	return Sum(scaled)
+}
+
+// Median returns the middle value, or the mean of the two middle values
+// when there is an even number of values.
+func Median(data []float64) (float64, error) {
+	return Percentile(data, 50, Linear)
+}
+
+// Mode returns the most frequent values in ascending order. Every value
+// that shares the highest count is returned; if no value occurs more than
+// once the result is empty.
+func Mode(data []float64) ([]float64, error) {
+	if err := check(data, 1); err != nil {
+		return nil, err
+	}
+	sorted := sortedCopy(data)
+
+	var modes []float64
+	best := 1
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		switch count := j - i; {
+		case count > best:
+			best = count
+			modes = []float64{sorted[i]}
+		case count == best && best > 1:
+			modes = append(modes, sorted[i])
+		}
+		i = j
+	}
+	return modes, nil
+}
+
+// Min returns the smallest value.
+func Min(data []float64) (float64, error) {
+	if err := check(data, 1); err != nil {
+		return 0, err
+	}
+	min := data[0]
+	for _, x := range data[1:] {
+		if x < min {
+			min = x
+		}
+	}
+	return min, nil
+}
+
+// Max returns the largest value.
+func Max(data []float64) (float64, error) {
+	if err := check(data, 1); err != nil {
+		return 0, err
+	}
+	max := data[0]
+	for _, x := range data[1:] {
+		if x > max {
+			max = x
+		}
+	}
+	return max, nil
+}
+
+// Variance returns the population variance, the mean squared deviation
+// from the mean.
+// Returns calculator.ErrOverflow if the variance is not finite.
+func Variance(data []float64) (float64, error) {
+	if err := check(data, 1); err != nil {
+		return 0, err
+	}
+	v := sumSquaredDeviations(data) / float64(len(data))
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, calculator.ErrOverflow
+	}
+	return v, nil
+}
+
+// SampleVariance returns the unbiased sample variance, which divides by
+// n-1. It needs at least two values.
+// Returns calculator.ErrOverflow if the variance is not finite.
+func SampleVariance(data []float64) (float64, error) {
+	if err := check(data, 2); err != nil {
+		return 0, err
+	}
+	v := sumSquaredDeviations(data) / float64(len(data)-1)
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, calculator.ErrOverflow
+	}
+	return v, nil
+}
+
+// StdDev returns the population standard deviation.
+// Returns calculator.ErrOverflow if the variance is not finite.
+func StdDev(data []float64) (float64, error) {
+	v, err := Variance(data)
+	return math.Sqrt(v), err
+}
+
+// SampleStdDev returns the sample standard deviation. It needs at least two
+// values.
+// Returns calculator.ErrOverflow if the variance is not finite.
+func SampleStdDev(data []float64) (float64, error) {
+	v, err := SampleVariance(data)
+	return math.Sqrt(v), err
+}
+
+// Skewness returns the population skewness g1 = m3 / m2^(3/2), where mk is
+// the k-th central moment. Symmetric data has a skewness of 0.
+func Skewness(data []float64) (float64, error) {
+	m2, m3, _, err := centralMoments(data)
+	if err != nil {
+		return 0, err
+	}
+	return m3 / math.Pow(m2, 1.5), nil
+}
+
+// Kurtosis returns the population excess kurtosis g2 = m4 / m2^2 - 3, where
+// mk is the k-th central moment. Normally distributed data has an excess
+// kurtosis of 0.
+func Kurtosis(data []float64) (float64, error) {
+	m2, _, m4, err := centralMoments(data)
+	if err != nil {
+		return 0, err
+	}
+	return m4/(m2*m2) - 3, nil
+}
+
+// Percentile returns the p-th percentile of the values, for p between 0 and
+// 100, using the given interpolation method.
+func Percentile(data []float64, p float64, method Interpolation) (float64, error) {
+	results, err := Percentiles(data, []float64{p}, method)
+	if err != nil {
+		return 0, err
+	}
+	return results[0], nil
+}
+
+// Percentiles returns several percentiles at once, sorting the values only
+// once.
+func Percentiles(data []float64, ps []float64, method Interpolation) ([]float64, error) {
+	if err := check(data, 1); err != nil {
+		return nil, err
+	}
+	for _, p := range ps {
+		if math.IsNaN(p) || p < 0 || p > 100 {
+			return nil, fmt.Errorf("%w: %g", ErrInvalidPercentile, p)
+		}
+	}
+	if method < Linear || method > Midpoint {
+		return nil, fmt.Errorf("unknown interpolation method %v", method)
+	}
+
+	sorted := sortedCopy(data)
+	results := make([]float64, len(ps))
+	for i, p := range ps {
+		results[i] = percentileSorted(sorted, p, method)
+	}
+	return results, nil
+}
+
+// Histogram counts the values in bins of equal width spanning the range of
+// the data. If every value is equal the range is widened to one unit
+// centred on that value.
+func Histogram(data []float64, bins int) ([]Bucket, error) {
+	if bins < 1 {
+		return nil, ErrInvalidBins
+	}
+	if err := check(data, 1); err != nil {
+		return nil, err
+	}
+
+	lo, _ := Min(data)
+	hi, _ := Max(data)
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+	// Work in halves when the range itself would overflow, so that data
+	// spanning most of the float64 range still gets finite bins. Halving is
+	// exact, so ordinary data is binned exactly as before.
+	scale := 1.0
+	if math.IsInf(hi-lo, 0) {
+		scale = 0.5
+	}
+	width := (hi*scale - lo*scale) / float64(bins)
+
+	buckets := make([]Bucket, bins)
+	for i := range buckets {
+		buckets[i].Lower = (lo*scale + float64(i)*width) / scale
+		buckets[i].Upper = (lo*scale + float64(i+1)*width) / scale
+	}
+	buckets[bins-1].Upper = hi
+
+	for _, x := range data {
+		i := bins - 1
+		if pos := (x*scale - lo*scale) / width; pos < float64(bins) {
+			i = int(pos)
+		}
+		if i < 0 {
+			i = 0
+		}
+		// Guard against rounding placing a value just below a boundary
+		// into the following bucket.
+		for i > 0 && x < buckets[i].Lower {
+			i--
+		}
+		buckets[i].Count++
+	}
+	return buckets, nil
+}
+
+// percentileSorted computes a percentile of sorted, non-empty data.
+func percentileSorted(sorted []float64, p float64, method Interpolation) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := math.Floor(rank)
+	hi := math.Ceil(rank)
+	frac := rank - lo
+	below, above := sorted[int(lo)], sorted[int(hi)]
+
+	switch method {
+	case Lower:
+		return below
+	case Higher:
+		return above
+	case Nearest:
+		if math.RoundToEven(rank) == lo {
+			return below
+		}
+		return above
+	case Midpoint:
+		return below + (above-below)/2
+	default:
+		return below + frac*(above-below)
+	}
+}
+
+// centralMoments returns the second, third and fourth central moments.
+func centralMoments(data []float64) (m2, m3, m4 float64, err error) {
+	if err := check(data, 1); err != nil {
+		return 0, 0, 0, err
+	}
+	mean := average(data)
+
+	d2 := make([]float64, len(data))
+	d3 := make([]float64, len(data))
+	d4 := make([]float64, len(data))
+	for i, x := range data {
+		d := x - mean
+		d2[i] = d * d
+		d3[i] = d2[i] * d
+		d4[i] = d2[i] * d2[i]
+	}
+
+	n := float64(len(data))
+	m2, m3, m4 = Sum(d2)/n, Sum(d3)/n, Sum(d4)/n
+	if m2 == 0 {
+		return 0, 0, 0, ErrZeroVariance
+	}
+	return m2, m3, m4, nil
+}
+
+// sumSquaredDeviations returns the sum of squared deviations from the mean
+// using the corrected two-pass algorithm, which cancels most of the
+// rounding error of the first pass.
+func sumSquaredDeviations(data []float64) float64 {
+	mean := average(data)
+	squares := make([]float64, len(data))
+	deviations := make([]float64, len(data))
+	for i, x := range data {
+		d := x - mean
+		deviations[i] = d
+		squares[i] = d * d
+	}
+	correction := Sum(deviations)
+	return Sum(squares) - correction*correction/float64(len(data))
+}
+
+// check returns an error if data has fewer than min values or contains a
+// non-finite value.
+func check(data []float64, min int) error {
+	switch {
+	case len(data) == 0:
+		return ErrEmpty
+	case len(data) < min:
+		return fmt.Errorf("%w: need at least %d, got %d", ErrTooFewValues, min, len(data))
+	}
+	for _, x := range data {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return calculator.ErrNonFinite
+		}
+	}
+	return nil
+}
+
+// sortedCopy returns a sorted copy of data.
+func sortedCopy(data []float64) []float64 {
+	sorted := make([]float64, len(data))
+	copy(sorted, data)
+	sort.Float64s(sorted)
+	return sorted
+}
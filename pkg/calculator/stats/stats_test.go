@@ -0,0 +1,236 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+const tolerance = 1e-9
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		expected float64
+	}{
+		{"empty", nil, 0},
+		{"integers", []float64{1, 2, 3, 4}, 10},
+		{"tenths", []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}, 1},
+		{"cancellation", []float64{1, 1e100, 1, -1e100}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sum(tt.data); got != tt.expected {
+				t.Errorf("Sum(%v) = %v, want %v", tt.data, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScalarStatistics(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	tests := []struct {
+		name     string
+		fn       func([]float64) (float64, error)
+		data     []float64
+		expected float64
+		err      error
+	}{
+		{"mean", Mean, data, 5, nil},
+		{"median_even", Median, data, 4.5, nil},
+		{"median_odd", Median, []float64{3, 1, 2}, 2, nil},
+		{"min", Min, data, 2, nil},
+		{"max", Max, data, 9, nil},
+		{"variance", Variance, data, 4, nil},
+		{"sample_variance", SampleVariance, data, 32.0 / 7, nil},
+		{"stddev", StdDev, data, 2, nil},
+		{"sample_stddev", SampleStdDev, data, math.Sqrt(32.0 / 7), nil},
+		{"skewness_symmetric", Skewness, []float64{1, 2, 3, 4, 5}, 0, nil},
+		{"skewness", Skewness, data, 0.65625, nil},
+		{"kurtosis_uniform", Kurtosis, []float64{1, 2, 3, 4, 5}, -1.3, nil},
+		{"variance_large_offset", Variance, []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}, 22.5, nil},
+		{"mean_large", Mean, []float64{1.5e308, 1.5e308}, 1.5e308, nil},
+		{"mean_large_opposite", Mean, []float64{1.6e308, 1.6e308, -1.6e308, 1.6e308}, 0.8e308, nil},
+		{"variance_overflow", Variance, []float64{-1e308, 1e308}, 0, calculator.ErrOverflow},
+		{"sample_variance_overflow", SampleVariance, []float64{-1e308, 1e308}, 0, calculator.ErrOverflow},
+		{"stddev_overflow", StdDev, []float64{-1e308, 1e308}, 0, calculator.ErrOverflow},
+		{"sample_stddev_overflow", SampleStdDev, []float64{-1e308, 1e308}, 0, calculator.ErrOverflow},
+		{"mean_empty", Mean, nil, 0, ErrEmpty},
+		{"min_empty", Min, []float64{}, 0, ErrEmpty},
+		{"sample_variance_single", SampleVariance, []float64{1}, 0, ErrTooFewValues},
+		{"skewness_constant", Skewness, []float64{3, 3, 3}, 0, ErrZeroVariance},
+		{"kurtosis_constant", Kurtosis, []float64{3}, 0, ErrZeroVariance},
+		{"mean_nan", Mean, []float64{1, math.NaN()}, 0, calculator.ErrNonFinite},
+		{"max_inf", Max, []float64{math.Inf(1)}, 0, calculator.ErrNonFinite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.data)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.expected) > tolerance {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		expected []float64
+		err      error
+	}{
+		{"single", []float64{1, 2, 2, 3}, []float64{2}, nil},
+		{"multiple", []float64{3, 1, 3, 1, 2}, []float64{1, 3}, nil},
+		{"all_unique", []float64{1, 2, 3}, nil, nil},
+		{"all_equal", []float64{4, 4}, []float64{4}, nil},
+		{"empty", nil, nil, ErrEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Mode(tt.data)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Mode(%v) error = %v, want %v", tt.data, err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Mode(%v) = %v, want %v", tt.data, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	data := []float64{15, 20, 35, 40, 50}
+
+	tests := []struct {
+		name     string
+		p        float64
+		method   Interpolation
+		expected float64
+		err      error
+	}{
+		{"min", 0, Linear, 15, nil},
+		{"max", 100, Linear, 50, nil},
+		{"median", 50, Linear, 35, nil},
+		{"linear", 40, Linear, 29, nil},
+		{"lower", 40, Lower, 20, nil},
+		{"higher", 40, Higher, 35, nil},
+		{"nearest_down", 30, Nearest, 20, nil},
+		{"nearest_up", 40, Nearest, 35, nil},
+		{"nearest_tie_even", 62.5, Nearest, 35, nil},
+		{"midpoint", 40, Midpoint, 27.5, nil},
+		{"negative", -1, Linear, 0, ErrInvalidPercentile},
+		{"above_100", 101, Linear, 0, ErrInvalidPercentile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Percentile(data, tt.p, tt.method)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Percentile(%v, %v) error = %v, want %v", tt.p, tt.method, err, tt.err)
+			}
+			if math.Abs(got-tt.expected) > tolerance {
+				t.Errorf("Percentile(%v, %v) = %v, want %v", tt.p, tt.method, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPercentilesDoesNotModifyInput(t *testing.T) {
+	data := []float64{3, 1, 2}
+	if _, err := Percentiles(data, []float64{25, 75}, Linear); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(data, []float64{3, 1, 2}) {
+		t.Errorf("input was modified: %v", data)
+	}
+}
+
+func TestParseInterpolation(t *testing.T) {
+	for _, method := range []Interpolation{Linear, Lower, Higher, Nearest, Midpoint} {
+		got, err := ParseInterpolation(method.String())
+		if err != nil || got != method {
+			t.Errorf("ParseInterpolation(%q) = %v, %v, want %v", method.String(), got, err, method)
+		}
+	}
+	if _, err := ParseInterpolation("cubic"); err == nil {
+		t.Error("ParseInterpolation(\"cubic\") should return an error")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		bins     int
+		expected []Bucket
+		err      error
+	}{
+		{
+			name: "even",
+			data: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			bins: 2,
+			expected: []Bucket{
+				{Lower: 0, Upper: 5, Count: 5},
+				{Lower: 5, Upper: 10, Count: 6},
+			},
+		},
+		{
+			name: "uneven",
+			data: []float64{1, 1, 2, 4},
+			bins: 3,
+			expected: []Bucket{
+				{Lower: 1, Upper: 2, Count: 2},
+				{Lower: 2, Upper: 3, Count: 1},
+				{Lower: 3, Upper: 4, Count: 1},
+			},
+		},
+		{
+			name:     "constant",
+			data:     []float64{7, 7},
+			bins:     1,
+			expected: []Bucket{{Lower: 6.5, Upper: 7.5, Count: 2}},
+		},
+		{
+			name: "range_overflows",
+			data: []float64{-1.7e308, 1.7e308, 0},
+			bins: 3,
+			expected: []Bucket{
+				{Lower: -1.7e308, Upper: -5.666666666666666e307, Count: 1},
+				{Lower: -5.666666666666666e307, Upper: 5.666666666666668e307, Count: 1},
+				{Lower: 5.666666666666668e307, Upper: 1.7e308, Count: 1},
+			},
+		},
+		{name: "no_bins", data: []float64{1}, bins: 0, err: ErrInvalidBins},
+		{name: "empty", data: nil, bins: 2, err: ErrEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Histogram(tt.data, tt.bins)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Histogram() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Histogram() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
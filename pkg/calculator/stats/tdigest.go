@@ -0,0 +1,138 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// defaultCompression is the t-digest compression used by Accumulator. The
+// digest keeps at most about compression*π/2 centroids, and quantile
+// estimates near the median are typically within 0.5% of the rank.
+const defaultCompression = 100
+
+// centroid is a cluster of values summarized by their mean and count.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// tdigest is a merging t-digest (Dunning and Ertl) that estimates
+// quantiles in bounded memory. Clusters are kept small near the tails, so
+// extreme quantiles are estimated more accurately than the median.
+type tdigest struct {
+	compression float64
+	centroids   []centroid // merged, sorted by mean
+	buffer      []centroid // values not yet merged
+	weight      float64    // total weight of centroids
+}
+
+// newTDigest returns an empty digest with the given compression.
+func newTDigest(compression float64) *tdigest {
+	return &tdigest{compression: compression}
+}
+
+// add records a value.
+func (d *tdigest) add(x float64) {
+	d.buffer = append(d.buffer, centroid{mean: x, weight: 1})
+	if len(d.buffer) >= d.bufferLimit() {
+		d.compress()
+	}
+}
+
+// merge adds every value summarized by other.
+func (d *tdigest) merge(other *tdigest) {
+	d.buffer = append(d.buffer, other.centroids...)
+	d.buffer = append(d.buffer, other.buffer...)
+	d.compress()
+}
+
+// bufferLimit is the number of unmerged values that triggers a compression.
+func (d *tdigest) bufferLimit() int {
+	return int(5 * d.compression)
+}
+
+// compress merges the buffer into the centroids, combining neighbours
+// while the k1 scale function allows it.
+func (d *tdigest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := append(d.centroids, d.buffer...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	total := 0.0
+	for _, c := range all {
+		total += c.weight
+	}
+
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	current := all[0]
+	before := 0.0 // weight of the centroids already emitted
+	for _, next := range all[1:] {
+		proposed := current.weight + next.weight
+		if d.scale((before+proposed)/total)-d.scale(before/total) <= 1 {
+			current.mean += (next.mean - current.mean) * next.weight / proposed
+			current.weight = proposed
+			continue
+		}
+		merged = append(merged, current)
+		before += current.weight
+		current = next
+	}
+	merged = append(merged, current)
+
+	d.centroids = merged
+	d.buffer = d.buffer[:0]
+	d.weight = total
+}
+
+// scale is the k1 scale function, which maps a quantile to a centroid
+// index so that centroids near q=0 and q=1 hold few values.
+func (d *tdigest) scale(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// quantile estimates the q-th quantile, for q between 0 and 1, of a
+// non-empty digest. min and max are the exact extremes of the data and
+// anchor the interpolation at the tails.
+func (d *tdigest) quantile(q, min, max float64) float64 {
+	d.compress()
+	cs := d.centroids
+	if len(cs) == 1 {
+		return cs[0].mean
+	}
+
+	// Each centroid is treated as centred on the middle of its weight
+	target := q * d.weight
+	if first := cs[0].weight / 2; target < first {
+		return min + (cs[0].mean-min)*target/first
+	}
+
+	cumulative := 0.0
+	for i := 0; i < len(cs)-1; i++ {
+		centre := cumulative + cs[i].weight/2
+		nextCentre := cumulative + cs[i].weight + cs[i+1].weight/2
+		if target < nextCentre {
+			t := (target - centre) / (nextCentre - centre)
+			return cs[i].mean + t*(cs[i+1].mean-cs[i].mean)
+		}
+		cumulative += cs[i].weight
+	}
+
+	last := cs[len(cs)-1]
+	centre := d.weight - last.weight/2
+	return last.mean + (max-last.mean)*(target-centre)/(d.weight-centre)
+}
+
+// size returns the number of centroids and buffered values held.
+func (d *tdigest) size() int {
+	return len(d.centroids) + len(d.buffer)
+}
+
+// clone returns a deep copy of d.
+func (d *tdigest) clone() *tdigest {
+	c := *d
+	c.centroids = append([]centroid(nil), d.centroids...)
+	c.buffer = append([]centroid(nil), d.buffer...)
+	return &c
+}
@@ -0,0 +1,114 @@
+// Package units provides quantities with physical dimensions: a number
+// together with a unit such as km or kg*m/s^2. Quantities convert between
+// compatible units, and arithmetic on them checks that dimensions agree, so
+// "3 km + 200 m" is 3.2 km while "5 kg + 3 s" is an error. Units are defined
+// in a Table, loaded from an embedded list of SI, imperial, time and data
+// size units and optionally from user definitions.
+package units
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The base dimensions, each measured in an SI base unit, plus information
+// measured in bits.
+const (
+	Length = iota
+	Mass
+	Time
+	Current
+	Temperature
+	Amount
+	Luminosity
+	Information
+	numDimensions
+)
+
+// baseUnits are the symbols of the units the base dimensions are measured
+// in, used to display dimensions.
+var baseUnits = [numDimensions]string{"m", "kg", "s", "A", "K", "mol", "cd", "bit"}
+
+// dimensionNames are the names of the base dimensions in unit definitions,
+// as in "m = !length".
+var dimensionNames = [numDimensions]string{"length", "mass", "time", "current", "temperature", "amount", "luminosity", "information"}
+
+// Dimension holds the exponent of each base dimension, indexed by Length,
+// Mass and so on. Velocity, for example, has Length 1 and Time -1. The zero
+// Dimension is that of plain numbers.
+type Dimension [numDimensions]int
+
+// Mul returns the dimension of a product.
+func (d Dimension) Mul(o Dimension) Dimension {
+	for i := range d {
+		d[i] += o[i]
+	}
+	return d
+}
+
+// Div returns the dimension of a quotient.
+func (d Dimension) Div(o Dimension) Dimension {
+	for i := range d {
+		d[i] -= o[i]
+	}
+	return d
+}
+
+// Pow returns the dimension raised to the integer power n.
+func (d Dimension) Pow(n int) Dimension {
+	for i := range d {
+		d[i] *= n
+	}
+	return d
+}
+
+// IsDimensionless reports whether d is the dimension of plain numbers.
+func (d Dimension) IsDimensionless() bool {
+	return d == Dimension{}
+}
+
+// String returns the dimension in base units, e.g. "m*kg/s^2", or "1" if it
+// is dimensionless.
+func (d Dimension) String() string {
+	var num, den []string
+	for i, exp := range d {
+		switch {
+		case exp > 0:
+			num = append(num, power(baseUnits[i], exp))
+		case exp < 0:
+			den = append(den, power(baseUnits[i], -exp))
+		}
+	}
+	s := strings.Join(num, "*")
+	if s == "" {
+		s = "1"
+	}
+	switch len(den) {
+	case 0:
+		return s
+	case 1:
+		return s + "/" + den[0]
+	default:
+		return s + "/(" + strings.Join(den, "*") + ")"
+	}
+}
+
+// power returns symbol raised to exp, leaving out an exponent of 1.
+func power(symbol string, exp int) string {
+	if exp == 1 {
+		return symbol
+	}
+	return fmt.Sprintf("%s^%d", symbol, exp)
+}
+
+// parseDimension returns the base dimension with the given name.
+func parseDimension(name string) (Dimension, error) {
+	for i, n := range dimensionNames {
+		if n == name {
+			var d Dimension
+			d[i] = 1
+			return d, nil
+		}
+	}
+	return Dimension{}, fmt.Errorf("unknown dimension %q (want %s)", name, strings.Join(dimensionNames[:], ", "))
+}
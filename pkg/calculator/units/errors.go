@@ -0,0 +1,50 @@
+package units
+
+import (
+	"errors"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Sentinel errors returned by this package. Errors from operations on
+// quantities are wrapped in a *calculator.DomainError whose operands are the
+// quantities, e.g. "add(5 kg, 3 s): dimension mismatch".
+var (
+	// ErrDimensionMismatch is returned when adding, subtracting or
+	// converting quantities of different dimensions, such as mass and
+	// time.
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+
+	// ErrUnknownUnit is returned for a name that is neither a unit nor a
+	// prefixed unit of the table.
+	ErrUnknownUnit = errors.New("unknown unit")
+
+	// ErrFractionalPower is returned when raising a quantity with a
+	// dimension to a power that would give it a fractional dimension, such
+	// as the square root of a length.
+	ErrFractionalPower = errors.New("fractional power of a dimension")
+
+	// ErrNotDimensionless is returned when a quantity with a dimension is
+	// used where a plain number is needed, such as in an exponent or as
+	// the argument of sin.
+	ErrNotDimensionless = errors.New("quantity must be dimensionless")
+)
+
+// quantityError builds a *calculator.DomainError for op.
+func quantityError(op string, reason error, operands ...Quantity) error {
+	values := make([]interface{}, len(operands))
+	for i, q := range operands {
+		values[i] = q
+	}
+	return &calculator.DomainError{Op: op, Operands: values, Reason: reason}
+}
+
+// wrap replaces the operands of a *calculator.DomainError returned for the
+// base values of quantities with the quantities themselves.
+func wrap(err error, op string, operands ...Quantity) error {
+	var domainErr *calculator.DomainError
+	if errors.As(err, &domainErr) {
+		return quantityError(op, domainErr.Reason, operands...)
+	}
+	return err
+}
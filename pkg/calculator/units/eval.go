@@ -0,0 +1,191 @@
+package units
+
+import (
+	"fmt"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+)
+
+// Eval parses and evaluates an expression with quantities, such as
+// "3 km + 200 m in mi", in a default expression environment.
+func (t *Table) Eval(input string) (Quantity, error) {
+	node, err := expr.Parse(input)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return t.EvalNode(node, expr.NewEnv())
+}
+
+// EvalNode evaluates a parsed expression with quantities. Names refer to
+// variables of env, which are dimensionless, or failing that to units of
+// the table. Functions of env take and return dimensionless values, except
+// sqrt, which accepts any quantity whose square root has a whole dimension.
+// Conversions such as "x in mi" are evaluated with Quantity.In. Errors are
+// reported as *expr.EvalError, like those of expr.Node.Eval.
+func (t *Table) EvalNode(node expr.Node, env *expr.Env) (Quantity, error) {
+	switch n := node.(type) {
+	case *expr.NumberLit:
+		return Number(n.Value), nil
+	case *expr.VarRef:
+		if v, ok := env.Vars[n.Name]; ok {
+			return Number(v), nil
+		}
+		u, ok := t.Lookup(n.Name)
+		if !ok {
+			return Quantity{}, &expr.EvalError{Col: n.Col, Err: fmt.Errorf("%w %q", ErrUnknownUnit, n.Name)}
+		}
+		return New(1, u), nil
+	case *expr.UnaryExpr:
+		q, err := t.EvalNode(n.Operand, env)
+		if err != nil || n.Op != "-" {
+			return q, err
+		}
+		return Negate(q), nil
+	case *expr.BinaryExpr:
+		return t.evalBinary(n, env)
+	case *expr.ConvertExpr:
+		return t.evalConvert(n, env)
+	case *expr.CallExpr:
+		return t.evalCall(n, env)
+	case *expr.AssignExpr:
+		q, err := t.EvalNode(n.Value, env)
+		if err != nil {
+			return Quantity{}, err
+		}
+		if !q.dim.IsDimensionless() {
+			return Quantity{}, &expr.EvalError{Col: n.Col, Err: fmt.Errorf("cannot assign %s to %q: %w", q, n.Name, ErrNotDimensionless)}
+		}
+		// Assign the base value, so the environment decides which names
+		// may be assigned to
+		if _, err := (&expr.AssignExpr{Name: n.Name, Value: &expr.NumberLit{Value: q.si}, Col: n.Col}).Eval(env); err != nil {
+			return Quantity{}, err
+		}
+		return q, nil
+	default:
+		return Quantity{}, fmt.Errorf("unsupported expression %s", node)
+	}
+}
+
+// evalBinary evaluates both operands and applies the operator.
+func (t *Table) evalBinary(n *expr.BinaryExpr, env *expr.Env) (Quantity, error) {
+	a, err := t.EvalNode(n.Left, env)
+	if err != nil {
+		return Quantity{}, err
+	}
+	b, err := t.EvalNode(n.Right, env)
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	var result Quantity
+	switch n.Op {
+	case "+":
+		result, err = Add(a, b)
+	case "-":
+		result, err = Subtract(a, b)
+	case "*":
+		result, err = Multiply(a, b)
+	case "/":
+		result, err = Divide(a, b)
+	case "^":
+		if !b.dim.IsDimensionless() {
+			err = quantityError("power", ErrNotDimensionless, a, b)
+			break
+		}
+		result, err = Pow(a, b.si)
+	default:
+		err = fmt.Errorf("unknown operator %q", n.Op)
+	}
+	if err != nil {
+		return Quantity{}, &expr.EvalError{Col: n.Col, Err: err}
+	}
+	return result, nil
+}
+
+// evalConvert evaluates a conversion. The target must be a unit, such as mi
+// or km/h, rather than a quantity such as 2 km.
+func (t *Table) evalConvert(n *expr.ConvertExpr, env *expr.Env) (Quantity, error) {
+	q, err := t.EvalNode(n.Value, env)
+	if err != nil {
+		return Quantity{}, err
+	}
+	target, err := t.EvalNode(n.Unit, env)
+	if err != nil {
+		return Quantity{}, err
+	}
+	if target.unit.Name == "" || target.Value() != 1 {
+		return Quantity{}, &expr.EvalError{Col: n.Col, Err: fmt.Errorf("cannot convert to %s: not a unit", target)}
+	}
+	result, err := q.In(target.unit)
+	if err != nil {
+		return Quantity{}, &expr.EvalError{Col: n.Col, Err: err}
+	}
+	return result, nil
+}
+
+// evalCall calls a function of env on the arguments, which must be
+// dimensionless unless the function is sqrt.
+func (t *Table) evalCall(n *expr.CallExpr, env *expr.Env) (Quantity, error) {
+	args := make([]Quantity, len(n.Args))
+	values := make([]float64, len(n.Args))
+	for i, arg := range n.Args {
+		q, err := t.EvalNode(arg, env)
+		if err != nil {
+			return Quantity{}, err
+		}
+		args[i] = q
+		values[i] = q.si
+	}
+
+	if n.Name == "sqrt" && len(args) == 1 {
+		result, err := Sqrt(args[0])
+		if err != nil {
+			return Quantity{}, &expr.EvalError{Col: n.Col, Err: err}
+		}
+		return result, nil
+	}
+	for _, q := range args {
+		if !q.dim.IsDimensionless() {
+			return Quantity{}, &expr.EvalError{Col: n.Col, Err: quantityError(n.Name, ErrNotDimensionless, args...)}
+		}
+	}
+
+	call := &expr.CallExpr{Name: n.Name, Col: n.Col}
+	for _, v := range values {
+		call.Args = append(call.Args, &expr.NumberLit{Value: v})
+	}
+	v, err := call.Eval(env)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Number(v), nil
+}
+
+// Mentions reports whether node converts between units or refers to a name
+// that is a unit of the table rather than a variable of env, so it needs
+// EvalNode rather than expr.Node.Eval.
+func (t *Table) Mentions(node expr.Node, env *expr.Env) bool {
+	switch n := node.(type) {
+	case *expr.ConvertExpr:
+		return true
+	case *expr.VarRef:
+		if _, ok := env.Vars[n.Name]; ok {
+			return false
+		}
+		_, ok := t.Lookup(n.Name)
+		return ok
+	case *expr.UnaryExpr:
+		return t.Mentions(n.Operand, env)
+	case *expr.BinaryExpr:
+		return t.Mentions(n.Left, env) || t.Mentions(n.Right, env)
+	case *expr.AssignExpr:
+		return t.Mentions(n.Value, env)
+	case *expr.CallExpr:
+		for _, arg := range n.Args {
+			if t.Mentions(arg, env) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package units
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		unit     string
+	}{
+		{"3 km + 200 m in mi", 1.988387815159469, "mi"},
+		{"3 km + 200 m", 3.2, "km"},
+		{"9.8 m/s^2 * 2 s", 19.6, "m/s^2*s"},
+		{"60 km/h in m/s", 16.666666666666668, "m/s"},
+		{"2 * (3 ft + 6 inch) in m", 2.1336, "m"},
+		{"-5 kg in lb", -11.023113109243878, "lb"},
+		{"sqrt(9 m^2) in cm", 300, "cm"},
+		{"1 GiB / (8 Mbit/s) in s", 1073.741824, "s"},
+		{"2 km / 500 m", 4, ""},
+		{"sin(pi / 2) * m", 1, "m"},
+	}
+
+	table := DefaultTable()
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := table.Eval(tt.input)
+			if err != nil {
+				t.Fatalf("Eval(%q) unexpected error: %v", tt.input, err)
+			}
+			if !near(got.Value(), tt.expected) || got.Unit().Name != tt.unit {
+				t.Errorf("Eval(%q) = %s, want %v %s", tt.input, got, tt.expected, tt.unit)
+			}
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		col   int
+		err   error
+	}{
+		{"dimension_mismatch", "5 kg + 3 s", 6, ErrDimensionMismatch},
+		{"conversion_mismatch", "3 km in kg", 6, ErrDimensionMismatch},
+		{"unknown_unit", "3 cubits", 3, ErrUnknownUnit},
+		{"dimensioned_argument", "1 + sin(2 m)", 5, ErrNotDimensionless},
+		{"dimensioned_exponent", "2 ^ 3 m", 3, ErrNotDimensionless},
+		{"fractional_power", "4 m^0.5", 4, ErrFractionalPower},
+		{"division_by_zero", "1 m / (2 s - 2 s)", 5, calculator.ErrDivisionByZero},
+	}
+
+	table := DefaultTable()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := table.Eval(tt.input)
+			var evalErr *expr.EvalError
+			if !errors.As(err, &evalErr) {
+				t.Fatalf("Eval(%q) error = %v, want *expr.EvalError", tt.input, err)
+			}
+			if evalErr.Col != tt.col || !errors.Is(err, tt.err) {
+				t.Errorf("Eval(%q) error = %v at column %d, want %v at column %d", tt.input, err, evalErr.Col, tt.err, tt.col)
+			}
+		})
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"1 + 2", false},
+		{"sqrt(pi)", false},
+		{"3 km", true},
+		{"sqrt(2 m^2)", true},
+		{"x * 2", false},
+		{"2 + 1 in e", true},
+	}
+
+	table := DefaultTable()
+	env := expr.NewEnv()
+	env.Vars["x"] = 1
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			node, err := expr.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if got := table.Mentions(node, env); got != tt.expected {
+				t.Errorf("Mentions(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package units
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// Quantity is an amount of some unit, such as 3 km. It stores its value in
+// the base units of its dimension, so quantities in different units of the
+// same dimension can be added and compared, and keeps the unit it is
+// displayed in.
+type Quantity struct {
+	// si is the value in base units.
+	si   float64
+	dim  Dimension
+	unit Unit
+}
+
+// New returns value of unit u.
+func New(value float64, u Unit) Quantity {
+	return Quantity{si: value * u.Factor, dim: u.Dim, unit: u}
+}
+
+// Number returns a dimensionless quantity.
+func Number(value float64) Quantity {
+	return Quantity{si: value, unit: Unit{Factor: 1}}
+}
+
+// Value returns the value of q in its unit.
+func (q Quantity) Value() float64 {
+	if q.unit.Factor == 1 {
+		return q.si
+	}
+	return q.si / q.unit.Factor
+}
+
+// Unit returns the unit q is displayed in.
+func (q Quantity) Unit() Unit {
+	return q.unit
+}
+
+// Dim returns the dimension of q.
+func (q Quantity) Dim() Dimension {
+	return q.dim
+}
+
+// String returns the value and unit, e.g. "3.2 km".
+func (q Quantity) String() string {
+	s := strconv.FormatFloat(q.Value(), 'g', -1, 64)
+	if q.unit.Name != "" {
+		s += " " + q.unit.Name
+	}
+	return s
+}
+
+// In returns q converted to unit u.
+// Returns an error if u measures a different dimension.
+func (q Quantity) In(u Unit) (Quantity, error) {
+	if q.dim != u.Dim {
+		return Quantity{}, quantityError("convert", ErrDimensionMismatch, q, New(1, u))
+	}
+	q.unit = u
+	return q, nil
+}
+
+// Add returns a + b in the unit of a.
+// Returns an error if a and b have different dimensions or the sum
+// overflows.
+func Add(a, b Quantity) (Quantity, error) {
+	if a.dim != b.dim {
+		return Quantity{}, quantityError("add", ErrDimensionMismatch, a, b)
+	}
+	si, err := calculator.AddChecked(a.si, b.si)
+	if err != nil {
+		return Quantity{}, wrap(err, "add", a, b)
+	}
+	a.si = si
+	return a, nil
+}
+
+// Subtract returns a - b in the unit of a.
+// Returns an error if a and b have different dimensions or the difference
+// overflows.
+func Subtract(a, b Quantity) (Quantity, error) {
+	if a.dim != b.dim {
+		return Quantity{}, quantityError("subtract", ErrDimensionMismatch, a, b)
+	}
+	si, err := calculator.SubtractChecked(a.si, b.si)
+	if err != nil {
+		return Quantity{}, wrap(err, "subtract", a, b)
+	}
+	a.si = si
+	return a, nil
+}
+
+// Negate returns -q.
+func Negate(q Quantity) Quantity {
+	q.si = -q.si
+	return q
+}
+
+// Multiply returns a * b, in the product of their units, e.g. N*m.
+// Returns an error if the product overflows.
+func Multiply(a, b Quantity) (Quantity, error) {
+	si, err := calculator.MultiplyChecked(a.si, b.si)
+	if err != nil {
+		return Quantity{}, wrap(err, "multiply", a, b)
+	}
+	return derived(si, a.dim.Mul(b.dim), combine(a.unit, "*", b.unit)), nil
+}
+
+// Divide returns a / b, in the quotient of their units, e.g. km/h.
+// Returns an error if b is zero.
+func Divide(a, b Quantity) (Quantity, error) {
+	si, err := calculator.Divide(a.si, b.si)
+	if err != nil {
+		return Quantity{}, wrap(err, "divide", a, b)
+	}
+	return derived(si, a.dim.Div(b.dim), combine(a.unit, "/", b.unit)), nil
+}
+
+// Pow returns q raised to the power n.
+// Returns an error if q has a dimension and n is not an integer, or for the
+// errors of calculator.Power.
+func Pow(q Quantity, n float64) (Quantity, error) {
+	if q.dim.IsDimensionless() {
+		v, err := calculator.Power(q.si, n)
+		if err != nil {
+			return Quantity{}, wrap(err, "power", q, Number(n))
+		}
+		return Number(v), nil
+	}
+	if n != math.Trunc(n) {
+		return Quantity{}, quantityError("power", ErrFractionalPower, q, Number(n))
+	}
+	si, err := calculator.Power(q.si, n)
+	if err != nil {
+		return Quantity{}, wrap(err, "power", q, Number(n))
+	}
+	u := Unit{
+		Name:   group(q.unit.Name, "*/^") + "^" + strconv.Itoa(int(n)),
+		Factor: math.Pow(q.unit.Factor, n),
+	}
+	return derived(si, q.dim.Pow(int(n)), u), nil
+}
+
+// Sqrt returns the square root of q, in the base units of its dimension.
+// Returns an error if q is negative or its dimension has an odd exponent.
+func Sqrt(q Quantity) (Quantity, error) {
+	var dim Dimension
+	for i, exp := range q.dim {
+		if exp%2 != 0 {
+			return Quantity{}, quantityError("sqrt", ErrFractionalPower, q)
+		}
+		dim[i] = exp / 2
+	}
+	v, err := calculator.SquareRoot(q.si)
+	if err != nil {
+		return Quantity{}, wrap(err, "sqrt", q)
+	}
+	return derived(v, dim, Unit{Name: dim.String(), Factor: 1, Dim: dim}), nil
+}
+
+// derived returns the quantity with base value si, displayed in unit u
+// unless it is dimensionless, in which case any units cancelled out.
+func derived(si float64, dim Dimension, u Unit) Quantity {
+	if dim.IsDimensionless() {
+		return Number(si)
+	}
+	u.Dim = dim
+	return Quantity{si: si, dim: dim, unit: u}
+}
+
+// combine returns the unit a op b, where op is "*" or "/". The unit of a
+// plain number is left out.
+func combine(a Unit, op string, b Unit) Unit {
+	factor := a.Factor * b.Factor
+	if op == "/" {
+		factor = a.Factor / b.Factor
+	}
+	name := a.Name
+	switch {
+	case b.Name == "":
+	case a.Name == "" && op == "*":
+		name = b.Name
+	case a.Name == "":
+		name = "1/" + group(b.Name, "*/")
+	case op == "/":
+		name = a.Name + "/" + group(b.Name, "*/")
+	default:
+		name = a.Name + "*" + b.Name
+	}
+	return Unit{Name: name, Factor: factor}
+}
+
+// group wraps a unit name containing any of the operators ops in
+// parentheses, so it can be divided by or raised to a power without changing
+// its meaning.
+func group(name, ops string) string {
+	if strings.ContainsAny(name, ops) {
+		return "(" + name + ")"
+	}
+	return name
+}
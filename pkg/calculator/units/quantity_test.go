@@ -0,0 +1,126 @@
+package units
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator"
+)
+
+// mustUnit returns the unit called name in the default table or fails the
+// test.
+func mustUnit(t *testing.T, name string) Unit {
+	t.Helper()
+	u, ok := DefaultTable().Lookup(name)
+	if !ok {
+		t.Fatalf("Lookup(%q) found no unit", name)
+	}
+	return u
+}
+
+func TestQuantityOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(a, b Quantity) (Quantity, error)
+		a, b     float64
+		ua, ub   string
+		expected float64
+		unit     string
+		err      error
+	}{
+		{"add", Add, 3, 200, "km", "m", 3.2, "km", nil},
+		{"add_imperial", Add, 1, 12, "ft", "inch", 2, "ft", nil},
+		{"add_mismatch", Add, 5, 3, "kg", "s", 0, "", ErrDimensionMismatch},
+		{"subtract", Subtract, 1, 30, "h", "min", 0.5, "h", nil},
+		{"subtract_mismatch", Subtract, 1, 1, "m", "ha", 0, "", ErrDimensionMismatch},
+		{"multiply", Multiply, 2, 3, "N", "m", 6, "N*m", nil},
+		{"multiply_cancels", Multiply, 2, 3, "Hz", "s", 6, "", nil},
+		{"divide", Divide, 10, 2, "km", "h", 5, "km/h", nil},
+		{"divide_cancels", Divide, 1, 1, "km", "m", 1000, "", nil},
+		{"divide_by_zero", Divide, 1, 0, "m", "s", 0, "", calculator.ErrDivisionByZero},
+		{"multiply_overflow", Multiply, 1e300, 1e300, "m", "m", 0, "", calculator.ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New(tt.a, mustUnit(t, tt.ua))
+			b := New(tt.b, mustUnit(t, tt.ub))
+			got, err := tt.fn(a, b)
+			if tt.err != nil {
+				var domainErr *calculator.DomainError
+				if !errors.Is(err, tt.err) || !errors.As(err, &domainErr) {
+					t.Errorf("%s(%s, %s) error = %v, want %v", tt.name, a, b, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s(%s, %s) unexpected error: %v", tt.name, a, b, err)
+			}
+			if !near(got.Value(), tt.expected) || got.Unit().Name != tt.unit {
+				t.Errorf("%s(%s, %s) = %s, want %v %s", tt.name, a, b, got, tt.expected, tt.unit)
+			}
+		})
+	}
+}
+
+func TestQuantityIn(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		expected float64
+		err      error
+	}{
+		{1, "mi", "km", 1.609344, nil},
+		{100, "kph", "mph", 62.13711922373341, nil},
+		{1, "KiB", "B", 1024, nil},
+		{1, "GB", "MiB", 953.67431640625, nil},
+		{1, "kWh", "J", 3.6e6, nil},
+		{1, "atm", "psi", 14.695948775513449, nil},
+		{1, "kg", "s", 0, ErrDimensionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"_in_"+tt.to, func(t *testing.T) {
+			q := New(tt.value, mustUnit(t, tt.from))
+			got, err := q.In(mustUnit(t, tt.to))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("%s.In(%s) error = %v, want %v", q, tt.to, err, tt.err)
+			}
+			if err == nil && !near(got.Value(), tt.expected) {
+				t.Errorf("%s.In(%s) = %s, want %v", q, tt.to, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuantityPowers(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(q Quantity) (Quantity, error)
+		value    float64
+		unit     string
+		expected string
+		err      error
+	}{
+		{"square", func(q Quantity) (Quantity, error) { return Pow(q, 2) }, 3, "m", "9 m^2", nil},
+		{"square_compound", func(q Quantity) (Quantity, error) { return Pow(q, 2) }, 2, "mph", "4 mph^2", nil},
+		{"inverse", func(q Quantity) (Quantity, error) { return Pow(q, -1) }, 4, "s", "0.25 s^-1", nil},
+		{"fractional", func(q Quantity) (Quantity, error) { return Pow(q, 0.5) }, 4, "m", "", ErrFractionalPower},
+		{"sqrt", Sqrt, 16, "ha", "400 m", nil},
+		{"sqrt_odd", Sqrt, 16, "m", "", ErrFractionalPower},
+		{"sqrt_negative", Sqrt, -16, "ha", "", calculator.ErrNegativeSquareRoot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := New(tt.value, mustUnit(t, tt.unit))
+			got, err := tt.fn(q)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("%s(%s) error = %v, want %v", tt.name, q, err, tt.err)
+			}
+			if err == nil && got.String() != tt.expected {
+				t.Errorf("%s(%s) = %s, want %s", tt.name, q, got, tt.expected)
+			}
+		})
+	}
+}
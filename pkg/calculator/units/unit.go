@@ -0,0 +1,221 @@
+package units
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/PingDavidR/go-release-test/pkg/calculator/expr"
+)
+
+// Unit is a named unit of measurement.
+type Unit struct {
+	// Name is how the unit is displayed, e.g. "km" or "m/s^2".
+	Name string
+	// Factor is the size of the unit in the base units of its dimension,
+	// e.g. 1000 for km.
+	Factor float64
+	// Dim is the dimension the unit measures.
+	Dim Dimension
+}
+
+// String returns the name of the unit.
+func (u Unit) String() string {
+	return u.Name
+}
+
+// prefix is an SI or binary prefix, such as k or Ki, with its long name.
+type prefix struct {
+	symbol, name string
+	factor       float64
+}
+
+// prefixes lists the prefixes Lookup accepts before prefixable units. Where
+// one symbol starts with another, the longer one comes first, so "dam" is a
+// decameter rather than a deci-am.
+var prefixes = []prefix{
+	{"Ki", "kibi", 1 << 10},
+	{"Mi", "mebi", 1 << 20},
+	{"Gi", "gibi", 1 << 30},
+	{"Ti", "tebi", 1 << 40},
+	{"Pi", "pebi", 1 << 50},
+	{"Ei", "exbi", 1 << 60},
+	{"Q", "quetta", 1e30},
+	{"R", "ronna", 1e27},
+	{"Y", "yotta", 1e24},
+	{"Z", "zetta", 1e21},
+	{"E", "exa", 1e18},
+	{"P", "peta", 1e15},
+	{"T", "tera", 1e12},
+	{"G", "giga", 1e9},
+	{"M", "mega", 1e6},
+	{"k", "kilo", 1e3},
+	{"h", "hecto", 1e2},
+	{"da", "deca", 1e1},
+	{"d", "deci", 1e-1},
+	{"c", "centi", 1e-2},
+	{"m", "milli", 1e-3},
+	{"µ", "micro", 1e-6},
+	{"u", "micro", 1e-6},
+	{"n", "nano", 1e-9},
+	{"p", "pico", 1e-12},
+	{"f", "femto", 1e-15},
+	{"a", "atto", 1e-18},
+	{"z", "zepto", 1e-21},
+	{"y", "yocto", 1e-24},
+	{"r", "ronto", 1e-27},
+	{"q", "quecto", 1e-30},
+}
+
+//go:embed units.txt
+var defaultUnits string
+
+// Table is a set of named units.
+type Table struct {
+	units map[string]Unit
+	// prefixable holds the names that accept prefixes.
+	prefixable map[string]bool
+}
+
+// NewTable returns an empty table.
+func NewTable() *Table {
+	return &Table{units: make(map[string]Unit), prefixable: make(map[string]bool)}
+}
+
+// DefaultTable returns a table holding the SI base and derived units and
+// common time, imperial, US customary and data size units.
+func DefaultTable() *Table {
+	t := NewTable()
+	if err := t.Load(strings.NewReader(defaultUnits)); err != nil {
+		panic(fmt.Sprintf("units: invalid default units: %v", err))
+	}
+	return t
+}
+
+// Load adds the unit definitions read from r to the table, replacing units
+// of the same name. Each line lists the names of a unit, then "=", then its
+// definition, which is "!" followed by the name of a base dimension
+// (length, mass, time, current, temperature, amount, luminosity or
+// information), or an expression in units already defined:
+//
+//	furlong furlongs = 220 yd
+//	kn knot = nmi/h
+//
+// Names ending in "*" also accept SI and binary prefixes. Blank lines and
+// lines starting with "#" are ignored.
+// Returns an error naming the line of the first invalid definition.
+func (t *Table) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	env := &expr.Env{}
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := t.define(text, env); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// define adds the units defined by one line of a definition file.
+func (t *Table) define(line string, env *expr.Env) error {
+	names, definition, ok := strings.Cut(line, "=")
+	if !ok {
+		return fmt.Errorf("missing \"=\" in %q", line)
+	}
+	fields := strings.Fields(names)
+	if len(fields) == 0 {
+		return fmt.Errorf("no unit names in %q", line)
+	}
+
+	definition = strings.TrimSpace(definition)
+	var u Unit
+	if strings.HasPrefix(definition, "!") {
+		dim, err := parseDimension(strings.TrimPrefix(definition, "!"))
+		if err != nil {
+			return err
+		}
+		u = Unit{Factor: 1, Dim: dim}
+	} else {
+		node, err := expr.Parse(definition)
+		if err != nil {
+			return fmt.Errorf("invalid definition %q: %w", definition, err)
+		}
+		q, err := t.EvalNode(node, env)
+		if err != nil {
+			return fmt.Errorf("invalid definition %q: %w", definition, err)
+		}
+		u = Unit{Factor: q.si, Dim: q.dim}
+	}
+
+	for _, name := range fields {
+		prefixable := strings.HasSuffix(name, "*")
+		name = strings.TrimSuffix(name, "*")
+		if name == "" || !isIdent(name) {
+			return fmt.Errorf("invalid unit name %q", name)
+		}
+		u.Name = name
+		t.units[name] = u
+		t.prefixable[name] = prefixable
+	}
+	return nil
+}
+
+// isIdent reports whether name can be written in an expression.
+func isIdent(name string) bool {
+	tokens, err := expr.Tokenize(name)
+	return err == nil && len(tokens) == 2 && tokens[0].Kind == expr.Ident
+}
+
+// Lookup returns the unit called name, which may be a prefixed unit such as
+// "km" or "KiB", or a plural ending in "s" such as "meters", and whether
+// there is one.
+func (t *Table) Lookup(name string) (Unit, bool) {
+	if u, ok := t.lookupPrefixed(name); ok {
+		return u, true
+	}
+	if singular := strings.TrimSuffix(name, "s"); singular != name && len(singular) > 1 {
+		if u, ok := t.lookupPrefixed(singular); ok {
+			u.Name = name
+			return u, true
+		}
+	}
+	return Unit{}, false
+}
+
+// lookupPrefixed returns the unit called name, or a prefix followed by the
+// name of a prefixable unit.
+func (t *Table) lookupPrefixed(name string) (Unit, bool) {
+	if u, ok := t.units[name]; ok {
+		return u, true
+	}
+	for _, p := range prefixes {
+		for _, s := range []string{p.symbol, p.name} {
+			rest := strings.TrimPrefix(name, s)
+			if rest == name || !t.prefixable[rest] {
+				continue
+			}
+			u := t.units[rest]
+			return Unit{Name: name, Factor: p.factor * u.Factor, Dim: u.Dim}, true
+		}
+	}
+	return Unit{}, false
+}
+
+// Names returns the names of the units in the table, sorted, without
+// prefixed forms.
+func (t *Table) Names() []string {
+	names := make([]string, 0, len(t.units))
+	for name := range t.units {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
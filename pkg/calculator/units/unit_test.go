@@ -0,0 +1,127 @@
+package units
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const tolerance = 1e-9
+
+// near reports whether a and b agree to within a relative tolerance.
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= tolerance*math.Max(1, math.Abs(b))
+}
+
+func TestLookup(t *testing.T) {
+	table := DefaultTable()
+
+	tests := []struct {
+		name   string
+		factor float64
+		dim    string
+		found  bool
+	}{
+		{"m", 1, "m", true},
+		{"km", 1000, "m", true},
+		{"kilometer", 1000, "m", true},
+		{"kilometers", 1000, "m", true},
+		{"dam", 10, "m", true},
+		{"µs", 1e-6, "s", true},
+		{"us", 1e-6, "s", true},
+		{"ms", 1e-3, "s", true},
+		{"min", 60, "s", true},
+		{"g", 1e-3, "kg", true},
+		{"mg", 1e-6, "kg", true},
+		{"KiB", 8192, "bit", true},
+		{"MB", 8e6, "bit", true},
+		{"N", 1, "m*kg/s^2", true},
+		{"kWh", 3.6e6, "m^2*kg/s^2", true},
+		{"mi", 1609.344, "m", true},
+		{"feet", 0.3048, "m", true},
+		{"mph", 0.44704, "m/s", true},
+		{"Hz", 1, "1/s", true},
+		{"psi", 6894.757293168361, "kg/(m*s^2)", true},
+		{"kmi", 0, "", false},
+		{"furlong", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, ok := table.Lookup(tt.name)
+			if ok != tt.found {
+				t.Fatalf("Lookup(%q) found = %v, want %v", tt.name, ok, tt.found)
+			}
+			if !ok {
+				return
+			}
+			if !near(u.Factor, tt.factor) || u.Dim.String() != tt.dim {
+				t.Errorf("Lookup(%q) = %v %s, want %v %s", tt.name, u.Factor, u.Dim, tt.factor, tt.dim)
+			}
+			if u.Name != tt.name {
+				t.Errorf("Lookup(%q).Name = %q", tt.name, u.Name)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		definitions string
+		unit        string
+		factor      float64
+		expectError string
+	}{
+		{"derived", "furlong furlongs = 220 yd", "furlongs", 201.168, ""},
+		{"prefixable", "parsec* pc* = 30856775814913673 m", "kpc", 3.0856775814913673e19, ""},
+		{"comments", "# a comment\n\nsmoot = 1.7018 m", "smoot", 1.7018, ""},
+		{"new_base", "px pixel = !information", "px", 1, ""},
+		{"replace", "mi = 1852 m", "mi", 1852, ""},
+		{"missing_equals", "furlong 220 yd", "", 0, "line 1: missing"},
+		{"unknown_unit", "ok = 1 m\nbad = 3 cubits", "", 0, "line 2: invalid definition"},
+		{"unknown_dimension", "x = !money", "", 0, "unknown dimension"},
+		{"bad_name", "2x = 1 m", "", 0, "invalid unit name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := DefaultTable()
+			err := table.Load(strings.NewReader(tt.definitions))
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Errorf("Load(%q) error = %v, want %q", tt.definitions, err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load(%q) unexpected error: %v", tt.definitions, err)
+			}
+			u, ok := table.Lookup(tt.unit)
+			if !ok || !near(u.Factor, tt.factor) {
+				t.Errorf("Lookup(%q) = %v, %v, want factor %v", tt.unit, u, ok, tt.factor)
+			}
+		})
+	}
+}
+
+func TestDimensionString(t *testing.T) {
+	tests := []struct {
+		dim      Dimension
+		expected string
+	}{
+		{Dimension{}, "1"},
+		{Dimension{Length: 1}, "m"},
+		{Dimension{Length: 1, Time: -1}, "m/s"},
+		{Dimension{Time: -1}, "1/s"},
+		{Dimension{Length: 2, Mass: 1, Time: -3, Current: -1}, "m^2*kg/(s^3*A)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.dim.String(); got != tt.expected {
+				t.Errorf("String() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package calculator
+
+import "math"
+
+// Sum returns the sum of the values, using Neumaier's compensated
+// summation so that rounding errors do not accumulate: Sum(1e100, 1,
+// -1e100) is 1 where adding left to right gives 0. Sum of no values is 0.
+// Returns an error if the result overflows or a value is NaN or infinite.
+func Sum(values ...float64) (float64, error) {
+	return checkFinite("add", compensatedSum(values), values...)
+}
+
+// compensatedSum returns the sum of values, as described for Sum.
+func compensatedSum(values []float64) float64 {
+	var sum, compensation float64
+	for _, v := range values {
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			compensation += (sum - t) + v
+		} else {
+			compensation += (v - t) + sum
+		}
+		sum = t
+	}
+	if math.IsInf(sum, 0) || math.IsNaN(sum) {
+		// The compensation is meaningless once the sum is not finite
+		return sum
+	}
+	return sum + compensation
+}
+
+// Product returns the product of the values. Product of no values is 1.
+// Returns an error if the result overflows or a value is NaN or infinite.
+func Product(values ...float64) (float64, error) {
+	product := 1.0
+	for _, v := range values {
+		product *= v
+	}
+	return checkFinite("multiply", product, values...)
+}
+
+// Min returns the smallest of the values.
+// Returns an error if there are no values or a value is NaN or infinite.
+func Min(values ...float64) (float64, error) {
+	return reduce("min", math.Min, values)
+}
+
+// Max returns the largest of the values.
+// Returns an error if there are no values or a value is NaN or infinite.
+func Max(values ...float64) (float64, error) {
+	return reduce("max", math.Max, values)
+}
+
+// reduce combines values from left to right with fn.
+func reduce(op string, fn func(a, b float64) float64, values []float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, domainError(op, ErrNoOperands)
+	}
+	if !isFinite(values...) {
+		return 0, domainError(op, ErrNonFinite, floatOperands(values)...)
+	}
+	result := values[0]
+	for _, v := range values[1:] {
+		result = fn(result, v)
+	}
+	return result, nil
+}
+
+// Mean returns the arithmetic mean of the values, summed as by Sum. If the
+// sum overflows, the values are divided by their count before they are
+// summed, so Mean(1e308, 1e308) is 1e308.
+// Returns an error if there are no values or a value is NaN or infinite.
+func Mean(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, domainError("mean", ErrNoOperands)
+	}
+	n := float64(len(values))
+	mean := compensatedSum(values) / n
+	if isFinite(values...) && !isFinite(mean) {
+		scaled := make([]float64, len(values))
+		for i, v := range values {
+			scaled[i] = v / n
+		}
+		mean = compensatedSum(scaled)
+	}
+	return checkFinite("mean", mean, values...)
+}
+
+// GCD returns the greatest common divisor of integer values, which is never
+// negative. GCD(0, 0) is 0.
+// Returns an error if there are no values or a value is not an integer.
+func GCD(values ...float64) (float64, error) {
+	if err := checkIntegers("gcd", values); err != nil {
+		return 0, err
+	}
+	result := 0.0
+	for _, v := range values {
+		result = gcd(result, v)
+	}
+	return result, nil
+}
+
+// LCM returns the least common multiple of integer values, which is never
+// negative. The LCM of values including 0 is 0.
+// Returns an error if there are no values, a value is not an integer or
+// the result overflows.
+func LCM(values ...float64) (float64, error) {
+	if err := checkIntegers("lcm", values); err != nil {
+		return 0, err
+	}
+	result := 1.0
+	for _, v := range values {
+		if v == 0 {
+			return 0, nil
+		}
+		result = math.Abs(result / gcd(result, v) * v)
+		if math.IsInf(result, 0) {
+			return 0, domainError("lcm", ErrOverflow, floatOperands(values)...)
+		}
+	}
+	return result, nil
+}
+
+// gcd returns the greatest common divisor of two integers by Euclid's
+// algorithm, which math.Mod performs exactly.
+func gcd(a, b float64) float64 {
+	a, b = math.Abs(a), math.Abs(b)
+	for b != 0 {
+		a, b = b, math.Mod(a, b)
+	}
+	return a
+}
+
+// checkIntegers reports an error for op unless values holds at least one
+// value and all of them are finite integers.
+func checkIntegers(op string, values []float64) error {
+	if len(values) == 0 {
+		return domainError(op, ErrNoOperands)
+	}
+	for _, v := range values {
+		if !isFinite(v) {
+			return domainError(op, ErrNonFinite, floatOperands(values)...)
+		}
+		if v != math.Trunc(v) {
+			return domainError(op, ErrNotInteger, floatOperands(values)...)
+		}
+	}
+	return nil
+}
+
+// floatOperands converts values for DomainError.Operands.
+func floatOperands(values []float64) []interface{} {
+	operands := make([]interface{}, len(values))
+	for i, v := range values {
+		operands[i] = v
+	}
+	return operands
+}
@@ -0,0 +1,117 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []float64
+		expected    float64
+		expectError error
+	}{
+		{"empty", nil, 0, nil},
+		{"single", []float64{5}, 5, nil},
+		{"several", []float64{1, 2, 3}, 6, nil},
+		{"cancellation", []float64{1e100, 1, -1e100}, 1, nil},
+		{"small_after_large", []float64{1, 1e-16, 1e-16, 1e-16, 1e-16}, 1.0000000000000004, nil},
+		{"tenths", []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}, 1, nil},
+		{"infinite", []float64{1, math.Inf(1), 2}, 0, ErrNonFinite},
+		{"nan", []float64{math.NaN(), 1}, 0, ErrNonFinite},
+		{"overflow", []float64{1e308, 1e308}, 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Sum(tt.values...)
+			if !errors.Is(err, tt.expectError) {
+				t.Fatalf("Sum(%v) error = %v, want %v", tt.values, err, tt.expectError)
+			}
+			if err == nil && got != tt.expected {
+				t.Errorf("Sum(%v) = %v, want %v", tt.values, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProduct(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []float64
+		expected    float64
+		expectError error
+	}{
+		{"empty", nil, 1, nil},
+		{"several", []float64{2, 3, 4}, 24, nil},
+		{"zero", []float64{2, 0, 4}, 0, nil},
+		{"negative", []float64{-2, 3}, -6, nil},
+		{"infinite", []float64{0, math.Inf(-1)}, 0, ErrNonFinite},
+		{"overflow", []float64{1e200, 1e200}, 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Product(tt.values...)
+			if !errors.Is(err, tt.expectError) {
+				t.Fatalf("Product(%v) error = %v, want %v", tt.values, err, tt.expectError)
+			}
+			if err == nil && got != tt.expected {
+				t.Errorf("Product(%v) = %v, want %v", tt.values, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReductions(t *testing.T) {
+	tests := []struct {
+		name        string
+		fn          func(...float64) (float64, error)
+		values      []float64
+		expected    float64
+		expectError error
+	}{
+		{"min", Min, []float64{3, -1, 2}, -1, nil},
+		{"min_single", Min, []float64{7}, 7, nil},
+		{"min_empty", Min, nil, 0, ErrNoOperands},
+		{"min_nan", Min, []float64{1, math.NaN()}, 0, ErrNonFinite},
+		{"max", Max, []float64{3, -1, 2}, 3, nil},
+		{"max_empty", Max, nil, 0, ErrNoOperands},
+		{"mean", Mean, []float64{1, 2, 3, 4}, 2.5, nil},
+		{"mean_compensated", Mean, []float64{1e100, 1, -1e100}, 1.0 / 3, nil},
+		{"mean_empty", Mean, nil, 0, ErrNoOperands},
+		{"mean_infinite", Mean, []float64{math.Inf(1), 1}, 0, ErrNonFinite},
+		{"mean_large", Mean, []float64{1e308, 1e308}, 1e308, nil},
+		{"mean_large_negative", Mean, []float64{-1.7e308, -1.7e308, 1.7e308}, -1.7e308 / 3, nil},
+		{"gcd", GCD, []float64{12, 18, 24}, 6, nil},
+		{"gcd_negative", GCD, []float64{-12, 18}, 6, nil},
+		{"gcd_coprime", GCD, []float64{9, 28}, 1, nil},
+		{"gcd_with_zero", GCD, []float64{0, 5}, 5, nil},
+		{"gcd_zeros", GCD, []float64{0, 0}, 0, nil},
+		{"gcd_large", GCD, []float64{1 << 52, 3 << 40}, 1 << 40, nil},
+		{"gcd_fraction", GCD, []float64{1.5, 3}, 0, ErrNotInteger},
+		{"gcd_infinite", GCD, []float64{math.Inf(1), 3}, 0, ErrNonFinite},
+		{"gcd_empty", GCD, nil, 0, ErrNoOperands},
+		{"lcm", LCM, []float64{4, 6, 10}, 60, nil},
+		{"lcm_negative", LCM, []float64{-4, 6}, 12, nil},
+		{"lcm_single", LCM, []float64{-7}, 7, nil},
+		{"lcm_with_zero", LCM, []float64{4, 0, 6}, 0, nil},
+		{"lcm_fraction", LCM, []float64{4, 0.5}, 0, ErrNotInteger},
+		{"lcm_overflow", LCM, []float64{math.Pow(3, 33), math.Pow(2, 1000)}, 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.values...)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("error = %v, want %v", err, tt.expectError)
+				return
+			}
+			if tt.expectError == nil && got != tt.expected {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
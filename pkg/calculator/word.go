@@ -0,0 +1,272 @@
+package calculator
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// Word is a fixed-width binary integer type, such as int8 or uint64, for
+// bitwise operations and overflow-checked arithmetic. Signed words hold
+// negative values in two's complement. Operands must fit in the word, and
+// results that do not fit are reported as overflows rather than wrapped.
+type Word struct {
+	// Bits is the width of the word: 8, 16, 32 or 64.
+	Bits uint
+	// Signed reports whether the word holds negative values.
+	Signed bool
+}
+
+// wordSizes lists the widths a Word may have.
+var wordSizes = []uint{8, 16, 32, 64}
+
+// ParseWord converts a Go type name, int8 to int64 or uint8 to uint64, into
+// a Word.
+func ParseWord(name string) (Word, error) {
+	for _, signed := range []bool{true, false} {
+		for _, size := range wordSizes {
+			if w := (Word{Bits: size, Signed: signed}); w.String() == name {
+				return w, nil
+			}
+		}
+	}
+	return Word{}, fmt.Errorf("unknown word size %q (want %s)", name, strings.Join(WordNames(), ", "))
+}
+
+// WordNames returns the names ParseWord accepts.
+func WordNames() []string {
+	var names []string
+	for _, prefix := range []string{"int", "uint"} {
+		for _, size := range wordSizes {
+			names = append(names, fmt.Sprintf("%s%d", prefix, size))
+		}
+	}
+	return names
+}
+
+// String returns the Go name of the word, e.g. "int32".
+func (w Word) String() string {
+	if w.Signed {
+		return fmt.Sprintf("int%d", w.Bits)
+	}
+	return fmt.Sprintf("uint%d", w.Bits)
+}
+
+// Min returns the smallest value the word holds.
+func (w Word) Min() Integer {
+	if !w.Signed {
+		return NewInteger(0)
+	}
+	return Integer{i: new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), w.Bits-1))}
+}
+
+// Max returns the largest value the word holds.
+func (w Word) Max() Integer {
+	width := w.Bits
+	if w.Signed {
+		width--
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), width)
+	return Integer{i: limit.Sub(limit, big.NewInt(1))}
+}
+
+// fits reports whether n is between Min and Max.
+func (w Word) fits(n Integer) bool {
+	return n.i.Cmp(w.Min().i) >= 0 && n.i.Cmp(w.Max().i) <= 0
+}
+
+// mask returns a bit pattern with the low w.Bits bits set.
+func (w Word) mask() uint64 {
+	return ^uint64(0) >> (64 - w.Bits)
+}
+
+// patterns returns the bit patterns of the operands of op.
+// Returns an error if an operand does not fit in the word.
+func (w Word) patterns(op string, operands ...Integer) ([]uint64, error) {
+	patterns := make([]uint64, len(operands))
+	for i, n := range operands {
+		if !w.fits(n) {
+			return nil, domainError(op, ErrOutOfRange, integerOperands(operands)...)
+		}
+		if n.i.Sign() < 0 {
+			patterns[i] = uint64(n.i.Int64()) & w.mask()
+		} else {
+			patterns[i] = n.i.Uint64()
+		}
+	}
+	return patterns, nil
+}
+
+// value returns the value of the bit pattern p in the word.
+func (w Word) value(p uint64) Integer {
+	p &= w.mask()
+	if w.Signed && p>>(w.Bits-1) == 1 {
+		// Sign-extend to 64 bits
+		return NewInteger(int64(p | ^w.mask()))
+	}
+	return Integer{i: new(big.Int).SetUint64(p)}
+}
+
+// check returns result if it fits in the word.
+// Returns an error if an operand or the result does not fit.
+func (w Word) check(op string, result Integer, operands ...Integer) (Integer, error) {
+	if _, err := w.patterns(op, operands...); err != nil {
+		return Integer{}, err
+	}
+	if !w.fits(result) {
+		return Integer{}, domainError(op, ErrOverflow, integerOperands(operands)...)
+	}
+	return result, nil
+}
+
+// Add returns the sum of the values.
+// Returns an error if a value or the sum does not fit in the word.
+func (w Word) Add(values ...Integer) (Integer, error) {
+	return w.check("add", AddInteger(values...), values...)
+}
+
+// Subtract returns a - b.
+// Returns an error if an operand or the difference does not fit in the word.
+func (w Word) Subtract(a, b Integer) (Integer, error) {
+	return w.check("subtract", SubtractInteger(a, b), a, b)
+}
+
+// Multiply returns the product of the values.
+// Returns an error if a value or the product does not fit in the word.
+func (w Word) Multiply(values ...Integer) (Integer, error) {
+	return w.check("multiply", MultiplyInteger(values...), values...)
+}
+
+// And returns the bitwise AND of a and b.
+// Returns an error if an operand does not fit in the word.
+func (w Word) And(a, b Integer) (Integer, error) {
+	return w.bitwise("and", func(x, y uint64) uint64 { return x & y }, a, b)
+}
+
+// Or returns the bitwise OR of a and b.
+// Returns an error if an operand does not fit in the word.
+func (w Word) Or(a, b Integer) (Integer, error) {
+	return w.bitwise("or", func(x, y uint64) uint64 { return x | y }, a, b)
+}
+
+// Xor returns the bitwise exclusive OR of a and b.
+// Returns an error if an operand does not fit in the word.
+func (w Word) Xor(a, b Integer) (Integer, error) {
+	return w.bitwise("xor", func(x, y uint64) uint64 { return x ^ y }, a, b)
+}
+
+// Not returns the bitwise complement of a, which is -a - 1 in a signed word
+// and Max - a in an unsigned one.
+// Returns an error if a does not fit in the word.
+func (w Word) Not(a Integer) (Integer, error) {
+	p, err := w.patterns("not", a)
+	if err != nil {
+		return Integer{}, err
+	}
+	return w.value(^p[0]), nil
+}
+
+// bitwise combines the bit patterns of a and b with fn.
+func (w Word) bitwise(op string, fn func(x, y uint64) uint64, a, b Integer) (Integer, error) {
+	p, err := w.patterns(op, a, b)
+	if err != nil {
+		return Integer{}, err
+	}
+	return w.value(fn(p[0], p[1])), nil
+}
+
+// ShiftLeft returns a shifted left by n bits, that is a * 2^n.
+// Returns an error if a does not fit in the word, n is negative or the
+// result overflows, meaning bits would be shifted out of the word or into
+// the sign bit.
+func (w Word) ShiftLeft(a, n Integer) (Integer, error) {
+	if err := w.checkShift("shl", a, n); err != nil {
+		return Integer{}, err
+	}
+	if a.i.Sign() == 0 {
+		return a, nil
+	}
+	if n.i.Cmp(big.NewInt(int64(w.Bits))) >= 0 {
+		return Integer{}, domainError("shl", ErrOverflow, a, n)
+	}
+	result := Integer{i: new(big.Int).Lsh(a.i, uint(n.i.Uint64()))}
+	if !w.fits(result) {
+		return Integer{}, domainError("shl", ErrOverflow, a, n)
+	}
+	return result, nil
+}
+
+// ShiftRight returns a shifted right by n bits, rounding towards negative
+// infinity, so signed words shift arithmetically and keep their sign.
+// Returns an error if a does not fit in the word or n is negative.
+func (w Word) ShiftRight(a, n Integer) (Integer, error) {
+	if err := w.checkShift("shr", a, n); err != nil {
+		return Integer{}, err
+	}
+	shift := w.Bits
+	if n.i.Cmp(big.NewInt(int64(w.Bits))) < 0 {
+		shift = uint(n.i.Uint64())
+	}
+	return Integer{i: new(big.Int).Rsh(a.i, shift)}, nil
+}
+
+// checkShift reports an error for op unless a fits in the word and the
+// shift count n is not negative.
+func (w Word) checkShift(op string, a, n Integer) error {
+	if _, err := w.patterns(op, a); err != nil {
+		return err
+	}
+	if n.i.Sign() < 0 {
+		return domainError(op, ErrUndefined, a, n)
+	}
+	return nil
+}
+
+// RotateLeft returns a with its bits rotated left by n, so bits shifted out
+// of the top of the word reappear at the bottom. A negative n rotates
+// right.
+// Returns an error if a does not fit in the word.
+func (w Word) RotateLeft(a, n Integer) (Integer, error) {
+	return w.rotate("rotl", a, n.i)
+}
+
+// RotateRight returns a with its bits rotated right by n. A negative n
+// rotates left.
+// Returns an error if a does not fit in the word.
+func (w Word) RotateRight(a, n Integer) (Integer, error) {
+	return w.rotate("rotr", a, new(big.Int).Neg(n.i))
+}
+
+// rotate rotates the bits of a left by n, modulo the width of the word.
+func (w Word) rotate(op string, a Integer, n *big.Int) (Integer, error) {
+	p, err := w.patterns(op, a)
+	if err != nil {
+		return Integer{}, err
+	}
+	k := uint(new(big.Int).Mod(n, big.NewInt(int64(w.Bits))).Uint64())
+	if k == 0 {
+		return a, nil
+	}
+	return w.value(p[0]<<k | p[0]>>(w.Bits-k)), nil
+}
+
+// PopCount returns the number of set bits in a, counting the sign bits of
+// a negative value in a signed word.
+// Returns an error if a does not fit in the word.
+func (w Word) PopCount(a Integer) (Integer, error) {
+	p, err := w.patterns("popcount", a)
+	if err != nil {
+		return Integer{}, err
+	}
+	return NewInteger(int64(bits.OnesCount64(p[0]))), nil
+}
+
+// integerOperands converts values for DomainError.Operands.
+func integerOperands(values []Integer) []interface{} {
+	operands := make([]interface{}, len(values))
+	for i, v := range values {
+		operands[i] = v
+	}
+	return operands
+}
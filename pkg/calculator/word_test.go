@@ -0,0 +1,161 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+// mustParseWord parses a word size or fails the test
+func mustParseWord(t *testing.T, name string) Word {
+	t.Helper()
+	w, err := ParseWord(name)
+	if err != nil {
+		t.Fatalf("ParseWord(%q) unexpected error: %v", name, err)
+	}
+	return w
+}
+
+func TestParseWord(t *testing.T) {
+	tests := []struct {
+		name      string
+		expected  Word
+		shouldErr bool
+	}{
+		{"int8", Word{Bits: 8, Signed: true}, false},
+		{"uint16", Word{Bits: 16}, false},
+		{"int64", Word{Bits: 64, Signed: true}, false},
+		{"uint64", Word{Bits: 64}, false},
+		{"int128", Word{}, true},
+		{"byte", Word{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWord(tt.name)
+			if (err != nil) != tt.shouldErr {
+				t.Fatalf("ParseWord(%q) error = %v, shouldErr %v", tt.name, err, tt.shouldErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseWord(%q) = %+v, want %+v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordLimits(t *testing.T) {
+	tests := []struct {
+		word     string
+		min, max string
+	}{
+		{"int8", "-128", "127"},
+		{"uint8", "0", "255"},
+		{"int64", "-9223372036854775808", "9223372036854775807"},
+		{"uint64", "0", "18446744073709551615"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			w := mustParseWord(t, tt.word)
+			if got := w.Min().String(); got != tt.min {
+				t.Errorf("%s.Min() = %s, want %s", tt.word, got, tt.min)
+			}
+			if got := w.Max().String(); got != tt.max {
+				t.Errorf("%s.Max() = %s, want %s", tt.word, got, tt.max)
+			}
+		})
+	}
+}
+
+func TestWordOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		fn       func(w Word, args []Integer) (Integer, error)
+		args     []string
+		expected string
+		err      error
+	}{
+		{"and", "uint8", func(w Word, a []Integer) (Integer, error) { return w.And(a[0], a[1]) }, []string{"0b1100", "0b1010"}, "8", nil},
+		{"and_signed", "int8", func(w Word, a []Integer) (Integer, error) { return w.And(a[0], a[1]) }, []string{"-1", "0x7f"}, "127", nil},
+		{"or", "uint8", func(w Word, a []Integer) (Integer, error) { return w.Or(a[0], a[1]) }, []string{"0b1100", "0b1010"}, "14", nil},
+		{"xor", "uint8", func(w Word, a []Integer) (Integer, error) { return w.Xor(a[0], a[1]) }, []string{"0b1100", "0b1010"}, "6", nil},
+		{"xor_to_negative", "int8", func(w Word, a []Integer) (Integer, error) { return w.Xor(a[0], a[1]) }, []string{"127", "-1"}, "-128", nil},
+		{"and_out_of_range", "uint8", func(w Word, a []Integer) (Integer, error) { return w.And(a[0], a[1]) }, []string{"256", "1"}, "", ErrOutOfRange},
+		{"or_negative_unsigned", "uint8", func(w Word, a []Integer) (Integer, error) { return w.Or(a[0], a[1]) }, []string{"-1", "1"}, "", ErrOutOfRange},
+		{"not_unsigned", "uint8", func(w Word, a []Integer) (Integer, error) { return w.Not(a[0]) }, []string{"0"}, "255", nil},
+		{"not_signed", "int32", func(w Word, a []Integer) (Integer, error) { return w.Not(a[0]) }, []string{"5"}, "-6", nil},
+		{"not_uint64", "uint64", func(w Word, a []Integer) (Integer, error) { return w.Not(a[0]) }, []string{"0"}, "18446744073709551615", nil},
+		{"shl", "uint8", func(w Word, a []Integer) (Integer, error) { return w.ShiftLeft(a[0], a[1]) }, []string{"3", "4"}, "48", nil},
+		{"shl_negative", "int8", func(w Word, a []Integer) (Integer, error) { return w.ShiftLeft(a[0], a[1]) }, []string{"-1", "7"}, "-128", nil},
+		{"shl_overflow", "uint8", func(w Word, a []Integer) (Integer, error) { return w.ShiftLeft(a[0], a[1]) }, []string{"0x80", "1"}, "", ErrOverflow},
+		{"shl_into_sign_bit", "int8", func(w Word, a []Integer) (Integer, error) { return w.ShiftLeft(a[0], a[1]) }, []string{"64", "1"}, "", ErrOverflow},
+		{"shl_past_width", "uint64", func(w Word, a []Integer) (Integer, error) { return w.ShiftLeft(a[0], a[1]) }, []string{"1", "64"}, "", ErrOverflow},
+		{"shl_zero_past_width", "uint8", func(w Word, a []Integer) (Integer, error) { return w.ShiftLeft(a[0], a[1]) }, []string{"0", "100"}, "0", nil},
+		{"shl_negative_count", "uint8", func(w Word, a []Integer) (Integer, error) { return w.ShiftLeft(a[0], a[1]) }, []string{"1", "-1"}, "", ErrUndefined},
+		{"shr", "uint8", func(w Word, a []Integer) (Integer, error) { return w.ShiftRight(a[0], a[1]) }, []string{"0xf0", "4"}, "15", nil},
+		{"shr_arithmetic", "int8", func(w Word, a []Integer) (Integer, error) { return w.ShiftRight(a[0], a[1]) }, []string{"-128", "3"}, "-16", nil},
+		{"shr_past_width", "int8", func(w Word, a []Integer) (Integer, error) { return w.ShiftRight(a[0], a[1]) }, []string{"-5", "100"}, "-1", nil},
+		{"rotl", "uint8", func(w Word, a []Integer) (Integer, error) { return w.RotateLeft(a[0], a[1]) }, []string{"0b10000001", "1"}, "3", nil},
+		{"rotl_full_turn", "uint8", func(w Word, a []Integer) (Integer, error) { return w.RotateLeft(a[0], a[1]) }, []string{"0x5a", "8"}, "90", nil},
+		{"rotl_negative", "uint8", func(w Word, a []Integer) (Integer, error) { return w.RotateLeft(a[0], a[1]) }, []string{"1", "-1"}, "128", nil},
+		{"rotr", "uint16", func(w Word, a []Integer) (Integer, error) { return w.RotateRight(a[0], a[1]) }, []string{"1", "1"}, "32768", nil},
+		{"rotr_signed", "int8", func(w Word, a []Integer) (Integer, error) { return w.RotateRight(a[0], a[1]) }, []string{"1", "1"}, "-128", nil},
+		{"rotr_uint64", "uint64", func(w Word, a []Integer) (Integer, error) { return w.RotateRight(a[0], a[1]) }, []string{"1", "1"}, "9223372036854775808", nil},
+		{"popcount", "uint32", func(w Word, a []Integer) (Integer, error) { return w.PopCount(a[0]) }, []string{"0xff0f"}, "12", nil},
+		{"popcount_negative", "int16", func(w Word, a []Integer) (Integer, error) { return w.PopCount(a[0]) }, []string{"-1"}, "16", nil},
+		{"add", "int8", func(w Word, a []Integer) (Integer, error) { return w.Add(a...) }, []string{"100", "27"}, "127", nil},
+		{"add_overflow", "int8", func(w Word, a []Integer) (Integer, error) { return w.Add(a...) }, []string{"100", "28"}, "", ErrOverflow},
+		{"subtract_underflow", "uint32", func(w Word, a []Integer) (Integer, error) { return w.Subtract(a[0], a[1]) }, []string{"1", "2"}, "", ErrOverflow},
+		{"multiply", "int64", func(w Word, a []Integer) (Integer, error) { return w.Multiply(a...) }, []string{"3037000499", "3037000499"}, "9223372030926249001", nil},
+		{"multiply_overflow", "int64", func(w Word, a []Integer) (Integer, error) { return w.Multiply(a...) }, []string{"3037000500", "3037000500"}, "", ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(mustParseWord(t, tt.word), mustParseIntegers(t, tt.args))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("%s %s(%v) error = %v, want %v", tt.word, tt.name, tt.args, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s %s(%v) unexpected error: %v", tt.word, tt.name, tt.args, err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("%s %s(%v) = %s, want %s", tt.word, tt.name, tt.args, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIntegerText(t *testing.T) {
+	tests := []struct {
+		input    string
+		base     int
+		expected string
+	}{
+		{"255", 16, "0xff"},
+		{"-255", 16, "-0xff"},
+		{"10", 2, "0b1010"},
+		{"8", 8, "0o10"},
+		{"35", 36, "z"},
+		{"-42", 10, "-42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			n := mustParseInteger(t, tt.input)
+			got := n.Text(tt.base)
+			if got != tt.expected {
+				t.Errorf("Text(%s, %d) = %s, want %s", tt.input, tt.base, got, tt.expected)
+			}
+			if tt.base == 36 {
+				return
+			}
+			if back := mustParseInteger(t, got); back.String() != n.String() {
+				t.Errorf("ParseInteger(%q) = %s, want %s", got, back, n)
+			}
+		})
+	}
+}
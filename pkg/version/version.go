@@ -41,3 +41,23 @@ func Info() string {
 func ShortInfo() string {
 	return fmt.Sprintf("v%s (%s)", Version, GitCommit)
 }
+
+// BuildInfo holds every version variable, for serialization.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// Current returns the version variables as a BuildInfo.
+func Current() BuildInfo {
+	return BuildInfo{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildDate: BuildDate,
+		GoVersion: GoVersion,
+		Platform:  Platform,
+	}
+}
@@ -103,3 +103,34 @@ func TestRuntimeValues(t *testing.T) {
 			expectedPlatform, Platform)
 	}
 }
+
+func TestCurrent(t *testing.T) {
+	// Save original values
+	origVersion := Version
+	origGitCommit := GitCommit
+	origBuildDate := BuildDate
+
+	// Restore original values after test
+	defer func() {
+		Version = origVersion
+		GitCommit = origGitCommit
+		BuildDate = origBuildDate
+	}()
+
+	// Set test values
+	Version = "1.2.3"
+	GitCommit = "abcdef123456"
+	BuildDate = "2025-07-29"
+
+	// Check that every variable is captured
+	expected := BuildInfo{
+		Version:   "1.2.3",
+		GitCommit: "abcdef123456",
+		BuildDate: "2025-07-29",
+		GoVersion: GoVersion,
+		Platform:  Platform,
+	}
+	if got := Current(); got != expected {
+		t.Errorf("Current() returned incorrect value.\nExpected: %+v\nGot: %+v", expected, got)
+	}
+}